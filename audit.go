@@ -0,0 +1,178 @@
+package redisx
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AuditRecord 一条命令审计记录
+type AuditRecord struct {
+	Time     time.Time         // 命令开始时间
+	Command  string            // 命令名
+	Keys     []string          // 涉及的键
+	Err      error             // 命令错误（键不存在不视为错误）
+	Metadata map[string]string // 调用方通过 WithAuditMetadata 附加到 context 的信息（如用户、请求ID），见 WithAuditMetadata
+}
+
+// AuditSink 审计记录的接收方，在命令执行的 goroutine 中同步调用，耗时操作应自行异步处理
+type AuditSink interface {
+	Record(record AuditRecord)
+}
+
+// AuditSinkFunc 以函数实现 AuditSink
+type AuditSinkFunc func(record AuditRecord)
+
+// Record 实现 AuditSink
+func (f AuditSinkFunc) Record(record AuditRecord) {
+	f(record)
+}
+
+// AuditOptions 命令审计配置
+type AuditOptions struct {
+	Sink        AuditSink // 为 nil 时关闭审计
+	SampleRate  float64   // 采样比例（0~1]，<=0 时记录全部
+	KeyPatterns []string  // 只记录键匹配任一模式（glob 语法，支持 * 和 ?）的命令，为空时不按键过滤
+}
+
+// auditMetadataKey context 中审计信息的键
+type auditMetadataKey struct{}
+
+// WithAuditMetadata 在 context 中附加审计信息，与已有信息合并
+// 只有接收 context 参数的接口（RedisPipeline.ExecCtx、DelCtx、BZPopMin/BZPopMax、XRead、XReadGroup 等）执行的命令会携带这些信息；
+// 其余接口使用 rm 的默认 context，记录中 Metadata 为空，需要按调用方审计的命令可放入 Pipeline 后以 ExecCtx 执行
+func WithAuditMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	merged := make(map[string]string)
+	for k, v := range AuditMetadata(ctx) {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, auditMetadataKey{}, merged)
+}
+
+// AuditMetadata 获取 context 中的审计信息
+func AuditMetadata(ctx context.Context) map[string]string {
+	metadata, _ := ctx.Value(auditMetadataKey{}).(map[string]string)
+	return metadata
+}
+
+// SetAudit 设置命令审计
+func (rm *RedisManager) SetAudit(opts AuditOptions) {
+	rm.auditMutex.Lock()
+	defer rm.auditMutex.Unlock()
+	rm.audit = opts
+}
+
+// auditOptions 获取命令审计配置
+func (rm *RedisManager) auditOptions() AuditOptions {
+	rm.auditMutex.RLock()
+	defer rm.auditMutex.RUnlock()
+	return rm.audit
+}
+
+// matches 键是否匹配审计的键模式
+func (o AuditOptions) matches(keys []string) bool {
+	if len(o.KeyPatterns) == 0 {
+		return true
+	}
+	for _, key := range keys {
+		for _, pattern := range o.KeyPatterns {
+			if globMatch(pattern, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// globMatch 匹配 glob 模式，* 匹配任意个字符（含 '/' 和 ':'），? 匹配单个字符
+func globMatch(pattern, s string) bool {
+	p, i := 0, 0
+	star, mark := -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, i
+			p++
+		case star >= 0:
+			p = star + 1
+			mark++
+			i = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// auditCmd 按配置采样并记录命令
+func (rm *RedisManager) auditCmd(ctx context.Context, opts AuditOptions, start time.Time, cmd redis.Cmder) {
+	keys := commandKeys(cmd)
+	if !opts.matches(keys) {
+		return
+	}
+	if opts.SampleRate > 0 && opts.SampleRate < 1 && rand.Float64() >= opts.SampleRate {
+		return
+	}
+
+	err := cmd.Err()
+	if errorCodeOf(err) == KEY_NOT_FOUND {
+		err = nil
+	}
+	opts.Sink.Record(AuditRecord{
+		Time:     start,
+		Command:  cmd.Name(),
+		Keys:     keys,
+		Err:      err,
+		Metadata: AuditMetadata(ctx),
+	})
+}
+
+// auditHook 记录命令审计的 go-redis 钩子，流水线中的命令逐条采样记录
+type auditHook struct {
+	rm *RedisManager
+}
+
+func (h auditHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h auditHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		opts := h.rm.auditOptions()
+		if opts.Sink == nil {
+			return next(ctx, cmd)
+		}
+
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.rm.auditCmd(ctx, opts, start, cmd)
+		return err
+	}
+}
+
+func (h auditHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		opts := h.rm.auditOptions()
+		if opts.Sink == nil {
+			return next(ctx, cmds)
+		}
+
+		start := time.Now()
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			h.rm.auditCmd(ctx, opts, start, cmd)
+		}
+		return err
+	}
+}
@@ -0,0 +1,144 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AutoPipelineConfig 自动流水线配置
+// 启用后 GetS/GetB、SetS/SetB、HGetS/HGetB 发出的命令在一个很短的时间窗口内收集起来，通过一条流水线发送，
+// 每个调用方仍然得到自己的 CacheResult，适合单个请求内频繁访问 Redis 的场景；命令指标中这些命令整体记为 "pipeline"
+type AutoPipelineConfig struct {
+	Window   time.Duration `json:"window,omitempty" yaml:"window,omitempty"`       // 收集窗口，从批次中第一条命令开始计时，默认 100µs
+	MaxBatch int           `json:"max_batch,omitempty" yaml:"max_batch,omitempty"` // 批次命令数达到该值时立即发送，默认 100
+}
+
+// setDefaults 设置默认值
+func (c *AutoPipelineConfig) setDefaults() {
+	if c.Window <= 0 {
+		c.Window = 100 * time.Microsecond
+	}
+	if c.MaxBatch <= 0 {
+		c.MaxBatch = 100
+	}
+}
+
+// autoPipelineCall 等待流水线结果的命令
+type autoPipelineCall struct {
+	cmd  redis.Cmder
+	done chan struct{}
+}
+
+// autoPipeliner 合并并发命令的自动流水线
+type autoPipeliner struct {
+	rm     *RedisManager
+	config AutoPipelineConfig
+
+	mu    sync.Mutex
+	batch []autoPipelineCall
+	timer *time.Timer
+}
+
+// newAutoPipeliner 创建自动流水线
+func newAutoPipeliner(rm *RedisManager, config AutoPipelineConfig) *autoPipeliner {
+	return &autoPipeliner{rm: rm, config: config}
+}
+
+// process 将命令加入当前批次并等待批次发送完成，结果和错误写入 cmd
+func (p *autoPipeliner) process(cmd redis.Cmder) {
+	call := autoPipelineCall{cmd: cmd, done: make(chan struct{})}
+
+	p.mu.Lock()
+	p.batch = append(p.batch, call)
+	switch {
+	case len(p.batch) >= p.config.MaxBatch:
+		batch := p.take()
+		p.mu.Unlock()
+		p.flush(batch)
+	case len(p.batch) == 1:
+		p.timer = time.AfterFunc(p.config.Window, p.flushPending)
+		p.mu.Unlock()
+	default:
+		p.mu.Unlock()
+	}
+
+	<-call.done
+}
+
+// take 取出当前批次，调用方需持有 p.mu
+func (p *autoPipeliner) take() []autoPipelineCall {
+	batch := p.batch
+	p.batch = nil
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	return batch
+}
+
+// flushPending 收集窗口结束时发送当前批次
+func (p *autoPipeliner) flushPending() {
+	p.mu.Lock()
+	batch := p.take()
+	p.mu.Unlock()
+
+	if len(batch) > 0 {
+		p.flush(batch)
+	}
+}
+
+// flush 通过一条流水线发送批次中的命令并唤醒等待者
+func (p *autoPipeliner) flush(batch []autoPipelineCall) {
+	defer func() {
+		for _, call := range batch {
+			close(call.done)
+		}
+	}()
+
+	client := p.rm.GetClient()
+	if client == nil {
+		for _, call := range batch {
+			call.cmd.SetErr(ErrConnectionFailed)
+		}
+		return
+	}
+
+	pipe := client.Pipeline()
+	for _, call := range batch {
+		_ = pipe.Process(p.rm.ctx, call.cmd)
+	}
+	// 服务端返回的错误已写入对应的 cmd；连接错误在 go-redis 重试耗尽后不一定写入各命令，补充到尚无错误的命令上
+	var redisErr redis.Error
+	if _, err := pipe.Exec(p.rm.ctx); err != nil && !errors.As(err, &redisErr) {
+		for _, call := range batch {
+			if call.cmd.Err() == nil {
+				call.cmd.SetErr(err)
+			}
+		}
+	}
+}
+
+// process 执行命令，启用自动流水线时与同一时间窗口内的其他命令合并发送
+func (rm *RedisManager) process(cmd redis.Cmder) {
+	if rm.autoPipeline != nil {
+		rm.autoPipeline.process(cmd)
+		return
+	}
+	_ = rm.activeClient().Process(rm.ctx, cmd)
+}
+
+// setCmd 构造与 client.Set 相同的 SET 命令
+func setCmd(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	args := []interface{}{"set", key, value}
+	switch {
+	case expiration > 0:
+		args = append(args, "px", max(1, expiration.Milliseconds()))
+	case expiration == redis.KeepTTL:
+		args = append(args, "keepttl")
+	}
+	return redis.NewStatusCmd(ctx, args...)
+}
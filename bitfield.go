@@ -0,0 +1,121 @@
+package redisx
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// BitFieldOverflow BITFIELD 溢出控制策略
+type BitFieldOverflow string
+
+const (
+	// BitFieldOverflowWrap 回绕（默认），超出范围时按模运算回绕
+	BitFieldOverflowWrap BitFieldOverflow = "WRAP"
+	// BitFieldOverflowSat 饱和，超出范围时取最大/最小值
+	BitFieldOverflowSat BitFieldOverflow = "SAT"
+	// BitFieldOverflowFail 失败，超出范围时不执行该子命令
+	BitFieldOverflowFail BitFieldOverflow = "FAIL"
+)
+
+// BitFieldValue BITFIELD 单个子命令的返回值
+type BitFieldValue struct {
+	Val int64
+	// Overflowed 为 true 表示该子命令因 FAIL 溢出策略未执行，此时 Val 无意义
+	Overflowed bool
+}
+
+// BitField BITFIELD 命令构建器，用于在一个键中打包多个定长整数计数器
+//
+// 用法:
+//
+//	res := rm.BitField("counters").
+//	    Overflow(redisx.BitFieldOverflowSat).
+//	    IncrBy("u8", "#0", 1).
+//	    Get("u8", "#1").
+//	    Exec()
+//
+// encoding 形如 "u8"、"i16"（u表示无符号，i表示有符号，数字为位宽）
+// offset 可以是位偏移（int64）或以 "#" 开头的按类型宽度计算的索引（如 "#2"）
+type BitField struct {
+	rm   *RedisManager
+	key  string
+	args []interface{}
+}
+
+// BitField 创建 BITFIELD 命令构建器
+func (rm *RedisManager) BitField(key string) *BitField {
+	return &BitField{
+		rm:  rm,
+		key: key,
+	}
+}
+
+// Get 读取指定位置的整数
+func (bf *BitField) Get(encoding string, offset interface{}) *BitField {
+	bf.args = append(bf.args, "GET", encoding, offset)
+	return bf
+}
+
+// Set 设置指定位置的整数，返回旧值
+func (bf *BitField) Set(encoding string, offset interface{}, value int64) *BitField {
+	bf.args = append(bf.args, "SET", encoding, offset, value)
+	return bf
+}
+
+// IncrBy 对指定位置的整数做增减，返回新值
+func (bf *BitField) IncrBy(encoding string, offset interface{}, incr int64) *BitField {
+	bf.args = append(bf.args, "INCRBY", encoding, offset, incr)
+	return bf
+}
+
+// Overflow 设置后续 SET/INCRBY 子命令的溢出策略
+func (bf *BitField) Overflow(mode BitFieldOverflow) *BitField {
+	bf.args = append(bf.args, "OVERFLOW", string(mode))
+	return bf
+}
+
+// Exec 执行 BITFIELD 命令，返回值与 GET/SET/INCRBY 子命令一一对应
+func (bf *BitField) Exec() CacheResult[[]BitFieldValue] {
+	rm := bf.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]BitFieldValue](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if len(bf.args) == 0 {
+		return NewCacheError[[]BitFieldValue](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	// go-redis 的 BitField 无法解析 FAIL 溢出时返回的 nil，这里使用原始命令
+	args := make([]interface{}, 0, len(bf.args)+2)
+	args = append(args, "bitfield", bf.key)
+	args = append(args, bf.args...)
+
+	val, err := rm.activeClient().Do(rm.ctx, args...).Slice()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]BitFieldValue](REDIS_INNER_ERROR, err)
+	}
+
+	result := make([]BitFieldValue, len(val))
+	for i, v := range val {
+		switch n := v.(type) {
+		case nil:
+			result[i].Overflowed = true
+		case int64:
+			result[i].Val = n
+		case string:
+			result[i].Val, err = strconv.ParseInt(n, 10, 64)
+		default:
+			err = fmt.Errorf("unexpected bitfield reply type %T", v)
+		}
+		if err != nil {
+			rm.stats.IncrError()
+			return NewCacheError[[]BitFieldValue](REDIS_INNER_ERROR, err)
+		}
+	}
+
+	return NewCacheResult(result)
+}
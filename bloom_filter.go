@@ -0,0 +1,123 @@
+package redisx
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxBloomFilterBits Redis 位图允许的最大位数（512MB）
+const maxBloomFilterBits = 1 << 32
+
+// BloomFilter 基于 SETBIT/GETBIT 实现的客户端布隆过滤器
+// 适用于未安装 RedisBloom 模块的部署环境，哈希计算在客户端完成，位读写通过 Pipeline 批量提交
+type BloomFilter struct {
+	rm        *RedisManager
+	key       string
+	size      uint64 // 位图大小（位）
+	hashCount int    // 哈希函数个数
+}
+
+// NewBloomFilter 创建布隆过滤器
+// size 为位图大小（位），hashCount 为每个元素使用的哈希函数个数
+func NewBloomFilter(rm *RedisManager, key string, size uint64, hashCount int) (*BloomFilter, error) {
+	if size == 0 || size > maxBloomFilterBits {
+		return nil, ErrInvalidConfig.WithMessage("bloom filter size must be in (0, 2^32]")
+	}
+	if hashCount <= 0 {
+		return nil, ErrInvalidConfig.WithMessage("bloom filter hash count must be positive")
+	}
+
+	return &BloomFilter{
+		rm:        rm,
+		key:       key,
+		size:      size,
+		hashCount: hashCount,
+	}, nil
+}
+
+// locations 计算元素对应的位偏移（双重哈希：h1 + i*h2）
+func (bf *BloomFilter) locations(item string) []int64 {
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(item))
+	sum := h.Sum(nil)
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:])
+
+	locs := make([]int64, bf.hashCount)
+	for i := 0; i < bf.hashCount; i++ {
+		locs[i] = int64((h1 + uint64(i)*h2) % bf.size)
+	}
+	return locs
+}
+
+// Add 添加一个或多个元素
+func (bf *BloomFilter) Add(items ...string) CacheResult[bool] {
+	if len(items) == 0 {
+		return NewCacheError[bool](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	pipe := bf.rm.Pipeline()
+	for _, item := range items {
+		for _, loc := range bf.locations(item) {
+			pipe.SetBit(bf.key, loc, 1)
+		}
+	}
+
+	result := pipe.Exec()
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+
+	return NewCacheResult(true)
+}
+
+// MightContain 判断元素是否可能存在
+// 返回 false 表示一定不存在，返回 true 表示可能存在（存在误判率）
+func (bf *BloomFilter) MightContain(item string) CacheResult[bool] {
+	result := bf.MightContainMulti(item)
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+
+	return NewCacheResult(result.Val[0])
+}
+
+// MightContainMulti 批量判断多个元素是否可能存在，结果顺序与 items 一致
+func (bf *BloomFilter) MightContainMulti(items ...string) CacheResult[[]bool] {
+	if len(items) == 0 {
+		return NewCacheError[[]bool](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	pipe := bf.rm.Pipeline()
+	cmds := make([][]*redis.IntCmd, len(items))
+	for i, item := range items {
+		for _, loc := range bf.locations(item) {
+			cmds[i] = append(cmds[i], pipe.GetBit(bf.key, loc))
+		}
+	}
+
+	result := pipe.Exec()
+	if !result.IsOK() {
+		return NewCacheError[[]bool](result.ErrCode, result.Err)
+	}
+
+	exists := make([]bool, len(items))
+	for i := range items {
+		exists[i] = true
+		for _, cmd := range cmds[i] {
+			if cmd.Val() == 0 {
+				exists[i] = false
+				break
+			}
+		}
+	}
+
+	return NewCacheResult(exists)
+}
+
+// Clear 清空布隆过滤器
+func (bf *BloomFilter) Clear() CacheResult[int64] {
+	return bf.rm.Del(bf.key)
+}
@@ -0,0 +1,269 @@
+package redisx
+
+// RedisBloom 模块命令封装（BF.* 布隆过滤器、CF.* 布谷鸟过滤器）
+// 需要服务端加载 RedisBloom 模块（Redis 8 起内置），模块不可用时返回 MODULE_NOT_LOADED
+// 未安装模块的部署可以使用基于位图实现的 BloomFilter
+
+// BFReserve 创建指定误判率和容量的布隆过滤器
+func (rm *RedisManager) BFReserve(key string, errorRate float64, capacity int64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().BFReserve(rm.ctx, key, errorRate, capacity).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BFAdd 向布隆过滤器添加元素，元素此前不存在时返回 true
+func (rm *RedisManager) BFAdd(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().BFAdd(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BFExists 判断元素是否可能存在于布隆过滤器中
+func (rm *RedisManager) BFExists(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().BFExists(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BFMAdd 向布隆过滤器批量添加元素
+func (rm *RedisManager) BFMAdd(key string, elements ...interface{}) CacheResult[[]bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().BFMAdd(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BFMExists 批量判断元素是否可能存在于布隆过滤器中
+func (rm *RedisManager) BFMExists(key string, elements ...interface{}) CacheResult[[]bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().BFMExists(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFReserve 创建指定容量的布谷鸟过滤器
+func (rm *RedisManager) CFReserve(key string, capacity int64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFReserve(rm.ctx, key, capacity).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFAdd 向布谷鸟过滤器添加元素（允许重复添加）
+func (rm *RedisManager) CFAdd(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFAdd(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFAddNX 仅当元素不存在时向布谷鸟过滤器添加元素
+func (rm *RedisManager) CFAddNX(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFAddNX(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFExists 判断元素是否可能存在于布谷鸟过滤器中
+func (rm *RedisManager) CFExists(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFExists(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFMExists 批量判断元素是否可能存在于布谷鸟过滤器中
+func (rm *RedisManager) CFMExists(key string, elements ...interface{}) CacheResult[[]bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFMExists(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFDel 从布谷鸟过滤器中删除一次元素
+func (rm *RedisManager) CFDel(key string, element interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFDel(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CFCount 获取元素在布谷鸟过滤器中的可能出现次数
+func (rm *RedisManager) CFCount(key string, element interface{}) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[int64](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CFCount(rm.ctx, key, element).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
@@ -0,0 +1,514 @@
+package redisx
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoaderFunc 缓存未命中时加载数据的函数
+type LoaderFunc[T any] func(ctx context.Context) (T, error)
+
+// Set 使用键所属命名空间的编解码器、压缩策略和默认过期时间写入结构化数据
+func (rm *RedisManager) Set(key string, value interface{}) CacheResult[string] {
+	return rm.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL 写入结构化数据，ttl<=0 时使用命名空间的默认过期时间
+func (rm *RedisManager) SetWithTTL(key string, value interface{}, ttl time.Duration) CacheResult[string] {
+	data, err := rm.encodeValue(key, value)
+	if err != nil {
+		return NewCacheError[string](INVALID_OPERATION, ErrInvalidOperation.WithError(err))
+	}
+	return rm.SetB(key, data, rm.defaultTTL(key, ttl))
+}
+
+// GetValue 读取结构化数据，按键所属命名空间的编解码器和压缩策略解码
+func GetValue[T any](rm *RedisManager, key string) CacheResult[T] {
+	return getCached[T](rm, key)
+}
+
+// LoadOptions GetOrLoad 选项
+type LoadOptions struct {
+	// EarlyRefresh 启用 XFetch 概率提前刷新：缓存值附带加载耗时和逻辑过期时间一起存储，
+	// 越接近过期、加载越慢，读取时提前刷新的概率越高，热点键过期前通常已被某一次读取刷新，无需加锁即可避免击穿
+	// 启用后缓存值的存储格式不同，同一个键的所有读写都应启用该选项
+	EarlyRefresh bool
+
+	// Beta XFetch 的提前系数，大于1更倾向于提前刷新，默认 1.0
+	Beta float64
+
+	// StaleGrace 大于0时启用 stale-while-revalidate：缓存值在逻辑过期（ttl）后继续保留 StaleGrace，
+	// 宽限期内读取直接返回旧值并在后台刷新，调用方不承担加载延迟；存储格式与 EarlyRefresh 相同，两者可同时启用
+	StaleGrace time.Duration
+
+	// LoadLock 未命中时先获取短期的分布式加载锁，整个集群只有获得锁的实例调用 loader，
+	// 其他实例订阅填充通知并等待缓存被写入，等待超过 LoadWait 后自行加载
+	LoadLock bool
+
+	// LoadLockTTL 加载锁的过期时间，应大于 loader 的最长耗时，默认 10s
+	LoadLockTTL time.Duration
+
+	// LoadWait 等待其他实例加载的最长时间，默认等于 LoadLockTTL
+	LoadWait time.Duration
+}
+
+// GetOrLoad 读取缓存，未命中时调用 loader 加载并以 ttl 写回缓存（read-through），opts 为 nil 时使用默认选项
+// ttl<=0 时使用命名空间的默认过期时间；数据按命名空间的编解码器和压缩策略序列化；同一进程内对同一个键的并发未命中只调用一次 loader
+// loader 返回错误时结果为 BREAK，不写入缓存；写回缓存失败不影响返回加载到的值
+func GetOrLoad[T any](rm *RedisManager, key string, ttl time.Duration, loader LoaderFunc[T], opts *LoadOptions) CacheResult[T] {
+	res, _ := getOrLoad(rm, key, ttl, loader, opts)
+	return res
+}
+
+// loadSource GetOrLoad 返回值的来源
+type loadSource int
+
+const (
+	sourceCache  loadSource = iota // 缓存命中（包括等待其他调用或其他实例写入的缓存）
+	sourceLoader                   // 由 loader 加载（包括共享同一进程内其他调用的加载结果）
+	sourceStale                    // 逻辑过期或刷新失败时返回的旧值
+)
+
+// loadedValue 加载函数的结果，loaded 表示值由 loader 加载而不是从缓存读取
+type loadedValue struct {
+	val    interface{}
+	loaded bool
+}
+
+// loadedResult 将加载函数的结果转换为 CacheResult 和值的来源
+func loadedResult[T any](val interface{}) (CacheResult[T], loadSource) {
+	lv, _ := val.(loadedValue)
+	v, _ := lv.val.(T)
+	if lv.loaded {
+		return NewCacheResult(v), sourceLoader
+	}
+	return NewCacheResult(v), sourceCache
+}
+
+// getOrLoad GetOrLoad 的实现，同时返回值的来源
+func getOrLoad[T any](rm *RedisManager, key string, ttl time.Duration, loader LoaderFunc[T], opts *LoadOptions) (CacheResult[T], loadSource) {
+	if loader == nil {
+		return NewCacheError[T](INVALID_OPERATION, ErrInvalidOperation.WithMessage("loader is nil")), sourceCache
+	}
+	// loader 执行期间计入正在执行的操作，Shutdown 等待其写回缓存
+	if !rm.beginLoad() {
+		return NewCacheError[T](CONNECTION_FAILED, ErrConnectionFailed), sourceCache
+	}
+	defer rm.endLoad()
+
+	var o LoadOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Beta <= 0 {
+		o.Beta = 1
+	}
+	if o.LoadLockTTL <= 0 {
+		o.LoadLockTTL = 10 * time.Second
+	}
+	if o.LoadWait <= 0 {
+		o.LoadWait = o.LoadLockTTL
+	}
+	ttl = rm.defaultTTL(key, ttl)
+	if o.EarlyRefresh || o.StaleGrace > 0 {
+		return getOrLoadEntry(rm, key, ttl, loader, o)
+	}
+
+	if res := getCached[T](rm, key); res.ErrCode != KEY_NOT_FOUND {
+		return res, sourceCache
+	}
+
+	load := func() (interface{}, error) {
+		v, err := loader(rm.ctx)
+		if err != nil {
+			return nil, &loadError{code: BREAK, err: err}
+		}
+		setCached(rm, key, v, rm.jitterTTL(key, ttl))
+		return loadedValue{val: v, loaded: true}, nil
+	}
+	if o.LoadLock {
+		load = rm.lockedLoad(key, o, func() (interface{}, bool) {
+			res := getCached[T](rm, key)
+			return loadedValue{val: res.Val}, res.IsOK()
+		}, load)
+	}
+
+	val, err := rm.loads.do(key, func() (interface{}, error) {
+		// 等待进入加载期间可能已有其他调用写回了缓存
+		if res := getCached[T](rm, key); res.ErrCode != KEY_NOT_FOUND {
+			if !res.IsOK() {
+				return nil, &loadError{code: res.ErrCode, err: res.Err}
+			}
+			return loadedValue{val: res.Val}, nil
+		}
+		return load()
+	})
+	if err != nil {
+		return loadErrorResult[T](err), sourceCache
+	}
+	return loadedResult[T](val)
+}
+
+// loadErrorResult 将加载过程中的错误转换为 CacheResult
+func loadErrorResult[T any](err error) CacheResult[T] {
+	if le, ok := err.(*loadError); ok {
+		return NewCacheError[T](le.code, le.err)
+	}
+	return NewCacheError[T](REDIS_INNER_ERROR, err)
+}
+
+// BatchLoaderFunc 批量加载未命中键的函数，返回结果中不包含的键视为不存在
+type BatchLoaderFunc[T any] func(missing []string) (map[string]T, error)
+
+// MGetOrLoad 批量读取缓存：通过 MGET 读取命中的键，未命中的键一次性交给 batchLoader 加载，
+// 加载结果通过 Pipeline 以 ttl 写回缓存，返回命中与加载结果合并后的映射
+// 无法解码的缓存值视为未命中；batchLoader 返回错误时结果为 BREAK；写回缓存失败不影响返回加载到的值
+// 集群模式下 keys 应位于同一个槽（如使用相同的哈希标签）
+func MGetOrLoad[T any](rm *RedisManager, keys []string, ttl time.Duration, batchLoader BatchLoaderFunc[T]) CacheResult[map[string]T] {
+	if batchLoader == nil {
+		return NewCacheError[map[string]T](INVALID_OPERATION, ErrInvalidOperation.WithMessage("batch loader is nil"))
+	}
+	result := make(map[string]T, len(keys))
+	if len(keys) == 0 {
+		return NewCacheResult(result)
+	}
+	if !rm.beginLoad() {
+		return NewCacheError[map[string]T](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.endLoad()
+
+	cached := rm.MGetB(keys...)
+	if !cached.IsOK() {
+		return NewCacheError[map[string]T](cached.ErrCode, cached.Err)
+	}
+
+	var missing []string
+	for i, key := range keys {
+		data := cached.Val[i]
+		if data == nil {
+			missing = append(missing, key)
+			continue
+		}
+		var v T
+		if err := rm.decodeValue(key, data, &v); err != nil {
+			rm.Logger().Warn("Redis cache decode failed, reloading", "key", key, "error", err)
+			missing = append(missing, key)
+			continue
+		}
+		result[key] = v
+	}
+	if len(missing) == 0 {
+		return NewCacheResult(result)
+	}
+
+	loaded, err := batchLoader(missing)
+	if err != nil {
+		return NewCacheError[map[string]T](BREAK, err)
+	}
+
+	pipe := rm.Pipeline()
+	queued := 0
+	for _, key := range missing {
+		v, ok := loaded[key]
+		if !ok {
+			continue
+		}
+		result[key] = v
+
+		data, err := rm.encodeValue(key, v)
+		if err != nil {
+			rm.logCacheWriteFailed(key, err)
+			continue
+		}
+		pipe.Set(key, data, rm.jitterTTL(key, rm.defaultTTL(key, ttl)))
+		queued++
+	}
+	if queued > 0 {
+		if res := pipe.Exec(); !res.IsOK() {
+			rm.Logger().Warn("Redis cache batch write failed", "keys", queued, "error", res.Err)
+		}
+	}
+
+	return NewCacheResult(result)
+}
+
+// cacheEntry 附带元数据的缓存值
+type cacheEntry[T any] struct {
+	Value  T     `json:"v"`
+	Delta  int64 `json:"d"` // 加载耗时（毫秒）
+	Expiry int64 `json:"e"` // 逻辑过期时间（Unix 毫秒）
+}
+
+// shouldRefresh XFetch 判定：now - delta*beta*ln(rand) >= expiry 时提前刷新
+func (e *cacheEntry[T]) shouldRefresh(beta float64, now time.Time) bool {
+	gap := -float64(e.Delta) * beta * math.Log(rand.Float64())
+	return float64(now.UnixMilli())+gap >= float64(e.Expiry)
+}
+
+// getOrLoadEntry 以 cacheEntry 格式存储的 GetOrLoad
+// 命中且未逻辑过期时按 XFetch 判定是否提前刷新；逻辑过期但仍在宽限期内时返回旧值并在后台刷新
+func getOrLoadEntry[T any](rm *RedisManager, key string, ttl time.Duration, loader LoaderFunc[T], o LoadOptions) (CacheResult[T], loadSource) {
+	cached := getCached[cacheEntry[T]](rm, key)
+	if cached.ErrCode != KEY_NOT_FOUND && !cached.IsOK() {
+		return NewCacheError[T](cached.ErrCode, cached.Err), sourceCache
+	}
+
+	load := func() (interface{}, error) {
+		start := time.Now()
+		v, err := loader(rm.ctx)
+		if err != nil {
+			return nil, &loadError{code: BREAK, err: err}
+		}
+
+		// 逻辑过期时间与键的实际过期时间使用同一个抖动后的 ttl
+		entry := cacheEntry[T]{Value: v, Delta: time.Since(start).Milliseconds(), Expiry: math.MaxInt64}
+		physical := time.Duration(0)
+		if ttl > 0 {
+			jittered := rm.jitterTTL(key, ttl)
+			entry.Expiry = time.Now().Add(jittered).UnixMilli()
+			physical = jittered + o.StaleGrace
+		}
+		setCached(rm, key, entry, physical)
+		return loadedValue{val: v, loaded: true}, nil
+	}
+	if o.LoadLock {
+		load = rm.lockedLoad(key, o, func() (interface{}, bool) {
+			res := getCached[cacheEntry[T]](rm, key)
+			if !res.IsOK() || time.Now().UnixMilli() >= res.Val.Expiry {
+				return nil, false
+			}
+			return loadedValue{val: res.Val.Value}, true
+		}, load)
+	}
+
+	if cached.IsOK() {
+		now := time.Now()
+		expired := now.UnixMilli() >= cached.Val.Expiry
+		switch {
+		case !expired && (!o.EarlyRefresh || !cached.Val.shouldRefresh(o.Beta, now)):
+			return NewCacheResult(cached.Val.Value), sourceCache
+		case expired && o.StaleGrace > 0:
+			if !rm.beginLoad() {
+				return NewCacheResult(cached.Val.Value), sourceStale
+			}
+			started := rm.loads.doAsync(key, func() (interface{}, error) {
+				defer rm.endLoad()
+				v, err := load()
+				if err != nil {
+					rm.Logger().Warn("Redis cache background refresh failed", "key", key, "error", err)
+				}
+				return v, err
+			})
+			if !started {
+				rm.endLoad()
+			}
+			return NewCacheResult(cached.Val.Value), sourceStale
+		}
+	}
+
+	val, err := rm.loads.do(key, load)
+	if err != nil {
+		// 刷新失败时继续使用旧值
+		if cached.IsOK() {
+			rm.Logger().Warn("Redis cache refresh failed, serving stale value", "key", key, "error", err)
+			if time.Now().UnixMilli() >= cached.Val.Expiry {
+				return NewCacheResult(cached.Val.Value), sourceStale
+			}
+			return NewCacheResult(cached.Val.Value), sourceCache
+		}
+		return loadErrorResult[T](err), sourceCache
+	}
+	return loadedResult[T](val)
+}
+
+// lockedLoad 将 load 包装为在分布式加载锁保护下执行
+// 获得锁时先通过 reread 检查缓存，仍未命中才执行 load 并发布填充通知；未获得锁时等待通知并通过 reread 读取其他实例写入的值，等待超时后自行执行 load
+func (rm *RedisManager) lockedLoad(key string, o LoadOptions, reread func() (interface{}, bool), load func() (interface{}, error)) func() (interface{}, error) {
+	lockKey := sameSlotBase(key) + ":loadlock"
+	channel := "cache:filled:" + key
+
+	return func() (interface{}, error) {
+		token := newTaskID()
+		locked := rm.TryLock(lockKey, token, o.LoadLockTTL)
+		if !locked.IsOK() {
+			rm.Logger().Warn("Redis cache load lock failed, loading without lock", "key", key, "error", locked.Err)
+			return load()
+		}
+
+		if locked.Val {
+			defer rm.ReleaseLock(lockKey, token)
+			// 其他实例可能在本实例获得锁之前刚完成加载并释放锁
+			if v, ok := reread(); ok {
+				return v, nil
+			}
+			v, err := load()
+			if err == nil {
+				rm.Publish(channel, "1")
+			}
+			return v, err
+		}
+
+		// 其他实例正在加载，等待其写入缓存
+		ctx, cancel := context.WithTimeout(rm.ctx, o.LoadWait)
+		defer cancel()
+
+		var filled interface{}
+		res := rm.waitUntil(ctx, channel, func() CacheResult[bool] {
+			v, ok := reread()
+			if ok {
+				filled = v
+			}
+			return NewCacheResult(ok)
+		})
+		if res.IsOK() && res.Val {
+			return filled, nil
+		}
+		return load()
+	}
+}
+
+// loadError 加载过程中的错误及其对应的错误码
+type loadError struct {
+	code ErrorCode
+	err  error
+}
+
+func (e *loadError) Error() string {
+	return e.err.Error()
+}
+
+// getCached 读取并解码缓存值，键不存在或结构版本无法识别时返回 KEY_NOT_FOUND
+func getCached[T any](rm *RedisManager, key string) CacheResult[T] {
+	res := rm.GetB(key)
+	if !res.IsOK() {
+		return NewCacheError[T](res.ErrCode, res.Err)
+	}
+
+	var v T
+	if err := rm.decodeValue(key, res.Val, &v); err != nil {
+		// 无法识别的结构版本视为未命中，由调用方重新加载
+		if err == errSchemaMismatch {
+			return NewCacheError[T](KEY_NOT_FOUND, ErrKeyNotFound.WithError(err))
+		}
+		return NewCacheError[T](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(v)
+}
+
+// setCached 编码并写入缓存值，失败时只记录日志；ttl 为最终的过期时间，调用方已按默认值和抖动调整
+func setCached[T any](rm *RedisManager, key string, v T, ttl time.Duration) {
+	data, err := rm.encodeValue(key, v)
+	if err != nil {
+		rm.logCacheWriteFailed(key, err)
+		return
+	}
+	if res := rm.setTTL(key, data, ttl, ttl); !res.IsOK() {
+		rm.logCacheWriteFailed(key, res.Err)
+	}
+}
+
+// logCacheWriteFailed 记录回写缓存失败
+func (rm *RedisManager) logCacheWriteFailed(key string, err error) {
+	rm.Logger().Warn("Redis cache write failed", "key", key, "error", err)
+}
+
+// namespaceFor 查找键所属的命名空间配置，取最长的匹配前缀，没有匹配时返回 nil
+func (rm *RedisManager) namespaceFor(key string) *NamespaceConfig {
+	var matched *NamespaceConfig
+	for i := range rm.config.Namespaces {
+		ns := &rm.config.Namespaces[i]
+		if strings.HasPrefix(key, ns.Prefix) && (matched == nil || len(ns.Prefix) > len(matched.Prefix)) {
+			matched = ns
+		}
+	}
+	return matched
+}
+
+// jitterTTL 按键所属命名空间（或全局）的抖动比例随机调整过期时间，ttl<=0 时原样返回
+func (rm *RedisManager) jitterTTL(key string, ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return ttl
+	}
+
+	ratio := rm.config.Common.TTLJitter
+	if ns := rm.namespaceFor(key); ns != nil && ns.TTLJitter != 0 {
+		ratio = ns.TTLJitter
+	}
+	if ratio <= 0 {
+		return ttl
+	}
+
+	jittered := ttl + time.Duration((rand.Float64()*2-1)*ratio*float64(ttl))
+	if jittered < time.Millisecond {
+		jittered = time.Millisecond
+	}
+	return jittered
+}
+
+// loadCall 正在进行的加载
+type loadCall struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+// loadGroup 合并同一进程内对同一个键的并发加载
+type loadGroup struct {
+	mu    sync.Mutex
+	calls map[string]*loadCall
+}
+
+// do 执行 fn，同一个键已有加载在进行时等待并共享其结果
+func (g *loadGroup) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*loadCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &loadCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	g.run(key, c, fn)
+	return c.val, c.err
+}
+
+// doAsync 同一个键没有加载在进行时在后台执行 fn，返回是否启动了加载
+func (g *loadGroup) doAsync(key string, fn func() (interface{}, error)) bool {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*loadCall)
+	}
+	if _, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		return false
+	}
+	c := &loadCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	go g.run(key, c, fn)
+	return true
+}
+
+// run 执行加载并唤醒等待者
+func (g *loadGroup) run(key string, c *loadCall, fn func() (interface{}, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+}
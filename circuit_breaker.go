@@ -0,0 +1,297 @@
+package redisx
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// errCircuitOpen 熔断器打开期间直接拒绝的命令返回的错误
+var errCircuitOpen = ErrConnectionFailed.WithMessage("circuit breaker is open")
+
+// CircuitBreakerConfig 熔断器配置
+// 启用后由熔断器代替健康检查决定是否执行命令：单节点客户端使用一个全局熔断器，集群客户端（集群模式和读写分离的哨兵模式）每个节点一个熔断器
+type CircuitBreakerConfig struct {
+	Window           time.Duration `json:"window,omitempty" yaml:"window,omitempty"`                         // 统计窗口，默认 10s
+	MinRequests      int64         `json:"min_requests,omitempty" yaml:"min_requests,omitempty"`             // 窗口内请求数达到该值才判断是否熔断，默认 20
+	ErrorRate        float64       `json:"error_rate,omitempty" yaml:"error_rate,omitempty"`                 // 连接和超时错误比例达到该值时熔断，默认 0.5
+	SlowThreshold    time.Duration `json:"slow_threshold,omitempty" yaml:"slow_threshold,omitempty"`         // 耗时达到该值的命令记为慢请求，0 表示不按耗时熔断
+	SlowRate         float64       `json:"slow_rate,omitempty" yaml:"slow_rate,omitempty"`                   // 慢请求比例达到该值时熔断，默认 0.5
+	OpenTimeout      time.Duration `json:"open_timeout,omitempty" yaml:"open_timeout,omitempty"`             // 熔断后经过该时间进入半开状态试探恢复，默认 5s
+	HalfOpenRequests int           `json:"half_open_requests,omitempty" yaml:"half_open_requests,omitempty"` // 半开状态允许的试探请求数，全部成功后关闭熔断器，默认 1
+}
+
+// setDefaults 设置默认值
+func (c *CircuitBreakerConfig) setDefaults() {
+	if c.Window <= 0 {
+		c.Window = 10 * time.Second
+	}
+	if c.MinRequests <= 0 {
+		c.MinRequests = 20
+	}
+	if c.ErrorRate <= 0 || c.ErrorRate > 1 {
+		c.ErrorRate = 0.5
+	}
+	if c.SlowRate <= 0 || c.SlowRate > 1 {
+		c.SlowRate = 0.5
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = 5 * time.Second
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = 1
+	}
+}
+
+// CircuitState 熔断器状态
+type CircuitState int
+
+const (
+	// CircuitClosed 正常放行
+	CircuitClosed CircuitState = iota
+	// CircuitOpen 熔断，直接拒绝
+	CircuitOpen
+	// CircuitHalfOpen 半开，放行少量试探请求
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerStatus 熔断器状态报告
+type CircuitBreakerStatus struct {
+	Node     string       // 节点地址，全局熔断器为空
+	State    CircuitState // 当前状态
+	Requests int64        // 当前窗口的请求数
+	Failures int64        // 当前窗口的连接和超时错误数
+	Slow     int64        // 当前窗口的慢请求数
+	OpenedAt time.Time    // 最近一次熔断的时间
+}
+
+// circuitBreaker 按错误率和慢请求比例熔断的熔断器
+type circuitBreaker struct {
+	rm     *RedisManager
+	node   string
+	config CircuitBreakerConfig
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int64
+	failures    int64
+	slow        int64
+	openedAt    time.Time
+	probes      int // 半开状态已放行的试探请求数
+	successes   int // 半开状态已成功的试探请求数
+}
+
+// setState 切换状态，调用方需持有 b.mu
+func (b *circuitBreaker) setState(state CircuitState, now time.Time) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	b.windowStart, b.requests, b.failures, b.slow = now, 0, 0, 0
+	b.probes, b.successes = 0, 0
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+
+	logger := b.rm.Logger()
+	if state == CircuitOpen {
+		logger.Warn("Redis circuit breaker opened", "node", b.node, "from", from.String())
+	} else {
+		logger.Info("Redis circuit breaker state changed", "node", b.node, "from", from.String(), "to", state.String())
+	}
+}
+
+// ready 是否可能放行请求（不占用半开状态的试探名额）
+func (b *circuitBreaker) ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state != CircuitOpen || time.Since(b.openedAt) >= b.config.OpenTimeout
+}
+
+// allow 判断是否放行请求，熔断期间返回错误
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.config.OpenTimeout {
+			return errCircuitOpen
+		}
+		b.setState(CircuitHalfOpen, now)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probes >= b.config.HalfOpenRequests {
+			return errCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// record 记录请求结果，只有连接和超时错误计为失败
+func (b *circuitBreaker) record(latency time.Duration, err error) {
+	class := ClassifyError(err)
+	failed := class == ErrorClassConnection || class == ErrorClassTimeout
+	slow := b.config.SlowThreshold > 0 && latency >= b.config.SlowThreshold
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	switch b.state {
+	case CircuitHalfOpen:
+		if failed || slow {
+			b.setState(CircuitOpen, now)
+			return
+		}
+		b.successes++
+		if b.successes >= b.config.HalfOpenRequests {
+			b.setState(CircuitClosed, now)
+		}
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= b.config.Window {
+			b.windowStart, b.requests, b.failures, b.slow = now, 0, 0, 0
+		}
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if slow {
+			b.slow++
+		}
+		if b.requests < b.config.MinRequests {
+			return
+		}
+		if float64(b.failures)/float64(b.requests) >= b.config.ErrorRate ||
+			(b.config.SlowThreshold > 0 && float64(b.slow)/float64(b.requests) >= b.config.SlowRate) {
+			b.setState(CircuitOpen, now)
+		}
+	}
+}
+
+// status 获取状态报告
+func (b *circuitBreaker) status() CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.state
+	if state == CircuitOpen && time.Since(b.openedAt) >= b.config.OpenTimeout {
+		// 下一个请求将作为试探请求放行
+		state = CircuitHalfOpen
+	}
+	return CircuitBreakerStatus{
+		Node:     b.node,
+		State:    state,
+		Requests: b.requests,
+		Failures: b.failures,
+		Slow:     b.slow,
+		OpenedAt: b.openedAt,
+	}
+}
+
+// circuitBreakers 全局或按节点的熔断器
+type circuitBreakers struct {
+	config CircuitBreakerConfig
+	mu     sync.Mutex
+	byNode map[string]*circuitBreaker
+}
+
+// circuitBreaker 获取节点的熔断器，不存在时创建，node 为空表示全局熔断器
+func (rm *RedisManager) circuitBreaker(node string) *circuitBreaker {
+	cbs := rm.breakers
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	if cbs.byNode == nil {
+		cbs.byNode = make(map[string]*circuitBreaker)
+	}
+	b, ok := cbs.byNode[node]
+	if !ok {
+		b = &circuitBreaker{rm: rm, node: node, config: cbs.config, windowStart: time.Now()}
+		cbs.byNode[node] = b
+	}
+	return b
+}
+
+// CircuitBreakers 获取所有熔断器的状态，未启用熔断器时返回 nil
+func (rm *RedisManager) CircuitBreakers() []CircuitBreakerStatus {
+	if rm.breakers == nil {
+		return nil
+	}
+
+	rm.breakers.mu.Lock()
+	breakers := make([]*circuitBreaker, 0, len(rm.breakers.byNode))
+	for _, b := range rm.breakers.byNode {
+		breakers = append(breakers, b)
+	}
+	rm.breakers.mu.Unlock()
+
+	statuses := make([]CircuitBreakerStatus, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Node < statuses[j].Node
+	})
+	return statuses
+}
+
+// breakerHook 按熔断器状态放行或拒绝命令的 go-redis 钩子
+type breakerHook struct {
+	breaker *circuitBreaker
+}
+
+func (h breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.breaker.allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.breaker.record(time.Since(start), err)
+		return err
+	}
+}
+
+func (h breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := h.breaker.allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.breaker.record(time.Since(start), err)
+		return err
+	}
+}
@@ -0,0 +1,191 @@
+package redisx
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// clusterSlots 集群哈希槽数量
+const clusterSlots = 16384
+
+// crc16Table CRC16-XMODEM 查找表，与 Redis Cluster 计算哈希槽使用的算法一致
+var crc16Table = func() [256]uint16 {
+	var table [256]uint16
+	for i := range table {
+		crc := uint16(i) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// keySlot 计算键所在的集群哈希槽，键中包含非空的 {hashtag} 时只对 hashtag 计算
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc = crc<<8 ^ crc16Table[byte(crc>>8)^key[i]]
+	}
+	return int(crc) % clusterSlots
+}
+
+// slotGroup 同一个哈希槽中的键及其在原始参数中的下标
+type slotGroup struct {
+	keys    []string
+	indices []int
+}
+
+// groupBySlot 按哈希槽分组键，分组按首次出现的顺序排列
+func groupBySlot(keys []string) []slotGroup {
+	var groups []slotGroup
+	bySlot := make(map[int]int)
+	for i, key := range keys {
+		slot := keySlot(key)
+		g, ok := bySlot[slot]
+		if !ok {
+			g = len(groups)
+			bySlot[slot] = g
+			groups = append(groups, slotGroup{})
+		}
+		groups[g].keys = append(groups[g].keys, key)
+		groups[g].indices = append(groups[g].indices, i)
+	}
+	return groups
+}
+
+// splitBySlot 集群模式下多个键是否需要按哈希槽拆分
+func (rm *RedisManager) splitBySlot(keys []string) []slotGroup {
+	if rm.config.Mode != ModeCluster || len(keys) < 2 {
+		return nil
+	}
+	if groups := groupBySlot(keys); len(groups) > 1 {
+		return groups
+	}
+	return nil
+}
+
+// mgetValues 执行 MGET，集群模式下键分布在多个哈希槽时按槽拆分，通过流水线在各节点并发执行后按原顺序合并
+func (rm *RedisManager) mgetValues(ctx context.Context, keys []string) ([]interface{}, error) {
+	groups := rm.splitBySlot(keys)
+	if groups == nil {
+		return rm.activeClient().MGet(ctx, keys...).Result()
+	}
+
+	pipe := rm.activeClient().Pipeline()
+	cmds := make([]*redis.SliceCmd, len(groups))
+	for i, g := range groups {
+		cmds[i] = pipe.MGet(ctx, g.keys...)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	val := make([]interface{}, len(keys))
+	for i, g := range groups {
+		for j, v := range cmds[i].Val() {
+			val[g.indices[j]] = v
+		}
+	}
+	return val, nil
+}
+
+// msetPairs 执行 MSET，集群模式下按哈希槽拆分（只拆分键为字符串的键值对列表或 map，其他形式直接执行）
+// 拆分后各槽分别写入，不再保证整体原子性
+func (rm *RedisManager) msetPairs(ctx context.Context, pairs []interface{}) (string, error) {
+	if rm.config.Mode != ModeCluster {
+		return rm.activeClient().MSet(ctx, pairs...).Result()
+	}
+
+	keys, values, ok := flattenPairs(pairs)
+	if !ok {
+		return rm.activeClient().MSet(ctx, pairs...).Result()
+	}
+	groups := rm.splitBySlot(keys)
+	if groups == nil {
+		return rm.activeClient().MSet(ctx, pairs...).Result()
+	}
+
+	pipe := rm.activeClient().Pipeline()
+	for _, g := range groups {
+		args := make([]interface{}, 0, 2*len(g.keys))
+		for j, key := range g.keys {
+			args = append(args, key, values[g.indices[j]])
+		}
+		pipe.MSet(ctx, args...)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return "OK", nil
+}
+
+// flattenPairs 将 MSET 参数拆为键和值，支持 "k1", "v1", "k2", "v2" 列表和 map[string]interface{}、map[string]string
+func flattenPairs(pairs []interface{}) ([]string, []interface{}, bool) {
+	var keys []string
+	var values []interface{}
+
+	if len(pairs) == 1 {
+		switch m := pairs[0].(type) {
+		case map[string]interface{}:
+			for k, v := range m {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			return keys, values, true
+		case map[string]string:
+			for k, v := range m {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			return keys, values, true
+		}
+	}
+
+	if len(pairs)%2 != 0 {
+		return nil, nil, false
+	}
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, nil, false
+		}
+		keys = append(keys, key)
+		values = append(values, pairs[i+1])
+	}
+	return keys, values, true
+}
+
+// delKeys 执行 DEL，集群模式下按哈希槽拆分并汇总删除数量
+func (rm *RedisManager) delKeys(ctx context.Context, keys []string) (int64, error) {
+	groups := rm.splitBySlot(keys)
+	if groups == nil {
+		return rm.activeClient().Del(ctx, keys...).Result()
+	}
+
+	pipe := rm.activeClient().Pipeline()
+	cmds := make([]*redis.IntCmd, len(groups))
+	for i, g := range groups {
+		cmds[i] = pipe.Del(ctx, g.keys...)
+	}
+	_, err := pipe.Exec(ctx)
+
+	// 部分槽删除失败时仍返回已删除的数量
+	var deleted int64
+	for _, cmd := range cmds {
+		deleted += cmd.Val()
+	}
+	return deleted, err
+}
@@ -0,0 +1,215 @@
+package redisx
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 拓扑变化原因
+const (
+	TopologyChangeFailover = "failover" // 有从节点被提升为主节点
+	TopologyChangeMoved    = "moved"    // 哈希槽迁移到其他主节点（扩缩容、重新分片）
+	TopologyChangeNodes    = "nodes"    // 哈希槽分配不变，从节点增减或主从关系变化
+)
+
+// SlotRange 连续的哈希槽范围（包含两端）
+type SlotRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// ClusterShard 一个主节点及其从节点和负责的哈希槽
+type ClusterShard struct {
+	Master   string      `json:"master"`
+	Replicas []string    `json:"replicas,omitempty"`
+	Slots    []SlotRange `json:"slots"`
+}
+
+// ClusterTopology 集群拓扑，按主节点地址排序；没有分配哈希槽的主节点不包含在内
+type ClusterTopology struct {
+	Shards []ClusterShard `json:"shards"`
+}
+
+// TopologyEvent 集群拓扑变化事件
+type TopologyEvent struct {
+	Reason     string          // TopologyChangeFailover、TopologyChangeMoved 或 TopologyChangeNodes
+	Previous   ClusterTopology // 变化前的拓扑
+	Current    ClusterTopology
+	MovedSlots int      // 主节点发生变化的哈希槽数
+	Promoted   []string // 由从节点提升为主节点的地址
+	Time       time.Time
+}
+
+// TopologyChangeHandler 集群拓扑变化回调
+type TopologyChangeHandler func(event TopologyEvent)
+
+// topologyWatcher 集群拓扑变化的回调和最近一次观察到的拓扑
+type topologyWatcher struct {
+	mu         sync.Mutex
+	handlers   []TopologyChangeHandler
+	last       *ClusterTopology
+	refreshing atomic.Bool
+}
+
+// ClusterTopology 通过 CLUSTER SLOTS 获取当前的集群拓扑，非集群模式时返回 INVALID_OPERATION
+func (rm *RedisManager) ClusterTopology() CacheResult[ClusterTopology] {
+	rm.stats.IncrTotal()
+
+	cluster, ok := rm.GetClient().(*redis.ClusterClient)
+	if !ok {
+		return NewCacheError[ClusterTopology](INVALID_OPERATION, ErrInvalidOperation.WithMessage("topology is only available in cluster mode"))
+	}
+	if !rm.acquire() {
+		return NewCacheError[ClusterTopology](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	topology, err := fetchClusterTopology(rm, cluster)
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[ClusterTopology](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(topology)
+}
+
+// OnTopologyChange 注册集群拓扑变化回调，收到 MOVED 重定向和每次健康检查时检查拓扑，发生变化时在后台 goroutine 中按注册顺序同步执行
+// 非集群模式下不会调用；注册时记录当前拓扑作为比较的基准
+func (rm *RedisManager) OnTopologyChange(handler TopologyChangeHandler) {
+	rm.topology.mu.Lock()
+	rm.topology.handlers = append(rm.topology.handlers, handler)
+	rm.topology.mu.Unlock()
+
+	go rm.refreshTopology()
+}
+
+// topologyWatched 是否需要检查集群拓扑变化
+func (rm *RedisManager) topologyWatched() bool {
+	rm.topology.mu.Lock()
+	defer rm.topology.mu.Unlock()
+	return len(rm.topology.handlers) > 0
+}
+
+// refreshTopology 获取当前拓扑并与上一次比较，发生变化时清除模块探测结果并通知回调；同一时间只执行一次
+func (rm *RedisManager) refreshTopology() {
+	cluster, ok := rm.GetClient().(*redis.ClusterClient)
+	if !ok || !rm.topologyWatched() || !rm.topology.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	defer rm.topology.refreshing.Store(false)
+
+	current, err := fetchClusterTopology(rm, cluster)
+	if err != nil {
+		rm.Logger().Debug("Redis cluster topology refresh failed", "error", err)
+		return
+	}
+
+	rm.topology.mu.Lock()
+	previous := rm.topology.last
+	rm.topology.last = &current
+	handlers := rm.topology.handlers
+	rm.topology.mu.Unlock()
+
+	if previous == nil || reflect.DeepEqual(*previous, current) {
+		return
+	}
+
+	rm.invalidateModules()
+	event := diffTopology(*previous, current)
+	rm.Logger().Info("Redis cluster topology changed", "reason", event.Reason, "moved_slots", event.MovedSlots, "promoted", event.Promoted)
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// fetchClusterTopology 通过 CLUSTER SLOTS 获取拓扑，按主节点合并哈希槽范围
+func fetchClusterTopology(rm *RedisManager, cluster *redis.ClusterClient) (ClusterTopology, error) {
+	slots, err := cluster.ClusterSlots(rm.ctx).Result()
+	if err != nil {
+		return ClusterTopology{}, err
+	}
+
+	shards := make(map[string]*ClusterShard)
+	for _, slot := range slots {
+		if len(slot.Nodes) == 0 {
+			continue
+		}
+		master := slot.Nodes[0].Addr
+		shard, ok := shards[master]
+		if !ok {
+			shard = &ClusterShard{Master: master}
+			shards[master] = shard
+		}
+		shard.Slots = append(shard.Slots, SlotRange{Start: slot.Start, End: slot.End})
+		for _, node := range slot.Nodes[1:] {
+			if !slices.Contains(shard.Replicas, node.Addr) {
+				shard.Replicas = append(shard.Replicas, node.Addr)
+			}
+		}
+	}
+
+	topology := ClusterTopology{Shards: make([]ClusterShard, 0, len(shards))}
+	for _, shard := range shards {
+		sort.Strings(shard.Replicas)
+		sort.Slice(shard.Slots, func(i, j int) bool {
+			return shard.Slots[i].Start < shard.Slots[j].Start
+		})
+		topology.Shards = append(topology.Shards, *shard)
+	}
+	sort.Slice(topology.Shards, func(i, j int) bool {
+		return topology.Shards[i].Master < topology.Shards[j].Master
+	})
+	return topology, nil
+}
+
+// diffTopology 比较两次拓扑，统计主节点变化的哈希槽和被提升的从节点
+func diffTopology(previous, current ClusterTopology) TopologyEvent {
+	event := TopologyEvent{Previous: previous, Current: current, Time: time.Now()}
+
+	before, after := slotOwners(previous), slotOwners(current)
+	for slot := range before {
+		if before[slot] != after[slot] {
+			event.MovedSlots++
+		}
+	}
+
+	replicas := make(map[string]bool)
+	for _, shard := range previous.Shards {
+		for _, addr := range shard.Replicas {
+			replicas[addr] = true
+		}
+	}
+	for _, shard := range current.Shards {
+		if replicas[shard.Master] {
+			event.Promoted = append(event.Promoted, shard.Master)
+		}
+	}
+
+	switch {
+	case len(event.Promoted) > 0:
+		event.Reason = TopologyChangeFailover
+	case event.MovedSlots > 0:
+		event.Reason = TopologyChangeMoved
+	default:
+		event.Reason = TopologyChangeNodes
+	}
+	return event
+}
+
+// slotOwners 各哈希槽所在的主节点地址
+func slotOwners(topology ClusterTopology) []string {
+	owners := make([]string, clusterSlots)
+	for _, shard := range topology.Shards {
+		for _, r := range shard.Slots {
+			for slot := max(r.Start, 0); slot <= min(r.End, clusterSlots-1); slot++ {
+				owners[slot] = shard.Master
+			}
+		}
+	}
+	return owners
+}
@@ -0,0 +1,49 @@
+package redisx
+
+import (
+	"maps"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SetCoalesceReads 开启或关闭读合并，可在运行时切换，初始值取自 Common.CoalesceReads
+// 开启后同一进程内对同一个键并发的 GET（GetS/GetB）和 HGETALL 只向 Redis 发送一条命令，其余调用等待并共享结果
+func (rm *RedisManager) SetCoalesceReads(enabled bool) {
+	rm.coalesceReads.Store(enabled)
+}
+
+// CoalesceReads 是否开启读合并
+func (rm *RedisManager) CoalesceReads() bool {
+	return rm.coalesceReads.Load()
+}
+
+// getString 执行 GET，开启读合并时共享同一个键正在进行的请求
+func (rm *RedisManager) getString(key string) (string, error) {
+	get := func() (string, error) {
+		cmd := redis.NewStringCmd(rm.ctx, "get", key)
+		rm.process(cmd)
+		return cmd.Result()
+	}
+	if !rm.coalesceReads.Load() {
+		return get()
+	}
+
+	val, err := rm.reads.do("get\x00"+key, func() (interface{}, error) {
+		return get()
+	})
+	s, _ := val.(string)
+	return s, err
+}
+
+// hGetAll 执行 HGETALL，开启读合并时共享同一个键正在进行的请求（每个调用方得到独立的副本）
+func (rm *RedisManager) hGetAll(key string) (map[string]string, error) {
+	if !rm.coalesceReads.Load() {
+		return rm.activeClient().HGetAll(rm.ctx, key).Result()
+	}
+
+	val, err := rm.reads.do("hgetall\x00"+key, func() (interface{}, error) {
+		return rm.activeClient().HGetAll(rm.ctx, key).Result()
+	})
+	m, _ := val.(map[string]string)
+	return maps.Clone(m), err
+}
@@ -0,0 +1,204 @@
+package redisx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// CodecJSON 内置 JSON 编解码器的注册名称
+const CodecJSON = "json"
+
+const (
+	// CompressionNone 不压缩
+	CompressionNone = "none"
+	// CompressionGzip gzip 压缩
+	CompressionGzip = "gzip"
+)
+
+// schemaTagMagic 结构版本标记的魔数，其后为无符号 varint 编码的版本号
+// 使用多字节魔数，避免与没有版本标记、恰好以 0xff 开头的数据（如 msgpack 的负整数）混淆
+var schemaTagMagic = []byte{0xff, 'r', 'x', 'v'}
+
+// errSchemaMismatch 缓存数据的结构版本与当前版本不同且没有注册对应的解码函数
+var errSchemaMismatch = ErrInvalidOperation.WithMessage("cached value schema version mismatch")
+
+// SchemaDecoder 旧版本数据的解码函数，data 为该版本编解码器输出的原始数据，out 为当前版本类型的指针
+type SchemaDecoder func(data []byte, out interface{}) error
+
+// gzipMagic 压缩数据的前缀；未达到压缩阈值的数据原样存储，启用压缩之前写入的数据同样可以读取
+var gzipMagic = []byte{0xff, 'r', 'x', 'z'}
+
+// Codec 对象序列化接口，用于在Redis中存取结构化数据
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec 基于 encoding/json 的编解码器（默认）
+type JSONCodec struct{}
+
+// Marshal 序列化
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal 反序列化
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// SetCodec 设置管理器使用的编解码器，传入 nil 时恢复为 JSONCodec
+func (rm *RedisManager) SetCodec(codec Codec) {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	rm.codecMutex.Lock()
+	defer rm.codecMutex.Unlock()
+	rm.codec = codec
+}
+
+// Codec 获取管理器使用的编解码器
+func (rm *RedisManager) Codec() Codec {
+	rm.codecMutex.RLock()
+	defer rm.codecMutex.RUnlock()
+	return rm.codec
+}
+
+// RegisterCodec 按名称注册编解码器，供命名空间配置的 codec 引用
+func (rm *RedisManager) RegisterCodec(name string, codec Codec) {
+	rm.codecMutex.Lock()
+	defer rm.codecMutex.Unlock()
+	rm.codecs[name] = codec
+}
+
+// codecFor 获取键所属命名空间使用的编解码器
+func (rm *RedisManager) codecFor(key string) (Codec, error) {
+	ns := rm.namespaceFor(key)
+	if ns == nil || ns.Codec == "" {
+		return rm.Codec(), nil
+	}
+
+	rm.codecMutex.RLock()
+	defer rm.codecMutex.RUnlock()
+	codec, ok := rm.codecs[ns.Codec]
+	if !ok {
+		return nil, ErrInvalidConfig.WithMessage("codec not registered: " + ns.Codec)
+	}
+	return codec, nil
+}
+
+// encodeValue 按键所属命名空间的编解码器和压缩策略序列化
+func (rm *RedisManager) encodeValue(key string, v interface{}) ([]byte, error) {
+	codec, err := rm.codecFor(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := rm.namespaceFor(key)
+	if ns != nil && ns.SchemaVersion > 0 {
+		tag := binary.AppendUvarint(append([]byte(nil), schemaTagMagic...), uint64(ns.SchemaVersion))
+		data = append(tag, data...)
+	}
+	if ns == nil || ns.Compression != CompressionGzip {
+		return data, nil
+	}
+	if len(data) < ns.CompressMinSize {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	buf.Write(gzipMagic)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue 按键所属命名空间的编解码器和压缩策略反序列化
+func (rm *RedisManager) decodeValue(key string, data []byte, v interface{}) error {
+	codec, err := rm.codecFor(key)
+	if err != nil {
+		return err
+	}
+
+	ns := rm.namespaceFor(key)
+	// 按前缀判断是否压缩，关闭压缩后仍可读取之前压缩的数据
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data[len(gzipMagic):]))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return err
+		}
+	}
+
+	if ns == nil || ns.SchemaVersion <= 0 {
+		return codec.Unmarshal(data, v)
+	}
+
+	// 没有版本标记的数据视为版本0（启用版本标记之前写入的数据）
+	version := 0
+	if bytes.HasPrefix(data, schemaTagMagic) {
+		n, size := binary.Uvarint(data[len(schemaTagMagic):])
+		if size <= 0 {
+			return ErrInvalidOperation.WithMessage("invalid schema version tag")
+		}
+		version, data = int(n), data[len(schemaTagMagic)+size:]
+	}
+	if version == ns.SchemaVersion {
+		return codec.Unmarshal(data, v)
+	}
+
+	decoder := rm.schemaDecoder(ns.Prefix, version)
+	if decoder == nil {
+		return errSchemaMismatch
+	}
+	return decoder(data, v)
+}
+
+// RegisterSchemaDecoder 为命名空间注册旧版本数据的解码函数
+// 读取到 version 版本的数据时调用 decoder 将其转换为当前版本；未注册的旧版本或更新的版本视为缓存未命中
+func (rm *RedisManager) RegisterSchemaDecoder(prefix string, version int, decoder SchemaDecoder) {
+	rm.codecMutex.Lock()
+	defer rm.codecMutex.Unlock()
+
+	if rm.schemaDecoders == nil {
+		rm.schemaDecoders = make(map[string]map[int]SchemaDecoder)
+	}
+	if rm.schemaDecoders[prefix] == nil {
+		rm.schemaDecoders[prefix] = make(map[int]SchemaDecoder)
+	}
+	rm.schemaDecoders[prefix][version] = decoder
+}
+
+// schemaDecoder 获取命名空间某个版本的解码函数
+func (rm *RedisManager) schemaDecoder(prefix string, version int) SchemaDecoder {
+	rm.codecMutex.RLock()
+	defer rm.codecMutex.RUnlock()
+	return rm.schemaDecoders[prefix][version]
+}
+
+// defaultTTL 未指定过期时间（ttl<=0）时使用键所属命名空间的默认过期时间
+func (rm *RedisManager) defaultTTL(key string, ttl time.Duration) time.Duration {
+	if ttl > 0 {
+		return ttl
+	}
+	if ns := rm.namespaceFor(key); ns != nil {
+		return ns.DefaultTTL
+	}
+	return 0
+}
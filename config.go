@@ -1,6 +1,10 @@
 package redisx
 
-import "time"
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
 
 // RedisMode 定义Redis连接模式
 type RedisMode string
@@ -30,6 +34,48 @@ type RedisConfig struct {
 
 	// 通用配置
 	Common CommonConfig `json:"common" yaml:"common"`
+
+	// 热点键检测配置，为空时不启用
+	HotKeys *HotKeyConfig `json:"hot_keys,omitempty" yaml:"hot_keys,omitempty"`
+
+	// 客户端慢操作日志配置，为空时不启用
+	SlowLog *SlowLogConfig `json:"slow_log,omitempty" yaml:"slow_log,omitempty"`
+
+	// 复制延迟监控配置（哨兵和集群模式），为空时不启用
+	ReplicationLag *ReplicationLagConfig `json:"replication_lag,omitempty" yaml:"replication_lag,omitempty"`
+
+	// 熔断器配置，为空时不启用（按健康检查结果决定是否执行命令）
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker,omitempty" yaml:"circuit_breaker,omitempty"`
+
+	// 封装层重试策略，为空时只使用 go-redis 自身的重试（Common.MaxRetries）
+	Retry *RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// 降级模式配置，为空时不启用（Redis 不可达时直接返回错误）
+	Fallback *FallbackConfig `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+
+	// 自动流水线配置，为空时不启用
+	AutoPipeline *AutoPipelineConfig `json:"auto_pipeline,omitempty" yaml:"auto_pipeline,omitempty"`
+
+	// WAIT 写入确认配置，为空时写入不等待从节点确认（DurableWriter 使用默认值）
+	Durability *DurabilityConfig `json:"durability,omitempty" yaml:"durability,omitempty"`
+
+	// 键命名空间配置，按键前缀匹配，多个前缀都匹配时取最长的前缀
+	Namespaces []NamespaceConfig `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// 日志输出，为空时使用 slog.Default()，可设置为 NopLogger{} 关闭日志
+	Logger Logger `json:"-" yaml:"-"`
+}
+
+// NamespaceConfig 键命名空间（前缀）配置，覆盖该前缀下所有键的通用配置
+// DefaultTTL、Codec、Compression 作用于结构化数据接口（Set、GetValue、GetOrLoad、MGetOrLoad 等），不影响 SetS/SetB 等原始接口
+type NamespaceConfig struct {
+	Prefix          string        `json:"prefix" yaml:"prefix"`                                           // 键前缀，如 "user:"
+	TTLJitter       float64       `json:"ttl_jitter,omitempty" yaml:"ttl_jitter,omitempty"`               // 过期时间抖动比例，0 表示沿用 common.ttl_jitter，负数表示不抖动
+	DefaultTTL      time.Duration `json:"default_ttl,omitempty" yaml:"default_ttl,omitempty"`             // 未指定过期时间（<=0）时使用的过期时间，0 表示不过期
+	Codec           string        `json:"codec,omitempty" yaml:"codec,omitempty"`                         // 编解码器名称（通过 RegisterCodec 注册），为空时使用管理器的编解码器
+	Compression     string        `json:"compression,omitempty" yaml:"compression,omitempty"`             // 压缩算法：none 或 gzip，默认 none
+	CompressMinSize int           `json:"compress_min_size,omitempty" yaml:"compress_min_size,omitempty"` // 序列化后达到该字节数才压缩，默认 1024
+	SchemaVersion   int           `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`       // 数据结构版本，大于0时序列化数据附带版本标记，读取旧版本数据时使用 RegisterSchemaDecoder 注册的解码函数
 }
 
 // SingleConfig 单例Redis配置
@@ -46,6 +92,14 @@ type MasterSlaveConfig struct {
 	Password string   `json:"password" yaml:"password"` // Redis密码
 	Database int      `json:"database" yaml:"database"` // 数据库编号
 
+	// Ring 分片配置（未启用哨兵时），设置后代替 Addrs
+	Shards []RingShardConfig `json:"shards,omitempty" yaml:"shards,omitempty"`
+
+	// Ring 键分布配置
+	RingHash          string                                     `json:"ring_hash,omitempty" yaml:"ring_hash,omitempty"`         // 分布算法：rendezvous（默认）或 ketama（兼容 twemproxy，分片名称须与 twemproxy 的 server 名称一致）
+	RingKeyHash       string                                     `json:"ring_key_hash,omitempty" yaml:"ring_key_hash,omitempty"` // ketama 的键哈希函数：fnv1a_64（默认）、fnv1a_32、fnv1_64、fnv1_32、md5
+	NewConsistentHash func(shards []string) redis.ConsistentHash `json:"-" yaml:"-"`                                             // 自定义一致性哈希，设置后忽略 RingHash，参数为当前可用的分片名称
+
 	// 哨兵配置（可选 - 仅添加监控和故障转移能力）
 	Sentinel *SentinelConfig `json:"sentinel,omitempty" yaml:"sentinel,omitempty"`
 }
@@ -61,7 +115,7 @@ type SentinelConfig struct {
 	// 故障转移配置
 	RouteRandomly  bool `json:"route_randomly,omitempty" yaml:"route_randomly,omitempty"`     // 把只读命令随机到一个节点
 	RouteByLatency bool `json:"route_by_latency,omitempty" yaml:"route_by_latency,omitempty"` // 把只读命令发送到响应最快的节点
-	ReplicaOnly    bool `json:"replica_only,omitempty" yaml:"replica_only,omitempty"`         // 把所有命令发送到发送到只读节点
+	ReplicaOnly    bool `json:"replica_only,omitempty" yaml:"replica_only,omitempty"`         // 只连接哨兵发现的从节点，写命令在发送前被拒绝（INVALID_OPERATION），用于不能访问主节点的分析类负载
 }
 
 // ClusterConfig Redis集群配置（支持主从结构）
@@ -94,13 +148,32 @@ type CommonConfig struct {
 	MinRetryBackoff time.Duration `json:"min_retry_backoff" yaml:"min_retry_backoff"` // 最小重试间隔，默认8ms
 	MaxRetryBackoff time.Duration `json:"max_retry_backoff" yaml:"max_retry_backoff"` // 最大重试间隔，默认512ms
 
+	// 事务配置
+	WatchTxAttempts int `json:"watch_tx_attempts,omitempty" yaml:"watch_tx_attempts,omitempty"` // WatchTx 因监视的键被修改而提交失败时的最大尝试次数，默认3
+
+	// 并发限制配置
+	MaxInflight  int           `json:"max_inflight,omitempty" yaml:"max_inflight,omitempty"`   // 同时执行的命令数上限（流水线计为一个，阻塞命令不计入），Redis 停顿时防止调用方 goroutine 无限堆积，默认0不限制
+	InflightWait time.Duration `json:"inflight_wait,omitempty" yaml:"inflight_wait,omitempty"` // 达到上限时等待空位的最长时间，超时返回 TIMEOUT；默认0不等待，直接返回 BREAK
+
 	// 健康检查配置
-	HealthCheck         bool          `json:"health_check" yaml:"health_check"`                   // 是否启用健康检查，默认true
-	HealthCheckInterval time.Duration `json:"health_check_interval" yaml:"health_check_interval"` // 健康检查间隔，默认30秒
+	HealthCheck         bool          `json:"health_check" yaml:"health_check"`                                 // 是否启用健康检查，默认true
+	HealthCheckInterval time.Duration `json:"health_check_interval" yaml:"health_check_interval"`               // 健康检查间隔，默认30秒
+	BypassHealthGate    bool          `json:"bypass_health_gate,omitempty" yaml:"bypass_health_gate,omitempty"` // 健康检查失败时仍执行命令（由实际错误决定结果），默认false即直接返回CONNECTION_FAILED
+	LazyConnect         bool          `json:"lazy_connect,omitempty" yaml:"lazy_connect,omitempty"`             // 启动时Redis不可用不报错，管理器以不健康状态创建，由健康检查循环（或首个操作触发）自动建立连接，默认false
 
 	// 统计配置
 	EnableStats   bool          `json:"enable_stats" yaml:"enable_stats"`     // 是否启用统计，默认false
 	StatsInterval time.Duration `json:"stats_interval" yaml:"stats_interval"` // 统计输出间隔，默认60秒
+
+	// 读合并配置
+	CoalesceReads bool `json:"coalesce_reads,omitempty" yaml:"coalesce_reads,omitempty"` // 合并同一进程内对同一个键并发的 GET 和 HGETALL 请求，热点键突发访问时减轻 Redis 压力，默认false
+
+	// 过期时间配置
+	TTLJitter float64 `json:"ttl_jitter,omitempty" yaml:"ttl_jitter,omitempty"` // 过期时间随机抖动比例（0~1），如 0.1 表示 ±10%，避免大量键同时过期，默认0不抖动
+
+	// 协议配置
+	Protocol      int  `json:"protocol" yaml:"protocol"`                                 // RESP协议版本 2 或 3，默认3；服务端不支持时自动回退到2。RESP3 下 Pub/Sub 复用 go-redis 的推送处理，HotKeys.Tracking 使用客户端缓存失效推送
+	UnstableResp3 bool `json:"unstable_resp3,omitempty" yaml:"unstable_resp3,omitempty"` // 允许在RESP3下通过 GetClient 直接使用 go-redis 中响应格式尚不稳定的命令（如 FTInfo）；SearchIndex 的查询和聚合在RESP3下无需开启
 }
 
 // SetDefaults 设置默认值
@@ -132,12 +205,48 @@ func (c *RedisConfig) SetDefaults() {
 	if c.Common.MaxRetryBackoff == 0 {
 		c.Common.MaxRetryBackoff = 512 * time.Millisecond
 	}
+	if c.Common.WatchTxAttempts <= 0 {
+		c.Common.WatchTxAttempts = 3
+	}
 	if c.Common.HealthCheckInterval == 0 {
 		c.Common.HealthCheckInterval = 30 * time.Second
 	}
 	if c.Common.StatsInterval == 0 {
 		c.Common.StatsInterval = 60 * time.Second
 	}
+	if c.Common.Protocol == 0 {
+		c.Common.Protocol = 3
+	}
+
+	if c.HotKeys != nil {
+		c.HotKeys.setDefaults()
+	}
+	if c.SlowLog != nil {
+		c.SlowLog.setDefaults()
+	}
+	if c.ReplicationLag != nil {
+		c.ReplicationLag.setDefaults()
+	}
+	if c.CircuitBreaker != nil {
+		c.CircuitBreaker.setDefaults()
+	}
+	if c.Retry != nil {
+		c.Retry.setDefaults()
+	}
+	if c.Fallback != nil {
+		c.Fallback.setDefaults()
+	}
+	if c.AutoPipeline != nil {
+		c.AutoPipeline.setDefaults()
+	}
+	if c.Durability != nil {
+		c.Durability.setDefaults()
+	}
+	for i := range c.Namespaces {
+		if c.Namespaces[i].CompressMinSize <= 0 {
+			c.Namespaces[i].CompressMinSize = 1024
+		}
+	}
 
 	// 默认启用健康检查和统计
 	c.Common.HealthCheck = true
@@ -163,10 +272,37 @@ func (c *RedisConfig) Validate() error {
 		}
 
 		// 验证基本主从配置
-		if len(c.MasterSlave.Addrs) == 0 {
+		if len(c.MasterSlave.Addrs) == 0 && len(c.MasterSlave.Shards) == 0 {
 			return ErrInvalidConfig.WithMessage("master_slave.slave_addrs is required")
 		}
 
+		// 验证 Ring 分片配置
+		names := make(map[string]bool, len(c.MasterSlave.Shards))
+		addrs := make(map[string]bool, len(c.MasterSlave.Shards))
+		for _, shard := range c.MasterSlave.ringShards() {
+			if shard.Addr == "" {
+				return ErrInvalidConfig.WithMessage("master_slave.shards.addr is required: " + shard.Name)
+			}
+			if shard.Weight < 0 {
+				return ErrInvalidConfig.WithMessage("master_slave.shards.weight must not be negative: " + shard.Name)
+			}
+			if names[shard.Name] {
+				return ErrInvalidConfig.WithMessage("duplicate master_slave.shards.name: " + shard.Name)
+			}
+			if addrs[shard.Addr] {
+				return ErrInvalidConfig.WithMessage("duplicate master_slave.shards.addr: " + shard.Addr)
+			}
+			names[shard.Name], addrs[shard.Addr] = true, true
+		}
+		switch c.MasterSlave.RingHash {
+		case "", RingHashRendezvous, RingHashKetama:
+		default:
+			return ErrInvalidConfig.WithMessage("master_slave.ring_hash must be 'rendezvous' or 'ketama'")
+		}
+		if _, ok := ketamaKeyHashes[c.MasterSlave.RingKeyHash]; !ok && c.MasterSlave.RingKeyHash != "" {
+			return ErrInvalidConfig.WithMessage("unsupported master_slave.ring_key_hash: " + c.MasterSlave.RingKeyHash)
+		}
+
 		// 验证哨兵配置（如果启用）
 		if c.MasterSlave.Sentinel != nil && c.MasterSlave.Sentinel.Enabled {
 			if c.MasterSlave.Sentinel.MasterName == "" {
@@ -176,6 +312,10 @@ func (c *RedisConfig) Validate() error {
 			if len(c.MasterSlave.Sentinel.SentinelAddrs) == 0 {
 				return ErrInvalidConfig.WithMessage("sentinel.sentinel_addrs is required when sentinel is enabled")
 			}
+
+			if c.MasterSlave.Sentinel.ReplicaOnly && (c.MasterSlave.Sentinel.RouteRandomly || c.MasterSlave.Sentinel.RouteByLatency) {
+				return ErrInvalidConfig.WithMessage("sentinel.replica_only cannot be combined with route_randomly or route_by_latency")
+			}
 		}
 	case ModeCluster:
 		if c.Cluster == nil {
@@ -188,5 +328,34 @@ func (c *RedisConfig) Validate() error {
 		return ErrInvalidConfig.WithMessage("invalid mode, must be 'single', 'master_slave' or 'cluster'")
 	}
 
+	if c.Common.Protocol != 0 && c.Common.Protocol != 2 && c.Common.Protocol != 3 {
+		return ErrInvalidConfig.WithMessage("common.protocol must be 2 or 3")
+	}
+
+	if c.Common.TTLJitter < 0 || c.Common.TTLJitter >= 1 {
+		return ErrInvalidConfig.WithMessage("common.ttl_jitter must be in [0, 1)")
+	}
+	for _, ns := range c.Namespaces {
+		if ns.Prefix == "" {
+			return ErrInvalidConfig.WithMessage("namespaces.prefix is required")
+		}
+		if ns.TTLJitter >= 1 {
+			return ErrInvalidConfig.WithMessage("namespaces.ttl_jitter must be less than 1: " + ns.Prefix)
+		}
+		if ns.Compression != "" && ns.Compression != CompressionNone && ns.Compression != CompressionGzip {
+			return ErrInvalidConfig.WithMessage("namespaces.compression must be none or gzip: " + ns.Prefix)
+		}
+	}
+
+	if c.Fallback != nil && c.Fallback.WritePolicy != "" &&
+		c.Fallback.WritePolicy != FallbackWriteDrop && c.Fallback.WritePolicy != FallbackWriteQueue {
+		return ErrInvalidConfig.WithMessage("fallback.write_policy must be drop or queue")
+	}
+
+	if c.Durability != nil && c.Mode == ModeMasterSlave &&
+		(c.MasterSlave.Sentinel == nil || !c.MasterSlave.Sentinel.Enabled) {
+		return ErrInvalidConfig.WithMessage("durability is not supported in ring mode")
+	}
+
 	return nil
 }
@@ -0,0 +1,161 @@
+package redisx
+
+import (
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 死信消息中附加的失败信息字段
+const (
+	dlqFieldSourceStream = "dlq_source_stream"
+	dlqFieldSourceID     = "dlq_source_id"
+	dlqFieldGroup        = "dlq_group"
+	dlqFieldConsumer     = "dlq_consumer"
+	dlqFieldError        = "dlq_error"
+	dlqFieldDeliveries   = "dlq_deliveries"
+	dlqFieldFailedAt     = "dlq_failed_at"
+)
+
+// DeadLetter 死信流中的一条消息
+type DeadLetter struct {
+	ID           string // 死信流中的消息ID
+	SourceStream string
+	SourceID     string
+	Group        string
+	Consumer     string
+	Error        string
+	Deliveries   int64
+	FailedAt     int64                  // 毫秒时间戳
+	Values       map[string]interface{} // 原消息内容
+}
+
+// DeadLetterQueue 死信流的查看和重新投递
+type DeadLetterQueue struct {
+	rm     *RedisManager
+	stream string
+}
+
+// NewDeadLetterQueue 创建死信流操作对象，stream 与 StreamConsumerConfig.DeadLetterStream 一致
+func NewDeadLetterQueue(rm *RedisManager, stream string) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		rm:     rm,
+		stream: stream,
+	}
+}
+
+// parseDeadLetter 从死信消息中拆分失败信息和原消息内容
+func parseDeadLetter(msg redis.XMessage) DeadLetter {
+	dl := DeadLetter{
+		ID:     msg.ID,
+		Values: make(map[string]interface{}, len(msg.Values)),
+	}
+	for k, v := range msg.Values {
+		s, _ := v.(string)
+		switch k {
+		case dlqFieldSourceStream:
+			dl.SourceStream = s
+		case dlqFieldSourceID:
+			dl.SourceID = s
+		case dlqFieldGroup:
+			dl.Group = s
+		case dlqFieldConsumer:
+			dl.Consumer = s
+		case dlqFieldError:
+			dl.Error = s
+		case dlqFieldDeliveries:
+			dl.Deliveries, _ = strconv.ParseInt(s, 10, 64)
+		case dlqFieldFailedAt:
+			dl.FailedAt, _ = strconv.ParseInt(s, 10, 64)
+		default:
+			dl.Values[k] = v
+		}
+	}
+	return dl
+}
+
+// List 从 start 开始按ID顺序列出死信消息，start 为 "-" 表示从头开始，"(id" 表示从 id 之后开始
+func (q *DeadLetterQueue) List(start string, count int64) CacheResult[[]DeadLetter] {
+	q.rm.stats.IncrTotal()
+
+	if !q.rm.acquire() {
+		return NewCacheError[[]DeadLetter](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer q.rm.release()
+
+	val, err := q.rm.activeClient().XRangeN(q.rm.ctx, q.stream, start, "+", count).Result()
+	if err != nil {
+		q.rm.stats.IncrError()
+		return NewCacheError[[]DeadLetter](REDIS_INNER_ERROR, err)
+	}
+
+	letters := make([]DeadLetter, 0, len(val))
+	for _, msg := range val {
+		letters = append(letters, parseDeadLetter(msg))
+	}
+
+	return NewCacheResult(letters)
+}
+
+// Len 获取死信消息数量
+func (q *DeadLetterQueue) Len() CacheResult[int64] {
+	return q.rm.XLen(q.stream)
+}
+
+// Redrive 将指定的死信消息以原内容重新投递到来源流，并从死信流中删除，返回重新投递的数量
+func (q *DeadLetterQueue) Redrive(ids ...string) CacheResult[int64] {
+	q.rm.stats.IncrTotal()
+
+	if !q.rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer q.rm.release()
+
+	var redriven int64
+	for _, id := range ids {
+		msgs, err := q.rm.activeClient().XRangeN(q.rm.ctx, q.stream, id, id, 1).Result()
+		if err != nil {
+			q.rm.stats.IncrError()
+			return CacheResult[int64]{Val: redriven, ErrCode: innerErrorCode(err), Err: err}
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+
+		dl := parseDeadLetter(msgs[0])
+		if dl.SourceStream == "" {
+			continue
+		}
+
+		// 先写回来源流再删除死信，失败时死信保留，重复执行不会丢消息
+		if err := q.rm.activeClient().XAdd(q.rm.ctx, &redis.XAddArgs{Stream: dl.SourceStream, Values: dl.Values}).Err(); err != nil {
+			q.rm.stats.IncrError()
+			return CacheResult[int64]{Val: redriven, ErrCode: innerErrorCode(err), Err: err}
+		}
+		if err := q.rm.activeClient().XDel(q.rm.ctx, q.stream, id).Err(); err != nil {
+			q.rm.stats.IncrError()
+			return CacheResult[int64]{Val: redriven + 1, ErrCode: innerErrorCode(err), Err: err}
+		}
+		redriven++
+	}
+
+	return NewCacheResult(redriven)
+}
+
+// Delete 从死信流中删除消息，返回删除的数量
+func (q *DeadLetterQueue) Delete(ids ...string) CacheResult[int64] {
+	q.rm.stats.IncrTotal()
+
+	if !q.rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer q.rm.release()
+
+	val, err := q.rm.activeClient().XDel(q.rm.ctx, q.stream, ids...).Result()
+	if err != nil {
+		q.rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
@@ -0,0 +1,107 @@
+package redisx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// debugMaxArgLen 调试日志中单个参数的最大长度，超出部分截断
+	debugMaxArgLen = 64
+	// debugMaxArgs 调试日志中最多输出的参数个数
+	debugMaxArgs = 16
+)
+
+// SetDebug 开启或关闭调试模式，可在运行时切换
+// 开启后每条命令的参数（过长的值截断，密码脱敏）和耗时都通过 Logger 以 Info 级别输出，只适合短时间排查问题
+func (rm *RedisManager) SetDebug(enabled bool) {
+	rm.debug.Store(enabled)
+}
+
+// Debug 是否处于调试模式
+func (rm *RedisManager) Debug() bool {
+	return rm.debug.Load()
+}
+
+// formatDebugArgs 格式化命令参数用于调试日志
+func formatDebugArgs(cmd redis.Cmder) string {
+	args := cmd.Args()
+	redactFrom := len(args)
+	switch cmd.Name() {
+	case "auth":
+		redactFrom = 1
+	case "hello":
+		// HELLO protover AUTH username password
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && strings.EqualFold(s, "auth") {
+				redactFrom = i + 2
+				break
+			}
+		}
+	case "config", "acl":
+		redactFrom = 3
+	}
+
+	parts := make([]string, 0, min(len(args), debugMaxArgs)+1)
+	for i, arg := range args {
+		if i == debugMaxArgs {
+			parts = append(parts, fmt.Sprintf("...(+%d args)", len(args)-debugMaxArgs))
+			break
+		}
+		if i >= redactFrom {
+			parts = append(parts, "***")
+			continue
+		}
+
+		s := fmt.Sprint(arg)
+		if len(s) > debugMaxArgLen {
+			s = fmt.Sprintf("%s...(%d bytes)", s[:debugMaxArgLen], len(s))
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+// debugHook 调试模式下输出每条命令的 go-redis 钩子
+type debugHook struct {
+	rm *RedisManager
+}
+
+func (h debugHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h debugHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !h.rm.Debug() {
+			return next(ctx, cmd)
+		}
+
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.rm.Logger().Info("Redis command", "command", cmd.Name(), "args", formatDebugArgs(cmd),
+			"latency", time.Since(start), "error", err)
+		return err
+	}
+}
+
+func (h debugHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !h.rm.Debug() {
+			return next(ctx, cmds)
+		}
+
+		start := time.Now()
+		err := next(ctx, cmds)
+		latency := time.Since(start)
+		for i, cmd := range cmds {
+			h.rm.Logger().Info("Redis pipeline command", "index", i, "size", len(cmds), "command", cmd.Name(),
+				"args", formatDebugArgs(cmd), "latency", latency, "error", cmd.Err())
+		}
+		return err
+	}
+}
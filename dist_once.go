@@ -0,0 +1,128 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// DistOnceOptions 分布式一次性执行选项
+type DistOnceOptions struct {
+	LeaseTTL     time.Duration // 执行期间的租约时间，执行中自动续期，执行者崩溃后租约过期由其他实例重新执行，默认 30s
+	DoneTTL      time.Duration // 完成标记的保留时间，0 表示永久保留
+	PollInterval time.Duration // 其他实例正在执行时的轮询间隔，默认 500ms
+}
+
+// DistOnce 跨实例的一次性执行保护，用于数据迁移、初始化等全局只能成功执行一次的任务
+// 执行权通过带租约的锁竞争，执行成功后写入完成标记；执行失败或执行者崩溃时其他实例可以重新执行
+type DistOnce struct {
+	rm      *RedisManager
+	doneKey string
+	opts    DistOnceOptions
+	lease   *Lock
+}
+
+// NewDistOnce 创建一次性执行保护，所有实例应使用相同的 key
+func NewDistOnce(rm *RedisManager, key string, opts *DistOnceOptions) *DistOnce {
+	var o DistOnceOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.LeaseTTL <= 0 {
+		o.LeaseTTL = 30 * time.Second
+	}
+	if o.DoneTTL < 0 {
+		o.DoneTTL = 0
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 500 * time.Millisecond
+	}
+
+	base := sameSlotBase(key)
+	return &DistOnce{
+		rm:      rm,
+		doneKey: base + ":done",
+		opts:    o,
+		lease:   rm.NewLock(base+":running", &LockOptions{TTL: o.LeaseTTL, AutoRenew: true}),
+	}
+}
+
+// Do 执行 fn，全局只会成功执行一次
+// 本实例执行成功返回 true；已由其他实例执行完成返回 false；其他实例正在执行时等待其完成或租约过期
+// fn 返回错误时结果为 BREAK（不写入完成标记，之后的调用会重新执行）
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (o *DistOnce) Do(ctx context.Context, fn func(ctx context.Context) error) CacheResult[bool] {
+	if fn == nil {
+		return NewCacheError[bool](INVALID_OPERATION, ErrInvalidOperation.WithMessage("once func is nil"))
+	}
+
+	for {
+		done := o.Done()
+		if !done.IsOK() {
+			return done
+		}
+		if done.Val {
+			return NewCacheResult(false)
+		}
+
+		res := o.lease.TryLockCtx(ctx)
+		if !res.IsOK() {
+			return res
+		}
+		if res.Val {
+			return o.run(ctx, fn)
+		}
+
+		// 其他实例正在执行，等待其完成；执行者崩溃时租约过期后由本实例接手
+		if !sleepContext(ctx, o.opts.PollInterval) {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NewCacheError[bool](TIMEOUT, ErrOperationTimeout.WithError(ctx.Err()))
+			}
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(ctx.Err()))
+		}
+	}
+}
+
+// run 持有租约时执行 fn，成功后写入完成标记
+func (o *DistOnce) run(ctx context.Context, fn func(ctx context.Context) error) CacheResult[bool] {
+	defer o.lease.Unlock()
+
+	// 获取租约前的检查与获取之间可能已有其他实例执行完成
+	done := o.Done()
+	if !done.IsOK() {
+		return done
+	}
+	if done.Val {
+		return NewCacheResult(false)
+	}
+
+	if err := fn(ctx); err != nil {
+		return NewCacheError[bool](BREAK, err)
+	}
+
+	// 先写完成标记再释放租约，两者之间崩溃时其他实例获得租约后会看到完成标记
+	marker := strconv.FormatInt(time.Now().UnixMilli(), 10) + ":" + o.lease.Token()
+	if res := o.rm.SetS(o.doneKey, marker, o.opts.DoneTTL); !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(true)
+}
+
+// Done 是否已执行完成
+func (o *DistOnce) Done() CacheResult[bool] {
+	res := o.rm.Exists(o.doneKey)
+	if !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(res.Val > 0)
+}
+
+// Reset 清除完成标记，之后的 Do 会重新执行
+func (o *DistOnce) Reset() CacheResult[bool] {
+	res := o.rm.Del(o.doneKey)
+	if !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(res.Val > 0)
+}
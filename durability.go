@@ -0,0 +1,213 @@
+package redisx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DurabilityConfig WAIT 写入确认配置：写命令之后在同一连接上执行 WAIT，等待足够的从节点确认后才返回成功
+type DurabilityConfig struct {
+	Replicas int           `json:"replicas,omitempty" yaml:"replicas,omitempty"` // 需要确认写入的从节点数，默认 1
+	Timeout  time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`   // 等待确认的超时，默认 100ms
+	Locks    bool          `json:"locks,omitempty" yaml:"locks,omitempty"`       // TryLock 获取锁时等待确认
+}
+
+// setDefaults 设置默认值
+func (c *DurabilityConfig) setDefaults() {
+	if c.Replicas <= 0 {
+		c.Replicas = 1
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 100 * time.Millisecond
+	}
+}
+
+// DurableWriter 等待从节点确认的写操作，由 Durable 创建
+// 写入在主节点执行成功、但超时前确认的从节点数不足时，结果为 TIMEOUT，Val 仍为写命令的结果（写入未回滚，可能在故障转移中丢失）
+// 集群模式下直接在键所在的主节点上执行，不经过命令钩子（日志、指标、拦截器等），双写迁移和热点键本地缓存的移除在写入后单独处理
+type DurableWriter struct {
+	rm     *RedisManager
+	config DurabilityConfig
+}
+
+// Durable 返回等待从节点确认的写操作，config 为 nil 时使用全局配置 Durability（未配置时为默认值）
+func (rm *RedisManager) Durable(config *DurabilityConfig) *DurableWriter {
+	var c DurabilityConfig
+	switch {
+	case config != nil:
+		c = *config
+	case rm.config.Durability != nil:
+		c = *rm.config.Durability
+	}
+	c.setDefaults()
+	return &DurableWriter{rm: rm, config: c}
+}
+
+// locksDurable 全局配置是否要求获取锁时等待确认
+func (rm *RedisManager) locksDurable() bool {
+	return rm.config.Durability != nil && rm.config.Durability.Locks
+}
+
+// pipeline 选择 key 所在主节点的流水线，WAIT 必须与写命令在同一连接上执行；direct 表示流水线不经过管理器的命令钩子
+func (w *DurableWriter) pipeline(key string) (pipe redis.Pipeliner, direct bool, err error) {
+	switch client := w.rm.GetClient().(type) {
+	case *redis.ClusterClient:
+		node, err := client.MasterForKey(w.rm.ctx, key)
+		if err != nil {
+			return nil, false, err
+		}
+		return node.Pipeline(), true, nil
+	case *redis.Ring:
+		return nil, false, ErrInvalidOperation.WithMessage("durable writes are not supported in ring mode")
+	case nil:
+		return nil, false, ErrConnectionFailed
+	default:
+		return client.Pipeline(), false, nil
+	}
+}
+
+// exec 在同一条流水线中执行写命令和 WAIT，返回写命令的错误码和错误
+func (w *DurableWriter) exec(key string, queue func(pipe redis.Pipeliner) redis.Cmder) (ErrorCode, error) {
+	w.rm.stats.IncrTotal()
+
+	if !w.rm.acquire() {
+		return CONNECTION_FAILED, ErrConnectionFailed
+	}
+	defer w.rm.release()
+
+	pipe, direct, err := w.pipeline(key)
+	if err != nil {
+		w.rm.stats.IncrError()
+		return errorCodeOf(err), err
+	}
+
+	m := w.rm.mirror.Load()
+	if direct && m != nil && m.cutover.Load() {
+		// 已切换到迁移目标，写入改为在目标上执行
+		return (&DurableWriter{rm: m.target, config: w.config}).exec(key, queue)
+	}
+
+	cmd := queue(pipe)
+	wait := pipe.Do(w.rm.ctx, "wait", w.config.Replicas, w.config.Timeout.Milliseconds())
+	_, _ = pipe.Exec(w.rm.ctx)
+
+	// 直接在节点上执行的写命令没有经过双写和热点键缓存的钩子
+	if direct {
+		if m != nil {
+			m.dispatch(w.rm.ctx, m.capture([]redis.Cmder{cmd}))
+		}
+		if w.rm.hotCache != nil {
+			w.rm.evictHotKeysFor(cmd)
+		}
+	}
+
+	if err := cmd.Err(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return KEY_NOT_FOUND, ErrKeyNotFound
+		}
+		w.rm.stats.IncrError()
+		return innerErrorCode(err), err
+	}
+	acked, err := wait.Int64()
+	if err != nil {
+		w.rm.stats.IncrError()
+		return innerErrorCode(err), err
+	}
+	if acked < int64(w.config.Replicas) {
+		w.rm.stats.IncrError()
+		return TIMEOUT, ErrOperationTimeout.WithMessage(fmt.Sprintf("write acknowledged by %d of %d replicas", acked, w.config.Replicas))
+	}
+	return OK, nil
+}
+
+// durableExec 执行写命令并等待确认，结果为写命令的值
+func durableExec[T any, C interface {
+	redis.Cmder
+	Val() T
+}](w *DurableWriter, key string, queue func(pipe redis.Pipeliner) C) CacheResult[T] {
+	var cmd C
+	queued := false
+	code, err := w.exec(key, func(pipe redis.Pipeliner) redis.Cmder {
+		cmd, queued = queue(pipe), true
+		return cmd
+	})
+
+	res := CacheResult[T]{ErrCode: code, Err: err}
+	if queued {
+		res.Val = cmd.Val()
+	}
+	return res
+}
+
+// SetS 设置字符串值
+func (w *DurableWriter) SetS(key, value string, expiration time.Duration) CacheResult[string] {
+	return durableExec(w, key, func(pipe redis.Pipeliner) *redis.StatusCmd {
+		return pipe.Set(w.rm.ctx, key, value, expiration)
+	})
+}
+
+// SetB 设置字节数组值
+func (w *DurableWriter) SetB(key string, value []byte, expiration time.Duration) CacheResult[string] {
+	return durableExec(w, key, func(pipe redis.Pipeliner) *redis.StatusCmd {
+		return pipe.Set(w.rm.ctx, key, value, expiration)
+	})
+}
+
+// SetNX 键不存在时设置值，返回是否设置成功
+func (w *DurableWriter) SetNX(key, value string, expiration time.Duration) CacheResult[bool] {
+	return durableExec(w, key, func(pipe redis.Pipeliner) *redis.BoolCmd {
+		return pipe.SetNX(w.rm.ctx, key, value, expiration)
+	})
+}
+
+// Del 删除键
+func (w *DurableWriter) Del(key string) CacheResult[int64] {
+	return durableExec(w, key, func(pipe redis.Pipeliner) *redis.IntCmd {
+		return pipe.Del(w.rm.ctx, key)
+	})
+}
+
+// HSet 设置哈希字段
+func (w *DurableWriter) HSet(key string, values ...interface{}) CacheResult[int64] {
+	return durableExec(w, key, func(pipe redis.Pipeliner) *redis.IntCmd {
+		return pipe.HSet(w.rm.ctx, key, values...)
+	})
+}
+
+// EvalScript 执行注册的 Lua 脚本，脚本的键须位于同一个哈希槽，以第一个键选择节点
+func (w *DurableWriter) EvalScript(name string, keys []string, args ...interface{}) CacheResult[interface{}] {
+	script, exists := w.rm.registeredScript(name)
+	if !exists {
+		return NewCacheError[interface{}](INVALID_OPERATION, ErrInvalidOperation.WithMessage("script not found: "+name))
+	}
+	script.lastUsed.Store(time.Now().UnixNano())
+
+	var key string
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+
+	res := durableExec(w, key, func(pipe redis.Pipeliner) *redis.Cmd {
+		return pipe.EvalSha(w.rm.ctx, script.sha, keys, args...)
+	})
+	if res.Err != nil && redis.HasErrorPrefix(res.Err, "NOSCRIPT") {
+		go w.rm.loadScripts()
+		res = durableExec(w, key, func(pipe redis.Pipeliner) *redis.Cmd {
+			return pipe.Eval(w.rm.ctx, script.source, keys, args...)
+		})
+	}
+	return res
+}
+
+// TryLock 尝试获取分布式锁，获取成功后等待从节点确认，避免主节点故障转移后锁丢失
+// 已获取但确认不足时释放锁并返回 TIMEOUT
+func (w *DurableWriter) TryLock(lockKey, lockValue string, expiration time.Duration) CacheResult[bool] {
+	result := w.EvalScript(ScriptKeyLock, []string{lockKey}, lockValue, expiration.Milliseconds())
+	if result.ErrCode == TIMEOUT && result.Val == int64(1) {
+		w.rm.ReleaseLock(lockKey, lockValue)
+	}
+	return lockScriptResult(result)
+}
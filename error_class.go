@@ -0,0 +1,138 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrorClass 错误类别，用于区分网络故障和使用错误
+type ErrorClass string
+
+const (
+	// ErrorClassConnection 连接断开或节点不可用（含 LOADING、MASTERDOWN、CLUSTERDOWN、TRYAGAIN、连接数超限）
+	ErrorClassConnection ErrorClass = "connection"
+	// ErrorClassTimeout 超时（含连接池等待超时）
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassRedirect 集群 MOVED/ASK 重定向
+	ErrorClassRedirect ErrorClass = "redirect"
+	// ErrorClassOOM 服务端内存不足
+	ErrorClassOOM ErrorClass = "oom"
+	// ErrorClassApplication 服务端拒绝的命令（WRONGTYPE、语法错误、NOSCRIPT、权限等）
+	ErrorClassApplication ErrorClass = "application"
+	// ErrorClassOther 其他错误（如调用方取消）
+	ErrorClassOther ErrorClass = "other"
+)
+
+// ClassifyError 对 go-redis 返回的错误分类，err 为 nil 或 redis.Nil 时返回空字符串
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return ""
+	}
+
+	if _, ok := redis.IsMovedError(err); ok {
+		return ErrorClassRedirect
+	}
+	if _, ok := redis.IsAskError(err); ok {
+		return ErrorClassRedirect
+	}
+
+	var netErr net.Error
+	code := errorCodeOf(err)
+	switch {
+	case code == TIMEOUT:
+		return ErrorClassTimeout
+	case code == CONNECTION_FAILED:
+		return ErrorClassConnection
+	case errors.Is(err, context.Canceled):
+		return ErrorClassOther
+	case redis.IsOOMError(err):
+		return ErrorClassOOM
+	case errors.Is(err, redis.ErrClosed), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &netErr),
+		redis.IsLoadingError(err), redis.IsMasterDownError(err), redis.IsClusterDownError(err),
+		redis.IsTryAgainError(err), redis.IsMaxClientsError(err):
+		return ErrorClassConnection
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		return ErrorClassApplication
+	}
+	return ErrorClassOther
+}
+
+// errorClasses 所有错误类别，顺序与 RedisStats 中的计数下标对应
+var errorClasses = [...]ErrorClass{
+	ErrorClassConnection,
+	ErrorClassTimeout,
+	ErrorClassRedirect,
+	ErrorClassOOM,
+	ErrorClassApplication,
+	ErrorClassOther,
+}
+
+// recordErrorClass 按类别计数错误
+func (s *RedisStats) recordErrorClass(class ErrorClass) {
+	for i, c := range errorClasses {
+		if c == class {
+			s.errorClassCounts[i].Add(1)
+			return
+		}
+	}
+}
+
+// ErrorClasses 获取按类别统计的命令错误数，只包含发生过的类别
+func (s *RedisStats) ErrorClasses() map[ErrorClass]int64 {
+	classes := make(map[ErrorClass]int64)
+	for i, class := range errorClasses {
+		if n := s.errorClassCounts[i].Load(); n > 0 {
+			classes[class] = n
+		}
+	}
+	return classes
+}
+
+// redirectHook 安装在集群各节点客户端上的钩子，统计被集群客户端自动处理的 MOVED/ASK 重定向
+type redirectHook struct {
+	stats *RedisStats
+	moved func() // 收到 MOVED 时调用，用于检查集群拓扑变化
+}
+
+func (h redirectHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h redirectHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if class := ClassifyError(err); class == ErrorClassRedirect {
+			h.stats.recordErrorClass(class)
+			h.notifyMoved(err)
+		}
+		return err
+	}
+}
+
+func (h redirectHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			if class := ClassifyError(cmd.Err()); class == ErrorClassRedirect {
+				h.stats.recordErrorClass(class)
+				h.notifyMoved(cmd.Err())
+			}
+		}
+		return err
+	}
+}
+
+// notifyMoved MOVED 表示哈希槽已迁移（ASK 为迁移中的临时重定向，不通知）
+func (h redirectHook) notifyMoved(err error) {
+	if h.moved != nil && redis.HasErrorPrefix(err, "MOVED") {
+		h.moved()
+	}
+}
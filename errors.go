@@ -1,6 +1,8 @@
 package redisx
 
 import (
+	"context"
+	"errors"
 	"fmt"
 )
 
@@ -30,18 +32,27 @@ const (
 	CLUSTER_NOT_READY
 	// HEALTH_CHECK_FAILED 健康检查失败
 	HEALTH_CHECK_FAILED
+	// MODULE_NOT_LOADED 服务端未加载所需模块
+	MODULE_NOT_LOADED
+
+	// numErrorCodes 错误码个数，用于按错误码计数；新增的错误码须加在此之前
+	numErrorCodes
 )
 
 func (e ErrorCode) String() string {
 	names := map[ErrorCode]string{
-		OK:                "OK",
-		INTERRUPTED:       "INTERRUPTED",
-		TIMEOUT:           "TIMEOUT",
-		BREAK:             "BREAK",
-		REDIS_INNER_ERROR: "REDIS_INNER_ERROR",
-		CONNECTION_FAILED: "CONNECTION_FAILED",
-		KEY_NOT_FOUND:     "KEY_NOT_FOUND",
-		INVALID_CONFIG:    "INVALID_CONFIG",
+		OK:                  "OK",
+		INTERRUPTED:         "INTERRUPTED",
+		TIMEOUT:             "TIMEOUT",
+		BREAK:               "BREAK",
+		REDIS_INNER_ERROR:   "REDIS_INNER_ERROR",
+		CONNECTION_FAILED:   "CONNECTION_FAILED",
+		KEY_NOT_FOUND:       "KEY_NOT_FOUND",
+		INVALID_CONFIG:      "INVALID_CONFIG",
+		INVALID_OPERATION:   "INVALID_OPERATION",
+		CLUSTER_NOT_READY:   "CLUSTER_NOT_READY",
+		HEALTH_CHECK_FAILED: "HEALTH_CHECK_FAILED",
+		MODULE_NOT_LOADED:   "MODULE_NOT_LOADED",
 	}
 	return names[e]
 }
@@ -51,6 +62,7 @@ type CacheResult[T any] struct {
 	Val     T
 	ErrCode ErrorCode
 	Err     error
+	Stale   bool // 降级模式下的结果：读取的值来自本地快照，可能已过期；写入已排队，恢复后重放
 }
 
 // IsOK 返回操作是否成功
@@ -75,8 +87,12 @@ func NewCacheResult[T any](val T) CacheResult[T] {
 	}
 }
 
-// NewCacheError 创建一个错误的缓存结果
+// NewCacheError 创建一个错误的缓存结果，REDIS_INNER_ERROR 中的 context 错误细分为 TIMEOUT 和 INTERRUPTED
 func NewCacheError[T any](errCode ErrorCode, err error) CacheResult[T] {
+	if errCode == REDIS_INNER_ERROR {
+		errCode = innerErrorCode(err)
+	}
+
 	var zero T
 	return CacheResult[T]{
 		Val:     zero,
@@ -85,6 +101,22 @@ func NewCacheError[T any](errCode ErrorCode, err error) CacheResult[T] {
 	}
 }
 
+// innerErrorCode 执行命令出错时的错误码：context 超时为 TIMEOUT，context 取消为 INTERRUPTED，
+// 命令钩子返回的 RedisError（如熔断、并发限制）为其错误码，其他为 REDIS_INNER_ERROR
+func innerErrorCode(err error) ErrorCode {
+	var redisErr *RedisError
+	switch {
+	case errors.As(err, &redisErr):
+		return redisErr.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return TIMEOUT
+	case errors.Is(err, context.Canceled):
+		return INTERRUPTED
+	default:
+		return REDIS_INNER_ERROR
+	}
+}
+
 // RedisError Redis错误类型
 type RedisError struct {
 	Code    ErrorCode
@@ -120,9 +152,11 @@ var (
 	ErrInvalidConfig     = &RedisError{Code: INVALID_CONFIG, Message: "invalid config"}
 	ErrConnectionFailed  = &RedisError{Code: CONNECTION_FAILED, Message: "connection failed"}
 	ErrOperationTimeout  = &RedisError{Code: TIMEOUT, Message: "operation timeout"}
+	ErrInterrupted       = &RedisError{Code: INTERRUPTED, Message: "operation interrupted"}
 	ErrOperationFailed   = &RedisError{Code: REDIS_INNER_ERROR, Message: "operation failed"}
 	ErrKeyNotFound       = &RedisError{Code: KEY_NOT_FOUND, Message: "key not found"}
 	ErrInvalidOperation  = &RedisError{Code: INVALID_OPERATION, Message: "invalid operation"}
 	ErrClusterNotReady   = &RedisError{Code: CLUSTER_NOT_READY, Message: "cluster not ready"}
 	ErrHealthCheckFailed = &RedisError{Code: HEALTH_CHECK_FAILED, Message: "health check failed"}
+	ErrModuleNotLoaded   = &RedisError{Code: MODULE_NOT_LOADED, Message: "module not loaded"}
 )
@@ -0,0 +1,146 @@
+package redisx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EventBusBackend 事件总线的投递方式
+type EventBusBackend string
+
+const (
+	// EventBusPubSub 基于 Pub/Sub，发后即忘，订阅者离线期间的事件会丢失
+	EventBusPubSub EventBusBackend = "pubsub"
+	// EventBusStream 基于 Stream 消费者组，事件持久化，至少投递一次
+	EventBusStream EventBusBackend = "stream"
+)
+
+// eventPayloadField 事件内容在流消息中的字段名
+const eventPayloadField = "payload"
+
+// Event 事件
+type Event struct {
+	Topic   string
+	ID      string // 仅 stream 后端有值
+	Payload []byte
+}
+
+// EventHandler 事件处理函数
+// pubsub 后端返回的错误只会被记录；stream 后端返回错误时按 StreamConsumer 的策略重试
+type EventHandler func(ctx context.Context, event Event) error
+
+// EventSubscription 事件订阅句柄
+type EventSubscription interface {
+	Close() error
+}
+
+// EventBus 事件总线，两种后端使用相同的接口，业务代码无需感知投递语义
+type EventBus interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string, handler EventHandler) (EventSubscription, error)
+}
+
+// EventBusConfig 事件总线配置
+// stream 后端中 topic 即流名，Group 和 Consumer 必填，Consumer 为消费者配置模板（其中的 Stream、Group、Consumer 会被覆盖）
+type EventBusConfig struct {
+	Backend  EventBusBackend      `json:"backend" yaml:"backend"`
+	Group    string               `json:"group,omitempty" yaml:"group,omitempty"`
+	Consumer string               `json:"consumer,omitempty" yaml:"consumer,omitempty"`
+	MaxLen   int64                `json:"max_len,omitempty" yaml:"max_len,omitempty"` // 发布时近似裁剪的流长度，0 表示不裁剪
+	Options  StreamConsumerConfig `json:"-" yaml:"-"`
+}
+
+// NewEventBus 根据配置创建事件总线
+func NewEventBus(rm *RedisManager, config EventBusConfig) (EventBus, error) {
+	switch config.Backend {
+	case EventBusPubSub:
+		return &pubSubEventBus{rm: rm}, nil
+	case EventBusStream:
+		if config.Group == "" || config.Consumer == "" {
+			return nil, ErrInvalidConfig.WithMessage("stream event bus requires group and consumer")
+		}
+		return &streamEventBus{rm: rm, config: config}, nil
+	default:
+		return nil, ErrInvalidConfig.WithMessage(fmt.Sprintf("unsupported event bus backend: %s", config.Backend))
+	}
+}
+
+// pubSubEventBus 基于 Pub/Sub 的事件总线
+type pubSubEventBus struct {
+	rm *RedisManager
+}
+
+func (b *pubSubEventBus) Publish(ctx context.Context, topic string, payload []byte) error {
+	if res := b.rm.Publish(topic, payload); !res.IsOK() {
+		return res.Err
+	}
+	return nil
+}
+
+func (b *pubSubEventBus) Subscribe(ctx context.Context, topic string, handler EventHandler) (EventSubscription, error) {
+	if handler == nil {
+		return nil, ErrInvalidOperation.WithMessage("event handler is nil")
+	}
+
+	res := b.rm.Subscribe(ctx, func(ctx context.Context, msg *redis.Message) error {
+		return handler(ctx, Event{Topic: msg.Channel, Payload: []byte(msg.Payload)})
+	}, topic)
+	if !res.IsOK() {
+		return nil, res.Err
+	}
+	return res.Val, nil
+}
+
+// streamEventBus 基于 Stream 的事件总线
+type streamEventBus struct {
+	rm     *RedisManager
+	config EventBusConfig
+}
+
+func (b *streamEventBus) Publish(ctx context.Context, topic string, payload []byte) error {
+	var opts *XAddOptions
+	if b.config.MaxLen > 0 {
+		opts = &XAddOptions{MaxLen: b.config.MaxLen, Approx: true}
+	}
+
+	res := b.rm.XAdd(topic, map[string]interface{}{eventPayloadField: payload}, opts)
+	if !res.IsOK() {
+		return res.Err
+	}
+	return nil
+}
+
+func (b *streamEventBus) Subscribe(ctx context.Context, topic string, handler EventHandler) (EventSubscription, error) {
+	if handler == nil {
+		return nil, ErrInvalidOperation.WithMessage("event handler is nil")
+	}
+
+	config := b.config.Options
+	config.Stream = topic
+	config.Group = b.config.Group
+	config.Consumer = b.config.Consumer
+
+	consumer, err := NewStreamConsumer(b.rm, config, func(ctx context.Context, msg StreamMessage) error {
+		payload, _ := msg.Values[eventPayloadField].(string)
+		return handler(ctx, Event{Topic: msg.Stream, ID: msg.ID, Payload: []byte(payload)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := consumer.Start(ctx); err != nil {
+		return nil, err
+	}
+	return &streamEventSubscription{consumer: consumer}, nil
+}
+
+// streamEventSubscription 将 StreamConsumer 适配为 EventSubscription
+type streamEventSubscription struct {
+	consumer *StreamConsumer
+}
+
+func (s *streamEventSubscription) Close() error {
+	s.consumer.Stop()
+	return nil
+}
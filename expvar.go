@@ -0,0 +1,58 @@
+package redisx
+
+import (
+	"expvar"
+)
+
+// PublishExpvar 将统计信息以名为 prefix 的 expvar 变量发布，已通过 /debug/vars 暴露 expvar 的服务无需额外依赖即可采集
+// 变量在每次读取时计算；expvar 变量无法注销，同名变量已存在时返回 INVALID_OPERATION
+func (rm *RedisManager) PublishExpvar(prefix string) error {
+	if prefix == "" {
+		return ErrInvalidConfig.WithMessage("expvar prefix is required")
+	}
+	if expvar.Get(prefix) != nil {
+		return ErrInvalidOperation.WithMessage("expvar already published: " + prefix)
+	}
+
+	expvar.Publish(prefix, expvar.Func(rm.expvarSnapshot))
+	return nil
+}
+
+// expvarSnapshot 生成 expvar 输出的统计快照
+func (rm *RedisManager) expvarSnapshot() interface{} {
+	total, errors, uptime := rm.stats.GetStats()
+
+	commands := make(map[string]interface{})
+	for _, c := range rm.CommandMetrics() {
+		errs := make(map[string]int64, len(c.Errors))
+		for code, n := range c.Errors {
+			errs[code.String()] = n
+		}
+		var avg float64
+		if c.Count > 0 {
+			avg = c.Sum.Seconds() / float64(c.Count)
+		}
+		commands[c.Command] = map[string]interface{}{
+			"count":           c.Count,
+			"avg_latency_sec": avg,
+			"errors":          errs,
+		}
+	}
+
+	snapshot := map[string]interface{}{
+		"mode":           string(rm.config.Mode),
+		"healthy":        rm.IsHealthy(),
+		"total":          total,
+		"errors":         errors,
+		"error_classes":  rm.stats.ErrorClasses(),
+		"uptime_seconds": uptime.Seconds(),
+		"commands":       commands,
+		"namespaces":     rm.stats.NamespaceStats(),
+		"locks":          rm.LockStats(),
+		"pool":           rm.PoolStats(),
+	}
+	if hot := rm.stats.TopKeys(10); hot != nil {
+		snapshot["hot_keys"] = hot
+	}
+	return snapshot
+}
@@ -0,0 +1,155 @@
+package redisx
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeServer 按 RESP 协议应答的测试服务端，handle 返回原始响应，返回空字符串时使用默认响应
+// 默认响应：HELLO 按请求的协议版本应答，PING 返回 PONG，MODULE LIST 返回空列表，SCRIPT LOAD/EXISTS 按脚本已加载应答，其他命令返回 OK
+type fakeServer struct {
+	addr   string
+	handle func(args []string) string
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+// newFakeServer 启动测试服务端，测试结束时关闭
+func newFakeServer(t *testing.T, handle func(args []string) string) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{addr: ln.Addr().String(), handle: handle}
+	t.Cleanup(func() {
+		_ = ln.Close()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, c := range s.conns {
+			_ = c.Close()
+		}
+	})
+
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, c)
+			s.mu.Unlock()
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+// serve 逐条读取命令并应答
+func (s *fakeServer) serve(c net.Conn) {
+	r := bufio.NewReader(c)
+	proto := 2
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		args[0] = strings.ToLower(args[0])
+
+		reply := ""
+		if s.handle != nil {
+			reply = s.handle(args)
+		}
+		if reply == "" {
+			reply = defaultReply(args, &proto)
+		}
+		if _, err := io.WriteString(c, reply); err != nil {
+			return
+		}
+	}
+}
+
+// readCommand 读取一条 RESP 数组格式的命令
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid command header %q", line)
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+// defaultReply 默认响应
+func defaultReply(args []string, proto *int) string {
+	switch args[0] {
+	case "hello":
+		if len(args) > 1 {
+			*proto, _ = strconv.Atoi(args[1])
+		}
+		if *proto == 3 {
+			return "%1\r\n$5\r\nproto\r\n:3\r\n"
+		}
+		return "*2\r\n$5\r\nproto\r\n:2\r\n"
+	case "ping":
+		return "+PONG\r\n"
+	case "module":
+		return "*0\r\n"
+	case "script":
+		if len(args) > 1 && strings.EqualFold(args[1], "load") && len(args) > 2 {
+			return bulk(fmt.Sprintf("%x", sha1.Sum([]byte(args[2]))))
+		}
+		if len(args) > 1 && strings.EqualFold(args[1], "exists") {
+			return "*" + strconv.Itoa(len(args)-2) + "\r\n" + strings.Repeat(":1\r\n", len(args)-2)
+		}
+	}
+	return "+OK\r\n"
+}
+
+// bulk 编码 RESP 批量字符串
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
+
+// newTestManager 连接测试服务端的单例模式管理器，configure 可在创建前修改配置
+func newTestManager(t *testing.T, addr string, configure func(c *RedisConfig)) *RedisManager {
+	t.Helper()
+	config := &RedisConfig{Mode: ModeSingle, Single: &SingleConfig{Addr: addr}}
+	if configure != nil {
+		configure(config)
+	}
+	rm, err := NewRedisManager(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = rm.Close() })
+	return rm
+}
@@ -0,0 +1,314 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 降级模式下的写入策略
+const (
+	// FallbackWriteDrop 丢弃写入，返回 CONNECTION_FAILED
+	FallbackWriteDrop = "drop"
+	// FallbackWriteQueue 写入本地快照并排队，恢复后按顺序重放
+	FallbackWriteQueue = "queue"
+)
+
+// FallbackConfig 降级模式配置
+// Redis 不可达（健康检查失败、熔断或连接/超时错误）时，GetS/GetB 从最近读写过的键的本地快照返回值（CacheResult.Stale 为 true），
+// SetS/SetB/Del 按写入策略排队或丢弃，避免短暂故障导致整个服务不可用
+type FallbackConfig struct {
+	Size        int           `json:"size,omitempty" yaml:"size,omitempty"`                 // 快照最多保存的键数，默认 10000
+	MaxStale    time.Duration `json:"max_stale,omitempty" yaml:"max_stale,omitempty"`       // 快照条目自最近一次读写起的有效时间，默认 5m
+	WritePolicy string        `json:"write_policy,omitempty" yaml:"write_policy,omitempty"` // 写入策略：drop（默认）或 queue
+	QueueSize   int           `json:"queue_size,omitempty" yaml:"queue_size,omitempty"`     // queue 策略下最多排队的写入数，队列满时丢弃，默认 1000
+}
+
+// setDefaults 设置默认值
+func (c *FallbackConfig) setDefaults() {
+	if c.Size <= 0 {
+		c.Size = 10000
+	}
+	if c.MaxStale <= 0 {
+		c.MaxStale = 5 * time.Minute
+	}
+	if c.WritePolicy == "" {
+		c.WritePolicy = FallbackWriteDrop
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 1000
+	}
+}
+
+// fallbackWrite 降级期间排队的写入
+type fallbackWrite struct {
+	seq        uint64 // 入队序号
+	key        string
+	value      string
+	expiration time.Duration
+	del        bool
+}
+
+// fallbackStore 降级模式的本地快照和写入队列
+type fallbackStore struct {
+	config   FallbackConfig
+	snapshot *lruCache[string]
+
+	mu      sync.Mutex
+	pending []fallbackWrite
+	nextSeq uint64
+
+	pendingCount atomic.Int64 // len(pending)，供命令钩子无锁判断
+	replaying    atomic.Bool
+}
+
+// fallbackReplayKey context 中重放排队写入的标记，重放的写入不视为覆盖排队写入的直接写入
+type fallbackReplayKey struct{}
+
+// newFallbackStore 创建降级存储
+func newFallbackStore(config FallbackConfig) *fallbackStore {
+	return &fallbackStore{
+		config:   config,
+		snapshot: newLRUCache[string](config.Size, config.MaxStale),
+	}
+}
+
+// isOutage 错误是否表示 Redis 不可达
+func isOutage(err error) bool {
+	class := ClassifyError(err)
+	return class == ErrorClassConnection || class == ErrorClassTimeout
+}
+
+// rememberValue 记录成功读写的值
+func (rm *RedisManager) rememberValue(key, value string) {
+	if rm.fallback != nil {
+		rm.fallback.snapshot.set(key, value)
+	}
+}
+
+// forgetValues 移除已删除或不存在的键
+func (rm *RedisManager) forgetValues(keys ...string) {
+	if rm.fallback == nil {
+		return
+	}
+	for _, key := range keys {
+		rm.fallback.snapshot.remove(key)
+	}
+}
+
+// staleValue Redis 不可达时从快照读取值
+func (rm *RedisManager) staleValue(key string, err error) (string, bool) {
+	if rm.fallback == nil || !isOutage(err) {
+		return "", false
+	}
+	return rm.fallback.snapshot.get(key)
+}
+
+// queueWrites Redis 不可达时按写入策略处理写入，返回写入是否已排队（队列剩余空间不足时整体丢弃）
+// 开始关闭后不再排队：队列不会再被回放，排队的写入会静默丢失
+func (rm *RedisManager) queueWrites(writes []fallbackWrite, err error) bool {
+	if rm.fallback == nil || rm.fallback.config.WritePolicy != FallbackWriteQueue || len(writes) == 0 || !isOutage(err) {
+		return false
+	}
+	if rm.closing.Load() {
+		return false
+	}
+
+	fb := rm.fallback
+	fb.mu.Lock()
+	if len(fb.pending)+len(writes) > fb.config.QueueSize {
+		fb.mu.Unlock()
+		rm.Logger().Warn("Redis fallback write queue full, write dropped", "key", writes[0].key, "count", len(writes))
+		return false
+	}
+	for _, write := range writes {
+		fb.nextSeq++
+		write.seq = fb.nextSeq
+		fb.pending = append(fb.pending, write)
+	}
+	fb.pendingCount.Store(int64(len(fb.pending)))
+	fb.mu.Unlock()
+
+	for _, write := range writes {
+		if write.del {
+			fb.snapshot.remove(write.key)
+		} else {
+			fb.snapshot.set(write.key, write.value)
+		}
+	}
+	return true
+}
+
+// queueSet 排队 SET 写入
+func (rm *RedisManager) queueSet(key string, value interface{}, expiration time.Duration, err error) bool {
+	return rm.queueWrites([]fallbackWrite{{key: key, value: fallbackString(value), expiration: expiration}}, err)
+}
+
+// queueDels 排队 DEL 写入
+func (rm *RedisManager) queueDels(keys []string, err error) bool {
+	writes := make([]fallbackWrite, len(keys))
+	for i, key := range keys {
+		writes[i] = fallbackWrite{key: key, del: true}
+	}
+	return rm.queueWrites(writes, err)
+}
+
+// fallbackString 将 SetS/SetB 的值转换为快照中保存的字符串
+func fallbackString(value interface{}) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	s, _ := value.(string)
+	return s
+}
+
+// PendingWrites 获取降级期间排队等待重放的写入数
+func (rm *RedisManager) PendingWrites() int {
+	if rm.fallback == nil {
+		return 0
+	}
+	rm.fallback.mu.Lock()
+	defer rm.fallback.mu.Unlock()
+	return len(rm.fallback.pending)
+}
+
+// peek 返回最早排队的写入
+func (fb *fallbackStore) peek() (fallbackWrite, bool) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	if len(fb.pending) == 0 {
+		return fallbackWrite{}, false
+	}
+	return fb.pending[0], true
+}
+
+// pop 移除已重放的写入，重放期间已被 supersede 移除时不做处理
+func (fb *fallbackStore) pop(seq uint64) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	if len(fb.pending) > 0 && fb.pending[0].seq == seq {
+		fb.pending = fb.pending[1:]
+		fb.pendingCount.Store(int64(len(fb.pending)))
+	}
+}
+
+// supersede 移除 keys 的排队写入：之后对同一个键的直接写入已成功，重放旧写入会覆盖新值
+func (fb *fallbackStore) supersede(keys []string) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	kept := fb.pending[:0]
+	for _, write := range fb.pending {
+		superseded := false
+		for _, key := range keys {
+			if write.key == key {
+				superseded = true
+				break
+			}
+		}
+		if !superseded {
+			kept = append(kept, write)
+		}
+	}
+	clear(fb.pending[len(kept):])
+	fb.pending = kept
+	fb.pendingCount.Store(int64(len(fb.pending)))
+}
+
+// replayWrites 按顺序重放排队的写入，再次遇到连接或超时错误时保留剩余写入等待下次重放
+// 在恢复健康和之后第一条执行成功的命令时触发，同一时间只执行一次；重放不持有队列锁，期间可继续排队
+// 某条写入正在重放时对同一个键的直接写入仍可能被覆盖
+func (rm *RedisManager) replayWrites() {
+	fb := rm.fallback
+	if fb == nil || !rm.acquire() {
+		return
+	}
+	defer rm.release()
+	if !fb.replaying.CompareAndSwap(false, true) {
+		return
+	}
+	defer fb.replaying.Store(false)
+
+	ctx := context.WithValue(rm.ctx, fallbackReplayKey{}, true)
+	client := rm.activeClient()
+	replayed := 0
+	for {
+		write, ok := fb.peek()
+		if !ok {
+			break
+		}
+
+		var err error
+		if write.del {
+			err = client.Del(ctx, write.key).Err()
+		} else {
+			err = client.Set(ctx, write.key, write.value, rm.jitterTTL(write.key, write.expiration)).Err()
+		}
+		if err != nil && isOutage(err) {
+			break
+		}
+		if err != nil {
+			rm.Logger().Warn("Redis fallback write replay failed", "key", write.key, "error", err)
+		}
+		fb.pop(write.seq)
+		replayed++
+	}
+
+	if replayed > 0 {
+		rm.Logger().Info("Redis fallback writes replayed", "count", replayed, "remaining", fb.pendingCount.Load())
+	}
+}
+
+// fallbackHook 降级模式的 go-redis 钩子：写命令成功后移除同一个键的排队写入，有排队写入时在命令成功后触发重放
+type fallbackHook struct {
+	rm *RedisManager
+}
+
+func (h fallbackHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h fallbackHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		h.observe(ctx, []redis.Cmder{cmd})
+		return err
+	}
+}
+
+func (h fallbackHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		h.observe(ctx, cmds)
+		return err
+	}
+}
+
+// observe 处理执行完成的命令
+func (h fallbackHook) observe(ctx context.Context, cmds []redis.Cmder) {
+	fb := h.rm.fallback
+	if fb.pendingCount.Load() == 0 || ctx.Value(fallbackReplayKey{}) != nil {
+		return
+	}
+
+	succeeded := false
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			continue
+		}
+		succeeded = true
+		if !readOnlyCommands[cmd.Name()] {
+			if keys := commandKeys(cmd); len(keys) > 0 {
+				fb.supersede(keys)
+			}
+		}
+	}
+
+	if succeeded && fb.pendingCount.Load() > 0 && !fb.replaying.Load() {
+		go h.rm.replayWrites()
+	}
+}
@@ -0,0 +1,27 @@
+package redisx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Shutdown 之后的写入不会被排队重放，直接返回错误
+func TestFallbackQueueAfterShutdown(t *testing.T) {
+	rm := newTestManager(t, newFakeServer(t, nil).addr, func(c *RedisConfig) {
+		c.Fallback = &FallbackConfig{WritePolicy: FallbackWriteQueue}
+	})
+	if err := rm.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if res := rm.SetS("key", "value", time.Minute); res.IsOK() || res.Stale {
+		t.Errorf("SetS after Shutdown = %v, stale=%v, want error", res.ErrCode, res.Stale)
+	}
+	if res := rm.Del("key"); res.IsOK() || res.Stale {
+		t.Errorf("Del after Shutdown = %v, stale=%v, want error", res.ErrCode, res.Stale)
+	}
+	if n := rm.PendingWrites(); n != 0 {
+		t.Fatalf("PendingWrites = %d, want 0", n)
+	}
+}
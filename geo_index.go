@@ -0,0 +1,135 @@
+package redisx
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultGeoBulkBatchSize 批量加载位置时每个Pipeline包含的成员数量
+const defaultGeoBulkBatchSize = 500
+
+// GeoIndex 基于 Redis GEO 命令的地理位置索引，用于附近搜索和地理围栏判断
+// 所有距离参数均以米为单位
+type GeoIndex struct {
+	rm  *RedisManager
+	key string
+}
+
+// NewGeoIndex 创建地理位置索引
+func NewGeoIndex(rm *RedisManager, key string) *GeoIndex {
+	return &GeoIndex{
+		rm:  rm,
+		key: key,
+	}
+}
+
+// UpsertLocation 新增或更新成员的位置，新增成员时返回 true
+func (gi *GeoIndex) UpsertLocation(member string, longitude, latitude float64) CacheResult[bool] {
+	result := gi.rm.GeoAdd(gi.key, &redis.GeoLocation{
+		Name:      member,
+		Longitude: longitude,
+		Latitude:  latitude,
+	})
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+
+	return NewCacheResult(result.Val == 1)
+}
+
+// Remove 移除成员（GEO 底层为有序集合，直接使用 ZREM），没有成员时直接返回0
+func (gi *GeoIndex) Remove(members ...string) CacheResult[int64] {
+	if len(members) == 0 {
+		return NewCacheResult[int64](0)
+	}
+	args := make([]interface{}, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+	return gi.rm.ZRem(gi.key, args...)
+}
+
+// Location 获取成员的位置，成员不存在时返回 KEY_NOT_FOUND
+func (gi *GeoIndex) Location(member string) CacheResult[redis.GeoPos] {
+	result := gi.rm.GeoPos(gi.key, member)
+	if !result.IsOK() {
+		return NewCacheError[redis.GeoPos](result.ErrCode, result.Err)
+	}
+
+	if len(result.Val) == 0 || result.Val[0] == nil {
+		return NewCacheError[redis.GeoPos](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(*result.Val[0])
+}
+
+// NearbyWithin 搜索以指定坐标为圆心、radius 米范围内的成员，按距离由近到远排序
+// limit 为0时不限制数量；返回结果的 Dist 字段为到圆心的距离（米）
+func (gi *GeoIndex) NearbyWithin(longitude, latitude, radius float64, limit int) CacheResult[[]redis.GeoLocation] {
+	return gi.rm.GeoSearchLocation(gi.key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude:  longitude,
+			Latitude:   latitude,
+			Radius:     radius,
+			RadiusUnit: "m",
+			Sort:       "ASC",
+			Count:      limit,
+		},
+		WithCoord: true,
+		WithDist:  true,
+	})
+}
+
+// WithinBoundingBox 搜索以指定坐标为中心、宽 width 米高 height 米的矩形范围内的成员，按距离由近到远排序
+func (gi *GeoIndex) WithinBoundingBox(longitude, latitude, width, height float64, limit int) CacheResult[[]redis.GeoLocation] {
+	return gi.rm.GeoSearchLocation(gi.key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude: longitude,
+			Latitude:  latitude,
+			BoxWidth:  width,
+			BoxHeight: height,
+			BoxUnit:   "m",
+			Sort:      "ASC",
+			Count:     limit,
+		},
+		WithCoord: true,
+		WithDist:  true,
+	})
+}
+
+// Distance 计算两个成员之间的距离（米）
+func (gi *GeoIndex) Distance(member1, member2 string) CacheResult[float64] {
+	return gi.rm.GeoDist(gi.key, member1, member2, "m")
+}
+
+// BulkLoad 通过Pipeline批量加载位置，返回新增的成员数量
+// 位置按 batchSize 拆分为多条 GEOADD 在同一个Pipeline中提交，batchSize<=0 时使用默认值
+func (gi *GeoIndex) BulkLoad(locations []*redis.GeoLocation, batchSize int) CacheResult[int64] {
+	if len(locations) == 0 {
+		return NewCacheResult[int64](0)
+	}
+	if batchSize <= 0 {
+		batchSize = defaultGeoBulkBatchSize
+	}
+
+	pipe := gi.rm.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, (len(locations)+batchSize-1)/batchSize)
+	for start := 0; start < len(locations); start += batchSize {
+		end := start + batchSize
+		if end > len(locations) {
+			end = len(locations)
+		}
+		cmds = append(cmds, pipe.GeoAdd(gi.key, locations[start:end]...))
+	}
+
+	result := pipe.Exec()
+	if !result.IsOK() {
+		return NewCacheError[int64](result.ErrCode, result.Err)
+	}
+
+	var added int64
+	for _, cmd := range cmds {
+		added += cmd.Val()
+	}
+
+	return NewCacheResult(added)
+}
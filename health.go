@@ -0,0 +1,182 @@
+package redisx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 节点角色
+const (
+	NodeRoleMaster  = "master"
+	NodeRoleReplica = "replica"
+	NodeRoleShard   = "shard" // Ring 模式的分片
+)
+
+// NodeStatus 单个节点的健康状态
+type NodeStatus struct {
+	Addr          string        `json:"addr"`
+	Role          string        `json:"role"`
+	Healthy       bool          `json:"healthy"`
+	Latency       time.Duration `json:"latency_ns"`
+	Error         string        `json:"error,omitempty"`          // 最近一次检查的错误
+	LastError     string        `json:"last_error,omitempty"`     // 最后一次失败的错误
+	LastErrorTime time.Time     `json:"last_error_time,omitzero"` // 最后一次失败的时间
+	LastSuccess   time.Time     `json:"last_success,omitzero"`    // 最后一次成功的时间
+}
+
+// HealthStatus 最近一次健康检查的结果
+type HealthStatus struct {
+	Healthy   bool          `json:"healthy"`
+	Degraded  bool          `json:"degraded"` // 整体可用但有节点异常（如从节点或个别 Ring 分片不可用）
+	Mode      RedisMode     `json:"mode"`
+	LastCheck time.Time     `json:"last_check"`
+	Latency   time.Duration `json:"latency_ns"` // 最近一次检查的总耗时
+	Error     string        `json:"error,omitempty"`
+	Nodes     []NodeStatus  `json:"nodes,omitempty"`
+}
+
+// defaultHealthPingTimeout 未配置读超时时单个节点健康检查的超时
+const defaultHealthPingTimeout = 3 * time.Second
+
+// nodeStatusCollector 并发检查节点时收集各节点状态
+type nodeStatusCollector struct {
+	timeout time.Duration // 单个节点的检查超时
+	mu      sync.Mutex
+	nodes   []NodeStatus
+}
+
+// ping 检查单个节点并记录结果
+func (c *nodeStatusCollector) ping(ctx context.Context, client *redis.Client, role string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Ping(ctx).Err()
+	c.add(NodeStatus{Addr: client.Options().Addr, Role: role, Healthy: err == nil, Latency: time.Since(start)}, err)
+	return err
+}
+
+// add 记录一个节点的检查结果
+func (c *nodeStatusCollector) add(status NodeStatus, err error) {
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes = append(c.nodes, status)
+}
+
+// pingNodes 并发检查各节点，每个节点的超时为 Common.ReadTimeout（未配置时3秒），返回各节点状态和整体检查的错误
+// 集群和哨兵模式下所有主节点可用即视为健康，Ring 模式下至少一个分片可用即视为健康，其余节点异常只体现在节点状态中
+func (rm *RedisManager) pingNodes() ([]NodeStatus, error) {
+	c := nodeStatusCollector{timeout: rm.config.Common.ReadTimeout}
+	if c.timeout <= 0 {
+		c.timeout = defaultHealthPingTimeout
+	}
+	var err error
+
+	switch client := rm.activeClient().(type) {
+	case *redis.ClusterClient:
+		err = client.ForEachMaster(rm.ctx, func(ctx context.Context, node *redis.Client) error {
+			return c.ping(ctx, node, NodeRoleMaster)
+		})
+		_ = client.ForEachSlave(rm.ctx, func(ctx context.Context, node *redis.Client) error {
+			_ = c.ping(ctx, node, NodeRoleReplica)
+			return nil
+		})
+	case *redis.Ring:
+		_ = client.ForEachShard(rm.ctx, func(ctx context.Context, node *redis.Client) error {
+			_ = c.ping(ctx, node, NodeRoleShard)
+			return nil
+		})
+
+		// Ring 跳过已被标记为下线的分片，按配置的地址补充
+		visited := make(map[string]bool, len(c.nodes))
+		for _, node := range c.nodes {
+			visited[node.Addr] = true
+		}
+		for _, shard := range rm.config.MasterSlave.ringShards() {
+			if !visited[shard.Addr] {
+				c.add(NodeStatus{Addr: shard.Addr, Role: NodeRoleShard}, ErrConnectionFailed.WithMessage("shard is marked down"))
+			}
+		}
+
+		err = ErrConnectionFailed.WithMessage("all ring shards are down")
+		for _, node := range c.nodes {
+			if node.Healthy {
+				err = nil
+				break
+			}
+		}
+	case *redis.Client:
+		role := NodeRoleMaster
+		if rm.config.replicaOnly() {
+			role = NodeRoleReplica
+		}
+		err = c.ping(rm.ctx, client, role)
+	default:
+		ctx, cancel := context.WithTimeout(rm.ctx, c.timeout)
+		err = client.Ping(ctx).Err()
+		cancel()
+	}
+
+	sort.Slice(c.nodes, func(i, j int) bool {
+		if c.nodes[i].Role != c.nodes[j].Role {
+			return c.nodes[i].Role < c.nodes[j].Role
+		}
+		return c.nodes[i].Addr < c.nodes[j].Addr
+	})
+	return c.nodes, err
+}
+
+// mergeNodeHistory 以上次检查的结果补全各节点的最后成功和失败时间，调用方需持有 rm.mu
+func (rm *RedisManager) mergeNodeHistory(nodes []NodeStatus, now time.Time) {
+	previous := make(map[string]NodeStatus, len(rm.lastHealth.Nodes))
+	for _, node := range rm.lastHealth.Nodes {
+		previous[node.Addr] = node
+	}
+
+	for i := range nodes {
+		prev := previous[nodes[i].Addr]
+		nodes[i].LastSuccess, nodes[i].LastError, nodes[i].LastErrorTime = prev.LastSuccess, prev.LastError, prev.LastErrorTime
+		if nodes[i].Healthy {
+			nodes[i].LastSuccess = now
+		} else {
+			nodes[i].LastError, nodes[i].LastErrorTime = nodes[i].Error, now
+		}
+	}
+}
+
+// HealthStatus 获取最近一次健康检查的结果
+func (rm *RedisManager) HealthStatus() HealthStatus {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	status := rm.lastHealth
+	status.Healthy = rm.isHealthy
+	status.Mode = rm.config.Mode
+	status.Nodes = append([]NodeStatus(nil), rm.lastHealth.Nodes...)
+	return status
+}
+
+// HealthHandler 返回输出健康状态 JSON 的 http.Handler，健康时返回 200，否则返回 503，可直接用于 Kubernetes 探针
+func (rm *RedisManager) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := rm.HealthStatus()
+
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
@@ -0,0 +1,80 @@
+package redisx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// healthEventBuffer 健康状态订阅通道的缓冲大小，接收方未及时读取时丢弃新事件
+const healthEventBuffer = 16
+
+// HealthChangeHandler 健康状态变化回调，healthy 为 false 时 err 为健康检查的错误
+type HealthChangeHandler func(healthy bool, err error)
+
+// HealthEvent 健康状态变化事件
+type HealthEvent struct {
+	Healthy bool
+	Err     error
+	Time    time.Time
+}
+
+// healthNotifier 健康状态变化的回调和订阅通道
+type healthNotifier struct {
+	mu       sync.Mutex
+	handlers []HealthChangeHandler
+	subs     map[chan HealthEvent]struct{}
+}
+
+// OnHealthChange 注册健康状态变化回调（健康↔不健康切换时调用），在健康检查 goroutine 中按注册顺序同步执行
+func (rm *RedisManager) OnHealthChange(handler HealthChangeHandler) {
+	rm.health.mu.Lock()
+	defer rm.health.mu.Unlock()
+	rm.health.handlers = append(rm.health.handlers, handler)
+}
+
+// SubscribeHealth 订阅健康状态变化，ctx 结束或管理器关闭时通道关闭
+// 通道有缓冲，接收方读取过慢时丢弃新事件，可随时通过 IsHealthy 获取当前状态
+func (rm *RedisManager) SubscribeHealth(ctx context.Context) <-chan HealthEvent {
+	ch := make(chan HealthEvent, healthEventBuffer)
+
+	rm.health.mu.Lock()
+	if rm.health.subs == nil {
+		rm.health.subs = make(map[chan HealthEvent]struct{})
+	}
+	rm.health.subs[ch] = struct{}{}
+	rm.health.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-rm.done:
+		}
+
+		rm.health.mu.Lock()
+		defer rm.health.mu.Unlock()
+		delete(rm.health.subs, ch)
+		close(ch)
+	}()
+
+	return ch
+}
+
+// notifyHealthChange 通知健康状态变化，不能在持有 rm.mu 时调用
+func (rm *RedisManager) notifyHealthChange(healthy bool, err error) {
+	event := HealthEvent{Healthy: healthy, Err: err, Time: time.Now()}
+
+	rm.health.mu.Lock()
+	handlers := rm.health.handlers
+	for ch := range rm.health.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	rm.health.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(healthy, err)
+	}
+}
@@ -0,0 +1,285 @@
+package redisx
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/push"
+)
+
+// maxTrackedHotKeys 单个统计窗口内最多跟踪的键数量，超出后新出现的键不再计数
+const maxTrackedHotKeys = 10000
+
+// HotKeyConfig 热点键检测配置
+type HotKeyConfig struct {
+	SampleRate float64       `json:"sample_rate" yaml:"sample_rate"`                     // 访问采样比例（0~1]，默认 0.01
+	Window     time.Duration `json:"window,omitempty" yaml:"window,omitempty"`           // 统计窗口，默认 1min
+	Threshold  int64         `json:"threshold,omitempty" yaml:"threshold,omitempty"`     // 单个窗口内估算访问次数达到该值即视为热点键，默认 10000
+	LocalCache bool          `json:"local_cache,omitempty" yaml:"local_cache,omitempty"` // 是否在本地缓存热点键的读取结果
+	LocalTTL   time.Duration `json:"local_ttl,omitempty" yaml:"local_ttl,omitempty"`     // 热点键本地缓存时间，即其他实例写入后的最长不一致时长，默认 1s
+	LocalSize  int           `json:"local_size,omitempty" yaml:"local_size,omitempty"`   // 热点键本地缓存的最大条目数，默认 1000
+	Tracking   bool          `json:"tracking,omitempty" yaml:"tracking,omitempty"`       // 通过 RESP3 客户端缓存失效通知（CLIENT TRACKING）移除其他实例写入的热点键；需要 RESP3，服务端不支持时只按 LocalTTL 过期
+}
+
+// setDefaults 设置默认值
+func (c *HotKeyConfig) setDefaults() {
+	if c.SampleRate <= 0 || c.SampleRate > 1 {
+		c.SampleRate = 0.01
+	}
+	if c.Window <= 0 {
+		c.Window = time.Minute
+	}
+	if c.Threshold <= 0 {
+		c.Threshold = 10000
+	}
+	if c.LocalTTL <= 0 {
+		c.LocalTTL = time.Second
+	}
+	if c.LocalSize <= 0 {
+		c.LocalSize = 1000
+	}
+}
+
+// HotKey 热点键报告
+type HotKey struct {
+	Key    string
+	Count  int64   // 最近一个完整窗口（或当前窗口，取较大者）内的估算访问次数
+	Rate   float64 // 估算的每秒访问次数
+	Hot    bool    // 是否达到热点阈值
+	Shards int     // 建议的拆分份数（按阈值计算，未达到阈值时为1），可配合 ShardKeys 将读取分散到多个键
+}
+
+// hotKeyTracker 基于采样的键访问频率统计，保留当前窗口和上一个完整窗口的计数
+type hotKeyTracker struct {
+	mu       sync.Mutex
+	config   HotKeyConfig
+	started  time.Time
+	current  map[string]int64
+	previous map[string]int64
+}
+
+// newHotKeyTracker 创建热点键统计
+func newHotKeyTracker(config HotKeyConfig) *hotKeyTracker {
+	return &hotKeyTracker{
+		config:   config,
+		started:  time.Now(),
+		current:  make(map[string]int64),
+		previous: make(map[string]int64),
+	}
+}
+
+// rotate 当前窗口结束时切换窗口，调用方需持有 t.mu
+func (t *hotKeyTracker) rotate(now time.Time) {
+	elapsed := now.Sub(t.started)
+	if elapsed < t.config.Window {
+		return
+	}
+	if elapsed < 2*t.config.Window {
+		t.previous = t.current
+	} else {
+		// 空闲超过一个完整窗口，上一个窗口没有访问
+		t.previous = make(map[string]int64)
+	}
+	t.current = make(map[string]int64)
+	t.started = now
+}
+
+// estimate 估算键在一个窗口内的访问次数，调用方需持有 t.mu
+func (t *hotKeyTracker) estimate(key string) int64 {
+	sampled := max(t.current[key], t.previous[key])
+	return int64(float64(sampled) / t.config.SampleRate)
+}
+
+// record 按采样比例记录一次访问，返回键当前是否为热点键
+func (t *hotKeyTracker) record(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rotate(time.Now())
+	if rand.Float64() < t.config.SampleRate {
+		if _, ok := t.current[key]; ok || len(t.current) < maxTrackedHotKeys {
+			t.current[key]++
+		}
+	}
+	return t.estimate(key) >= t.config.Threshold
+}
+
+// top 返回估算访问次数最高的 n 个键
+func (t *hotKeyTracker) top(n int) []HotKey {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rotate(time.Now())
+
+	seen := make(map[string]struct{}, len(t.current)+len(t.previous))
+	keys := make([]HotKey, 0, len(t.current)+len(t.previous))
+	for _, counts := range []map[string]int64{t.current, t.previous} {
+		for key := range counts {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			count := t.estimate(key)
+			keys = append(keys, HotKey{
+				Key:    key,
+				Count:  count,
+				Rate:   float64(count) / t.config.Window.Seconds(),
+				Hot:    count >= t.config.Threshold,
+				Shards: max(1, int(math.Ceil(float64(count)/float64(t.config.Threshold)))),
+			})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Count > keys[j].Count
+	})
+	if n > 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// recordAccess 记录键访问，未启用热点键检测时返回 false
+func (s *RedisStats) recordAccess(key string) bool {
+	if s.hotKeys == nil {
+		return false
+	}
+	return s.hotKeys.record(key)
+}
+
+// TopKeys 获取估算访问次数最高的 n 个键（n<=0 时返回全部），未启用热点键检测时返回 nil
+func (s *RedisStats) TopKeys(n int) []HotKey {
+	if s.hotKeys == nil {
+		return nil
+	}
+	return s.hotKeys.top(n)
+}
+
+// evictHotKeys 本实例写入或删除键时移除热点键的本地缓存
+func (rm *RedisManager) evictHotKeys(keys ...string) {
+	if rm.hotCache == nil {
+		return
+	}
+	for _, key := range keys {
+		rm.hotCache.remove(key)
+	}
+}
+
+// evictHotKeysFor 命令执行后移除其写入的键的本地缓存，FLUSHDB/FLUSHALL 清空本地缓存
+func (rm *RedisManager) evictHotKeysFor(cmd redis.Cmder) {
+	switch name := cmd.Name(); {
+	case name == "flushdb" || name == "flushall":
+		rm.hotCache.clear()
+	case !readOnlyCommands[name]:
+		rm.evictHotKeys(commandKeys(cmd)...)
+	}
+}
+
+// hotKeyEvictHook 热点键本地缓存的 go-redis 钩子，写命令（包括脚本、事务和流水线中的写命令）执行后移除涉及的键
+// 在执行后移除，避免执行期间并发的读取把旧值重新写入本地缓存
+type hotKeyEvictHook struct {
+	rm *RedisManager
+}
+
+func (h hotKeyEvictHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h hotKeyEvictHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		h.rm.evictHotKeysFor(cmd)
+		return err
+	}
+}
+
+func (h hotKeyEvictHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			h.rm.evictHotKeysFor(cmd)
+		}
+		return err
+	}
+}
+
+// trackingEnabled 是否在连接上开启 CLIENT TRACKING 并处理 invalidate 推送
+func (rm *RedisManager) trackingEnabled() bool {
+	return rm.hotCache != nil && rm.config.HotKeys.Tracking && rm.config.Common.Protocol == 3
+}
+
+// withTracking 在连接建立时（onConnect 之后）开启 CLIENT TRACKING，未启用时原样返回 onConnect
+// 服务端读取过的键被修改时在该连接上推送 invalidate，go-redis 在连接下一次执行命令前处理；
+// NOLOOP 不推送本连接自己的写入（本实例的写入已由 hotKeyEvictHook 移除）
+func (rm *RedisManager) withTracking(onConnect func(ctx context.Context, cn *redis.Conn) error) func(ctx context.Context, cn *redis.Conn) error {
+	if !rm.trackingEnabled() {
+		return onConnect
+	}
+	return func(ctx context.Context, cn *redis.Conn) error {
+		if onConnect != nil {
+			if err := onConnect(ctx, cn); err != nil {
+				return err
+			}
+		}
+		// 协商为 RESP2 时服务端要求 REDIRECT 参数而返回错误，此时不使用失效通知，热点键按 LocalTTL 过期
+		cmd := redis.NewStatusCmd(ctx, "client", "tracking", "on", "noloop")
+		if err := cn.Process(ctx, cmd); err != nil {
+			rm.Logger().Debug("Redis client tracking not enabled", "error", err)
+		}
+		return nil
+	}
+}
+
+// registerTracking 为客户端（集群和 Ring 模式下为每个节点）注册 invalidate 推送的处理
+func (rm *RedisManager) registerTracking(client interface{}) {
+	rm.eachNode(client, func(node *redis.Client) {
+		if err := node.RegisterPushNotificationHandler("invalidate", trackingHandler{rm: rm}, false); err != nil {
+			rm.Logger().Debug("Redis tracking handler not registered", "error", err)
+		}
+	})
+}
+
+// trackingHandler 处理 invalidate 推送，移除热点键本地缓存
+type trackingHandler struct {
+	rm *RedisManager
+}
+
+// HandlePushNotification 推送格式为 ["invalidate", [key...]]，键列表为 nil 时（FLUSHALL/FLUSHDB 或服务端跟踪表溢出）清空本地缓存
+func (h trackingHandler) HandlePushNotification(ctx context.Context, handlerCtx push.NotificationHandlerContext, notification []interface{}) error {
+	if len(notification) < 2 {
+		return nil
+	}
+	keys, ok := notification[1].([]interface{})
+	if !ok {
+		h.rm.hotCache.clear()
+		return nil
+	}
+	for _, key := range keys {
+		if key, ok := key.(string); ok {
+			h.rm.hotCache.remove(key)
+		}
+	}
+	return nil
+}
+
+// ShardKeys 将键拆分为 shards 个副本键（<key>:shard:<i>），热点键的值写入所有副本，读取时随机选择一个副本以分散到不同的分片
+func ShardKeys(key string, shards int) []string {
+	shards = max(1, shards)
+	keys := make([]string, shards)
+	for i := range keys {
+		keys[i] = key + ":shard:" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// RandomShardKey 随机选择一个副本键用于读取
+func RandomShardKey(key string, shards int) string {
+	return key + ":shard:" + strconv.Itoa(rand.Intn(max(1, shards)))
+}
@@ -0,0 +1,128 @@
+package redisx
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	errInflightLimit   = &RedisError{Code: BREAK, Message: "too many in-flight operations"}
+	errInflightTimeout = ErrOperationTimeout.WithMessage("timed out waiting for in-flight slot")
+)
+
+// inflightSlotKey context 中已持有执行空位的标记：建立连接时的握手命令（HELLO 等）以同一个 context 再次经过钩子，不能重复获取
+type inflightSlotKey struct{}
+
+// blockingCommands 在服务端阻塞等待的命令，占用的时间取决于等待时长而不是 Redis 的负载，不计入并发限制
+var blockingCommands = map[string]bool{
+	"blpop": true, "brpop": true, "blmove": true, "brpoplpush": true, "blmpop": true,
+	"bzpopmin": true, "bzpopmax": true, "bzmpop": true,
+	"wait": true, "waitaof": true,
+}
+
+// isBlocking 是否为阻塞命令，XREAD/XREADGROUP 带 BLOCK 参数时阻塞
+func isBlocking(cmd redis.Cmder) bool {
+	name := cmd.Name()
+	if blockingCommands[name] {
+		return true
+	}
+	if name != "xread" && name != "xreadgroup" {
+		return false
+	}
+	for _, arg := range cmd.Args()[1:] {
+		s, ok := arg.(string)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(s) {
+		case "block":
+			return true
+		case "streams":
+			return false
+		}
+	}
+	return false
+}
+
+// inflightLimiter 同时执行的命令数上限，流水线计为一个命令
+type inflightLimiter struct {
+	slots chan struct{}
+	wait  time.Duration
+}
+
+// newInflightLimiter 创建并发限制
+func newInflightLimiter(limit int, wait time.Duration) *inflightLimiter {
+	return &inflightLimiter{slots: make(chan struct{}, limit), wait: wait}
+}
+
+// acquire 获取执行空位，没有空位时最多等待 wait：不等待时返回 BREAK，等待超时返回 TIMEOUT，ctx 结束时返回 ctx 的错误
+func (l *inflightLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.wait <= 0 {
+		return errInflightLimit
+	}
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return errInflightTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release 释放执行空位
+func (l *inflightLimiter) release() {
+	<-l.slots
+}
+
+// inflightLimitHook 限制同时执行的命令数的 go-redis 钩子，Redis 停顿时让调用方快速失败，避免 goroutine 无限堆积
+// 阻塞命令（BLPOP、XREADGROUP BLOCK 等）及包含阻塞命令的流水线不占用空位，避免少数消费者长时间占满限制
+type inflightLimitHook struct {
+	limiter *inflightLimiter
+}
+
+func (h inflightLimitHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h inflightLimitHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if ctx.Value(inflightSlotKey{}) != nil || isBlocking(cmd) {
+			return next(ctx, cmd)
+		}
+		if err := h.limiter.acquire(ctx); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		defer h.limiter.release()
+		return next(context.WithValue(ctx, inflightSlotKey{}, true), cmd)
+	}
+}
+
+func (h inflightLimitHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if ctx.Value(inflightSlotKey{}) != nil || slices.ContainsFunc(cmds, isBlocking) {
+			return next(ctx, cmds)
+		}
+		if err := h.limiter.acquire(ctx); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		defer h.limiter.release()
+		return next(context.WithValue(ctx, inflightSlotKey{}, true), cmds)
+	}
+}
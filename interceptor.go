@@ -0,0 +1,127 @@
+package redisx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Op 一次命令调用（或一次流水线）
+type Op struct {
+	Name string        // 命令名（小写），流水线为 "pipeline"
+	Keys []string      // 涉及的键，流水线为各命令的键
+	Cmds []redis.Cmder // 原始命令，执行后可读取结果
+}
+
+// OpFunc 执行命令的函数，返回命令的错误（键不存在时为 redis.Nil）
+type OpFunc func(ctx context.Context, op *Op) error
+
+// Interceptor 命令拦截器，可在调用 next 前后执行自定义逻辑，也可以不调用 next 直接返回错误以拒绝命令
+type Interceptor func(next OpFunc) OpFunc
+
+// Use 添加命令拦截器，作用于之后执行的所有命令，先添加的拦截器在外层
+func (rm *RedisManager) Use(interceptors ...Interceptor) {
+	rm.interceptorsMutex.Lock()
+	defer rm.interceptorsMutex.Unlock()
+	rm.interceptors = append(rm.interceptors, interceptors...)
+}
+
+// intercept 以拦截器链包装 final 并执行；拦截器未调用 final 直接返回错误时，错误写回尚未设置错误的命令
+// 命令已执行时保留各命令自身的结果，流水线中一条命令的错误（如 redis.Nil）不影响其他命令
+func (rm *RedisManager) intercept(ctx context.Context, op *Op, final OpFunc) error {
+	rm.interceptorsMutex.RLock()
+	chain := rm.interceptors
+	rm.interceptorsMutex.RUnlock()
+
+	if len(chain) == 0 {
+		return final(ctx, op)
+	}
+
+	called := false
+	fn := func(ctx context.Context, op *Op) error {
+		called = true
+		return final(ctx, op)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		fn = chain[i](fn)
+	}
+
+	err := fn(ctx, op)
+	if err != nil && !called {
+		for _, cmd := range op.Cmds {
+			if cmd.Err() == nil {
+				cmd.SetErr(err)
+			}
+		}
+	}
+	return err
+}
+
+// commandKeys 命令涉及的键
+func commandKeys(cmd redis.Cmder) []string {
+	args := cmd.Args()
+	switch cmd.Name() {
+	case "del", "unlink", "exists", "touch", "mget", "watch", "sinter", "sunion", "sdiff", "pfcount":
+		keys := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			keys = append(keys, fmt.Sprint(arg))
+		}
+		return keys
+	case "mset", "msetnx":
+		keys := make([]string, 0, len(args)/2)
+		for i := 1; i < len(args); i += 2 {
+			keys = append(keys, fmt.Sprint(args[i]))
+		}
+		return keys
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		if len(args) < 3 {
+			return nil
+		}
+		numKeys, err := strconv.Atoi(fmt.Sprint(args[2]))
+		if err != nil || numKeys < 0 {
+			return nil
+		}
+		keys := make([]string, 0, numKeys)
+		for i := 3; i < len(args) && i < 3+numKeys; i++ {
+			keys = append(keys, fmt.Sprint(args[i]))
+		}
+		return keys
+	}
+
+	if key := commandKey(cmd); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+// interceptorHook 执行命令拦截器链的 go-redis 钩子
+type interceptorHook struct {
+	rm *RedisManager
+}
+
+func (h interceptorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h interceptorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		op := &Op{Name: cmd.Name(), Keys: commandKeys(cmd), Cmds: []redis.Cmder{cmd}}
+		return h.rm.intercept(ctx, op, func(ctx context.Context, op *Op) error {
+			return next(ctx, cmd)
+		})
+	}
+}
+
+func (h interceptorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		op := &Op{Name: "pipeline", Cmds: cmds}
+		for _, cmd := range cmds {
+			op.Keys = append(op.Keys, commandKeys(cmd)...)
+		}
+		return h.rm.intercept(ctx, op, func(ctx context.Context, op *Op) error {
+			return next(ctx, cmds)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// syncPollInterval 等待通知期间的兜底轮询间隔，防止错过 Pub/Sub 通知后一直等待
+const syncPollInterval = time.Second
+
+// waitUntil 订阅通知频道并等待 check 返回 true，收到通知或到达轮询间隔时重新检查
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (rm *RedisManager) waitUntil(ctx context.Context, channel string, check func() CacheResult[bool]) CacheResult[bool] {
+	notify := make(chan struct{}, 1)
+	sub := rm.Subscribe(ctx, func(ctx context.Context, msg *redis.Message) error {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+		return nil
+	}, channel)
+	if sub.IsOK() {
+		defer sub.Val.Close()
+	}
+
+	// 先订阅再检查，避免检查之后、订阅之前发布的通知丢失
+	for {
+		res := check()
+		if !res.IsOK() || res.Val {
+			return res
+		}
+
+		timer := time.NewTimer(syncPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NewCacheError[bool](TIMEOUT, ErrOperationTimeout.WithError(ctx.Err()))
+			}
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(ctx.Err()))
+		case <-notify:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// CountDownLatch 分布式倒计时门闩，计数减到0时所有等待者继续执行
+type CountDownLatch struct {
+	rm  *RedisManager
+	key string
+}
+
+// NewCountDownLatch 创建倒计时门闩对象，使用前需由一方调用 TrySetCount 初始化
+func NewCountDownLatch(rm *RedisManager, key string) *CountDownLatch {
+	return &CountDownLatch{
+		rm:  rm,
+		key: key,
+	}
+}
+
+// channel 计数归零的通知频道
+func (l *CountDownLatch) channel() string {
+	return "latch:" + l.key
+}
+
+// TrySetCount 初始化计数，门闩已存在时返回 false；ttl 为门闩的最长存活时间
+func (l *CountDownLatch) TrySetCount(count int64, ttl time.Duration) CacheResult[bool] {
+	if count <= 0 {
+		return NewCacheError[bool](INVALID_OPERATION, ErrInvalidOperation.WithMessage("latch count must be positive"))
+	}
+	return l.rm.SetNX(l.key, strconv.FormatInt(count, 10), ttl)
+}
+
+// CountDown 计数减一，返回剩余计数；门闩不存在时返回 KEY_NOT_FOUND
+func (l *CountDownLatch) CountDown() CacheResult[int64] {
+	result := l.rm.EvalScript(ScriptKeyLatchCountDown, []string{l.key}, l.channel())
+	if !result.IsOK() {
+		return NewCacheError[int64](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[int64](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+	if val == -1 {
+		return NewCacheError[int64](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(val)
+}
+
+// Count 获取当前计数，门闩不存在时返回 KEY_NOT_FOUND
+func (l *CountDownLatch) Count() CacheResult[int64] {
+	res := l.rm.GetS(l.key)
+	if !res.IsOK() {
+		return NewCacheError[int64](res.ErrCode, res.Err)
+	}
+
+	val, err := strconv.ParseInt(res.Val, 10, 64)
+	if err != nil {
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// Wait 等待计数归零，门闩不存在时返回 KEY_NOT_FOUND
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (l *CountDownLatch) Wait(ctx context.Context) CacheResult[bool] {
+	return l.rm.waitUntil(ctx, l.channel(), func() CacheResult[bool] {
+		res := l.Count()
+		if !res.IsOK() {
+			return NewCacheError[bool](res.ErrCode, res.Err)
+		}
+		return NewCacheResult(res.Val <= 0)
+	})
+}
+
+// Barrier 分布式一次性屏障，parties 个参与方全部到达后所有参与方继续执行
+type Barrier struct {
+	rm      *RedisManager
+	key     string
+	parties int64
+	ttl     time.Duration
+}
+
+// NewBarrier 创建屏障，ttl 为屏障的最长存活时间（从第一个参与方到达开始计算）
+func NewBarrier(rm *RedisManager, key string, parties int64, ttl time.Duration) (*Barrier, error) {
+	if parties <= 0 {
+		return nil, ErrInvalidConfig.WithMessage("barrier parties must be positive")
+	}
+	if ttl <= 0 {
+		return nil, ErrInvalidConfig.WithMessage("barrier ttl must be positive")
+	}
+
+	return &Barrier{
+		rm:      rm,
+		key:     key,
+		parties: parties,
+		ttl:     ttl,
+	}, nil
+}
+
+// channel 全部到达的通知频道
+func (b *Barrier) channel() string {
+	return "barrier:" + b.key
+}
+
+// Await 到达屏障并等待其余参与方，全部到达后返回 true
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED；超时的参与方已计入到达数
+func (b *Barrier) Await(ctx context.Context) CacheResult[bool] {
+	result := b.rm.EvalScript(ScriptKeyBarrierArrive, []string{b.key}, b.parties, b.ttl.Milliseconds(), b.channel())
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+	arrived, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+	if arrived >= b.parties {
+		return NewCacheResult(true)
+	}
+
+	return b.rm.waitUntil(ctx, b.channel(), func() CacheResult[bool] {
+		res := b.Arrived()
+		if !res.IsOK() {
+			return NewCacheError[bool](res.ErrCode, res.Err)
+		}
+		return NewCacheResult(res.Val >= b.parties)
+	})
+}
+
+// Arrived 获取已到达的参与方数量，屏障不存在（未有参与方到达或已过期）时返回 KEY_NOT_FOUND
+func (b *Barrier) Arrived() CacheResult[int64] {
+	res := b.rm.GetS(b.key)
+	if !res.IsOK() {
+		return NewCacheError[int64](res.ErrCode, res.Err)
+	}
+
+	val, err := strconv.ParseInt(res.Val, 10, 64)
+	if err != nil {
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
@@ -0,0 +1,383 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LockOptions 分布式锁选项
+type LockOptions struct {
+	TTL           time.Duration // 锁的过期时间，默认 30s
+	RetryInterval time.Duration // Lock 阻塞获取时的初始重试间隔，默认 100ms
+
+	// MaxRetryInterval 大于 RetryInterval 时重试间隔按指数退避增长到该值，否则固定为 RetryInterval
+	MaxRetryInterval time.Duration
+
+	// NotifyUnlock 释放锁时通过 Pub/Sub 通知等待者，Lock 阻塞等待期间收到通知立即重试
+	// 需要持有方和等待方都开启；通知只用于提前唤醒，丢失时仍按退避间隔重试
+	NotifyUnlock bool
+
+	// AutoRenew 获取锁后启动看门狗协程，每 TTL/3 续期一次，直到 Unlock、锁丢失、获取锁时的 ctx 结束或管理器关闭
+	// Lock(ctx)/TryLockCtx(ctx) 的 ctx 同时限制获取锁的等待时间和续期时长，ctx 结束后锁在 TTL 到期时自动释放
+	AutoRenew bool
+
+	// Reentrant 使用可重入锁，持有同一令牌时可重复获取，Unlock 次数与获取次数相同时才真正释放
+	// 可重入锁以哈希存储，与非可重入锁不能使用相同的键
+	Reentrant bool
+
+	// Fair 使用公平锁，Lock 阻塞等待时进入等待队列，锁按排队先后授予，避免高竞争下的饥饿
+	// TryLock 不排队，只有队列为空时才能获取；Reentrant 为 true 时忽略该选项
+	Fair bool
+
+	// FairWaiterTimeout 公平锁等待者的心跳超时，超时未重试的等待者被移出队列
+	// 默认取 5s 与 3 倍最大重试间隔中的较大值
+	FairWaiterTimeout time.Duration
+
+	// Token 持有者令牌，为空时自动生成；多个 Lock 对象共享令牌即视为同一持有者
+	Token string
+}
+
+// Lock 分布式锁，基于 LockScript/UnlockScript/RenewLockScript
+// 每个 Lock 对象持有唯一令牌（默认自动生成），只有持有令牌的对象才能释放或续期锁
+type Lock struct {
+	rm    *RedisManager
+	key   string
+	token string
+	opts  LockOptions
+
+	mutex       sync.Mutex
+	held        bool
+	acquiredAt  time.Time
+	watchCancel context.CancelFunc
+	watchDone   chan struct{}
+}
+
+// NewLock 创建分布式锁对象，opts 为 nil 时使用默认选项
+func (rm *RedisManager) NewLock(key string, opts *LockOptions) *Lock {
+	var o LockOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.TTL <= 0 {
+		o.TTL = 30 * time.Second
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 100 * time.Millisecond
+	}
+	if o.FairWaiterTimeout <= 0 {
+		o.FairWaiterTimeout = 5 * time.Second
+		if maxInterval := 3 * max(o.RetryInterval, o.MaxRetryInterval); maxInterval > o.FairWaiterTimeout {
+			o.FairWaiterTimeout = maxInterval
+		}
+	}
+	if o.Token == "" {
+		o.Token = newTaskID()
+	}
+
+	return &Lock{
+		rm:    rm,
+		key:   key,
+		token: o.Token,
+		opts:  o,
+	}
+}
+
+// Key 锁的键名
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token 锁的唯一令牌
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// TryLock 尝试获取锁，不阻塞；锁已被占用时返回 false
+func (l *Lock) TryLock() CacheResult[bool] {
+	return l.TryLockCtx(context.Background())
+}
+
+// TryLockCtx 尝试获取锁（支持context），启用 AutoRenew 时 ctx 结束后停止续期
+func (l *Lock) TryLockCtx(ctx context.Context) CacheResult[bool] {
+	start := time.Now()
+	res := l.tryLock(ctx, false)
+	if res.IsOK() {
+		if res.Val {
+			l.record(LockEvent{Type: LockEventAcquired, Wait: time.Since(start)})
+		} else {
+			l.record(LockEvent{Type: LockEventContended})
+		}
+	}
+	return res
+}
+
+// tryLock 尝试获取锁，成功且启用 AutoRenew 时启动看门狗，看门狗随 ctx 结束退出
+// wait 为 true 表示调用方将继续等待，公平锁模式下进入等待队列
+func (l *Lock) tryLock(ctx context.Context, wait bool) CacheResult[bool] {
+	var res CacheResult[bool]
+	if l.opts.Reentrant {
+		count := l.rm.TryReentrantLock(l.key, l.token, l.opts.TTL)
+		if !count.IsOK() {
+			return NewCacheError[bool](count.ErrCode, count.Err)
+		}
+		res = NewCacheResult(count.Val > 0)
+	} else if l.opts.Fair {
+		res = l.rm.TryFairLock(l.key, l.token, l.opts.TTL, l.opts.FairWaiterTimeout, wait)
+	} else {
+		res = l.rm.TryLock(l.key, l.token, l.opts.TTL)
+	}
+
+	if res.IsOK() && res.Val {
+		l.mutex.Lock()
+		if !l.held {
+			l.acquiredAt = time.Now()
+		}
+		l.held = true
+		if l.opts.AutoRenew && l.watchCancel == nil {
+			l.startWatchdog(ctx)
+		}
+		l.mutex.Unlock()
+	}
+	return res
+}
+
+// startWatchdog 启动续期协程，调用方需持有 l.mutex；看门狗随 ctx 结束或管理器关闭退出
+// 看门狗因锁丢失退出时清除 watchCancel/watchDone，之后再次获取锁时重新启动
+func (l *Lock) startWatchdog(ctx context.Context) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(l.rm.ctx, cancel)
+	done := make(chan struct{})
+	l.watchCancel = cancel
+	l.watchDone = done
+	l.rm.addWorker(l, l.stopWatchdog)
+
+	go func() {
+		defer close(done)
+		defer func() {
+			stop()
+			cancel()
+			l.mutex.Lock()
+			if l.watchDone == done {
+				l.watchCancel, l.watchDone = nil, nil
+				l.rm.removeWorker(l)
+			}
+			l.mutex.Unlock()
+		}()
+
+		interval := l.opts.TTL / 3
+		if interval < time.Millisecond {
+			interval = time.Millisecond
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-watchCtx.Done():
+				return
+			case <-ticker.C:
+				res := l.Refresh()
+				if res.IsOK() && res.Val {
+					continue
+				}
+				// 锁已丢失（过期或被他人持有），停止续期；连接错误则等待下次重试
+				if res.IsOK() {
+					l.rm.Logger().Warn("Redis lock lost, stop renewing", "key", l.key)
+					if wasHeld, held := l.markReleased(); wasHeld {
+						l.record(LockEvent{Type: LockEventExpired, Held: held})
+					}
+					return
+				}
+				l.rm.Logger().Warn("Redis lock renew failed", "key", l.key, "error", res.Err)
+				l.record(LockEvent{Type: LockEventRenewFailed, Err: res.Err})
+			}
+		}
+	}()
+}
+
+// stopWatchdog 停止续期协程并等待其退出
+func (l *Lock) stopWatchdog() {
+	l.mutex.Lock()
+	cancel, done := l.watchCancel, l.watchDone
+	l.watchCancel, l.watchDone = nil, nil
+	l.mutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+		l.mutex.Lock()
+		if l.watchDone == nil {
+			l.rm.removeWorker(l)
+		}
+		l.mutex.Unlock()
+	}
+}
+
+// Lock 阻塞获取锁，直到成功或 ctx 结束；启用 AutoRenew 时 ctx 结束后停止续期
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (l *Lock) Lock(ctx context.Context) CacheResult[bool] {
+	start := time.Now()
+	res := l.tryLock(ctx, true)
+	if !res.IsOK() {
+		return res
+	}
+	if res.Val {
+		l.record(LockEvent{Type: LockEventAcquired, Wait: time.Since(start)})
+		return res
+	}
+
+	l.record(LockEvent{Type: LockEventContended})
+	res = l.wait(ctx)
+	if res.IsOK() && res.Val {
+		l.record(LockEvent{Type: LockEventAcquired, Wait: time.Since(start)})
+	}
+
+	// 公平锁放弃等待时离开队列，避免后续等待者被阻塞到心跳超时
+	if l.opts.Fair && !l.opts.Reentrant && !(res.IsOK() && res.Val) {
+		l.rm.LeaveFairLockQueue(l.key, l.token)
+	}
+	return res
+}
+
+// wait 按退避间隔（或收到释放通知时）重试获取锁，直到成功或 ctx 结束
+func (l *Lock) wait(ctx context.Context) CacheResult[bool] {
+	// 订阅释放通知，订阅失败不影响按退避间隔重试
+	var notify chan struct{}
+	if l.opts.NotifyUnlock {
+		notify = make(chan struct{}, 1)
+		sub := l.rm.Subscribe(ctx, func(ctx context.Context, msg *redis.Message) error {
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+			return nil
+		}, l.notifyChannel())
+		if sub.IsOK() {
+			defer sub.Val.Close()
+		}
+	}
+
+	interval := l.opts.RetryInterval
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NewCacheError[bool](TIMEOUT, ErrOperationTimeout.WithError(ctx.Err()))
+			}
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(ctx.Err()))
+		case <-notify:
+			timer.Stop()
+		case <-timer.C:
+		}
+
+		res := l.tryLock(ctx, true)
+		if !res.IsOK() || res.Val {
+			return res
+		}
+
+		if l.opts.MaxRetryInterval > interval {
+			interval *= 2
+			if interval > l.opts.MaxRetryInterval {
+				interval = l.opts.MaxRetryInterval
+			}
+		}
+	}
+}
+
+// notifyChannel 锁释放通知的频道名
+func (l *Lock) notifyChannel() string {
+	return "lock:notify:" + l.key
+}
+
+// Unlock 释放锁；锁已过期或被他人持有时返回 false
+// 可重入锁每次调用减少一次重入次数，减为0时才真正释放
+func (l *Lock) Unlock() CacheResult[bool] {
+	if l.opts.Reentrant {
+		remaining := l.rm.ReleaseReentrantLock(l.key, l.token)
+		if !remaining.IsOK() {
+			return NewCacheError[bool](remaining.ErrCode, remaining.Err)
+		}
+		if remaining.Val > 0 {
+			return NewCacheResult(true)
+		}
+		l.released(remaining.Val == 0)
+		return NewCacheResult(remaining.Val == 0)
+	}
+
+	l.stopWatchdog()
+
+	res := l.rm.ReleaseLock(l.key, l.token)
+	if res.IsOK() {
+		wasHeld, held := l.markReleased()
+		if res.Val {
+			l.record(LockEvent{Type: LockEventReleased, Held: held})
+		} else if wasHeld {
+			l.record(LockEvent{Type: LockEventExpired, Held: held})
+		}
+	}
+	if res.IsOK() && res.Val && l.opts.NotifyUnlock {
+		l.rm.Publish(l.notifyChannel(), l.token)
+	}
+	return res
+}
+
+// released 可重入锁完全释放后的清理，ok 为 false 表示释放时锁已不由本对象持有
+func (l *Lock) released(ok bool) {
+	l.stopWatchdog()
+
+	wasHeld, held := l.markReleased()
+	if ok {
+		l.record(LockEvent{Type: LockEventReleased, Held: held})
+	} else if wasHeld {
+		l.record(LockEvent{Type: LockEventExpired, Held: held})
+	}
+
+	if l.opts.NotifyUnlock {
+		l.rm.Publish(l.notifyChannel(), l.token)
+	}
+}
+
+// markReleased 清除本地持有状态，返回此前是否持有及持有时长
+func (l *Lock) markReleased() (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.held {
+		return false, 0
+	}
+	l.held = false
+	return true, time.Since(l.acquiredAt)
+}
+
+// record 上报锁事件到管理器的锁指标
+func (l *Lock) record(event LockEvent) {
+	event.Key = l.key
+	event.Token = l.token
+	l.rm.lockMetrics.record(event)
+}
+
+// Refresh 将锁的过期时间重置为 TTL；锁已不再由本对象持有时返回 false
+func (l *Lock) Refresh() CacheResult[bool] {
+	if l.opts.Reentrant {
+		return l.rm.RenewReentrantLock(l.key, l.token, l.opts.TTL)
+	}
+	return l.rm.RenewLock(l.key, l.token, l.opts.TTL)
+}
+
+// TTL 获取锁的剩余有效期，锁不存在时返回 KEY_NOT_FOUND
+func (l *Lock) TTL() CacheResult[time.Duration] {
+	return l.rm.PTTL(l.key)
+}
+
+// IsHeld 本对象是否认为自己持有锁（仅反映本地状态，锁可能已在服务端过期）
+func (l *Lock) IsHeld() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.held
+}
@@ -0,0 +1,116 @@
+package redisx
+
+import (
+	"sync"
+	"time"
+)
+
+// LockEventType 分布式锁事件类型
+type LockEventType string
+
+const (
+	// LockEventAcquired 获取锁成功
+	LockEventAcquired LockEventType = "acquired"
+	// LockEventContended 获取锁时锁已被占用（TryLock 失败或 Lock 进入等待）
+	LockEventContended LockEventType = "contended"
+	// LockEventReleased 主动释放锁
+	LockEventReleased LockEventType = "released"
+	// LockEventRenewFailed 看门狗续期请求失败（连接错误等，之后会继续重试）
+	LockEventRenewFailed LockEventType = "renew_failed"
+	// LockEventExpired 本地认为持有锁，但锁已在服务端过期或被他人持有
+	LockEventExpired LockEventType = "expired"
+)
+
+// LockEvent 分布式锁事件
+type LockEvent struct {
+	Type  LockEventType
+	Key   string
+	Token string
+	Wait  time.Duration // 仅 acquired 事件：从开始获取到获取成功的耗时
+	Held  time.Duration // 仅 released、expired 事件：持有时长
+	Err   error         // 仅 renew_failed 事件
+}
+
+// LockEventHandler 分布式锁事件回调，在触发事件的协程中同步调用，应尽快返回
+type LockEventHandler func(event LockEvent)
+
+// LockStats 分布式锁统计，统计通过 NewLock 创建的 Lock 对象
+type LockStats struct {
+	Acquired      int64         // 获取成功次数
+	Contended     int64         // 遇到竞争的次数
+	Released      int64         // 主动释放次数
+	RenewFailures int64         // 续期失败次数
+	Expired       int64         // 持有期间过期（锁丢失）次数
+	TotalWait     time.Duration // 获取成功的累计等待时间
+	MaxWait       time.Duration // 获取成功的最长等待时间
+	TotalHold     time.Duration // 主动释放的锁的累计持有时间
+	MaxHold       time.Duration // 主动释放的锁的最长持有时间
+}
+
+// AvgWait 平均获取等待时间
+func (s LockStats) AvgWait() time.Duration {
+	if s.Acquired == 0 {
+		return 0
+	}
+	return s.TotalWait / time.Duration(s.Acquired)
+}
+
+// AvgHold 平均持有时间
+func (s LockStats) AvgHold() time.Duration {
+	if s.Released == 0 {
+		return 0
+	}
+	return s.TotalHold / time.Duration(s.Released)
+}
+
+// lockMetrics 管理器级别的锁指标和事件回调
+type lockMetrics struct {
+	mu      sync.RWMutex
+	stats   LockStats
+	handler LockEventHandler
+}
+
+// record 更新统计并触发事件回调
+func (m *lockMetrics) record(event LockEvent) {
+	m.mu.Lock()
+	switch event.Type {
+	case LockEventAcquired:
+		m.stats.Acquired++
+		m.stats.TotalWait += event.Wait
+		if event.Wait > m.stats.MaxWait {
+			m.stats.MaxWait = event.Wait
+		}
+	case LockEventContended:
+		m.stats.Contended++
+	case LockEventReleased:
+		m.stats.Released++
+		m.stats.TotalHold += event.Held
+		if event.Held > m.stats.MaxHold {
+			m.stats.MaxHold = event.Held
+		}
+	case LockEventRenewFailed:
+		m.stats.RenewFailures++
+	case LockEventExpired:
+		m.stats.Expired++
+	}
+	handler := m.handler
+	m.mu.Unlock()
+
+	if handler != nil {
+		handler(event)
+	}
+}
+
+// SetLockEventHandler 设置分布式锁事件回调，传入 nil 时取消回调
+func (rm *RedisManager) SetLockEventHandler(handler LockEventHandler) {
+	rm.lockMetrics.mu.Lock()
+	defer rm.lockMetrics.mu.Unlock()
+	rm.lockMetrics.handler = handler
+}
+
+// LockStats 获取分布式锁统计
+func (rm *RedisManager) LockStats() LockStats {
+	rm.lockMetrics.mu.RLock()
+	defer rm.lockMetrics.mu.RUnlock()
+	return rm.lockMetrics.stats
+}
@@ -0,0 +1,52 @@
+package redisx
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 获取锁时的 ctx 结束后看门狗停止续期
+func TestLockWatchdogStopsWithContext(t *testing.T) {
+	var evals atomic.Int64
+	s := newFakeServer(t, func(args []string) string {
+		if args[0] == "evalsha" || args[0] == "eval" {
+			evals.Add(1)
+			return ":1\r\n"
+		}
+		return ""
+	})
+	rm := newTestManager(t, s.addr, nil)
+	l := rm.NewLock("job", &LockOptions{TTL: 30 * time.Millisecond, AutoRenew: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if res := l.TryLockCtx(ctx); !res.IsOK() || !res.Val {
+		t.Fatalf("TryLockCtx = %v, %v", res.Val, res.Err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if evals.Load() < 2 {
+		t.Fatal("watchdog did not renew the lock")
+	}
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for {
+		l.mutex.Lock()
+		running := l.watchDone != nil
+		l.mutex.Unlock()
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("watchdog still running after context was cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	n := evals.Load()
+	time.Sleep(50 * time.Millisecond)
+	if evals.Load() != n {
+		t.Fatal("lock still renewed after context was cancelled")
+	}
+}
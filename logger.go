@@ -0,0 +1,161 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Logger 日志接口，args 为交替的键值对（同 log/slog）
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	With(args ...any) Logger
+}
+
+// slogLogger 基于 log/slog 的 Logger
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger 创建基于 log/slog 的 Logger，l 为 nil 时使用 slog.Default()
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) Debug(msg string, args ...any) { s.l.Debug(msg, args...) }
+func (s slogLogger) Info(msg string, args ...any)  { s.l.Info(msg, args...) }
+func (s slogLogger) Warn(msg string, args ...any)  { s.l.Warn(msg, args...) }
+func (s slogLogger) Error(msg string, args ...any) { s.l.Error(msg, args...) }
+func (s slogLogger) With(args ...any) Logger       { return slogLogger{l: s.l.With(args...)} }
+
+// NopLogger 丢弃所有日志
+type NopLogger struct{}
+
+func (NopLogger) Debug(msg string, args ...any) {}
+func (NopLogger) Info(msg string, args ...any)  {}
+func (NopLogger) Warn(msg string, args ...any)  {}
+func (NopLogger) Error(msg string, args ...any) {}
+func (n NopLogger) With(args ...any) Logger     { return n }
+
+// SetLogger 设置管理器使用的 Logger，传入 nil 时恢复为 slog.Default()
+// 所有日志自动附带 mode 和 addr 字段
+func (rm *RedisManager) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NewSlogLogger(nil)
+	}
+	logger = logger.With("mode", string(rm.config.Mode), "addr", rm.config.addrs())
+
+	rm.loggerMutex.Lock()
+	rm.logger = logger
+	rm.loggerMutex.Unlock()
+
+	rm.stats.setLogger(logger)
+
+	rm.statsSinksMutex.Lock()
+	rm.logStats = NewLogStatsSink(logger)
+	rm.statsSinksMutex.Unlock()
+}
+
+// Logger 获取管理器使用的 Logger
+func (rm *RedisManager) Logger() Logger {
+	rm.loggerMutex.RLock()
+	defer rm.loggerMutex.RUnlock()
+	return rm.logger
+}
+
+// addrs 配置的节点地址，多个地址以逗号分隔
+func (c *RedisConfig) addrs() string {
+	switch c.Mode {
+	case ModeSingle:
+		if c.Single != nil {
+			return c.Single.Addr
+		}
+	case ModeMasterSlave:
+		if c.MasterSlave != nil {
+			if c.MasterSlave.Sentinel != nil && c.MasterSlave.Sentinel.Enabled {
+				return strings.Join(c.MasterSlave.Sentinel.SentinelAddrs, ",")
+			}
+			shards := c.MasterSlave.ringShards()
+			addrs := make([]string, len(shards))
+			for i, shard := range shards {
+				addrs[i] = shard.Addr
+			}
+			return strings.Join(addrs, ",")
+		}
+	case ModeCluster:
+		if c.Cluster != nil {
+			return strings.Join(c.Cluster.Addrs, ",")
+		}
+	}
+	return ""
+}
+
+// errorCodeOf 将 go-redis 返回的错误归类为错误码
+func errorCodeOf(err error) ErrorCode {
+	var netErr net.Error
+	var redisErr *RedisError
+	switch {
+	case err == nil:
+		return OK
+	case errors.As(err, &redisErr):
+		return redisErr.Code
+	case errors.Is(err, redis.Nil):
+		return KEY_NOT_FOUND
+	case errors.Is(err, context.Canceled):
+		return INTERRUPTED
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return TIMEOUT
+	default:
+		return REDIS_INNER_ERROR
+	}
+}
+
+// logHook 记录失败命令的 go-redis 钩子，命令名、耗时和错误码以结构化字段输出（Debug 级别）
+type logHook struct {
+	rm *RedisManager
+}
+
+func (h logHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.rm.Logger().Warn("Redis dial failed", "node", addr, "error", err)
+		}
+		return conn, err
+	}
+}
+
+func (h logHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		if code := errorCodeOf(err); code != OK && code != KEY_NOT_FOUND {
+			h.rm.Logger().Debug("Redis command failed", "command", cmd.Name(),
+				"latency", time.Since(start), "error_code", code.String(), "error", err)
+		}
+		return err
+	}
+}
+
+func (h logHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		if code := errorCodeOf(err); code != OK && code != KEY_NOT_FOUND {
+			h.rm.Logger().Debug("Redis pipeline failed", "commands", len(cmds),
+				"latency", time.Since(start), "error_code", code.String(), "error", err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,111 @@
+package redisx
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruEntry 本地缓存条目
+type lruEntry[V any] struct {
+	key      string
+	value    V
+	expireAt time.Time
+}
+
+// lruCache 带容量上限和条目过期时间的进程内 LRU 缓存，并发安全
+type lruCache[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// newLRUCache 创建 LRU 缓存，ttl<=0 时条目不过期
+func newLRUCache[V any](capacity int, ttl time.Duration) *lruCache[V] {
+	return &lruCache[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get 读取条目，过期的条目视为不存在并移除
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[V])
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		c.removeElement(elem)
+		return zero, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set 写入条目，超出容量时淘汰最久未使用的条目
+func (c *lruCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expireAt time.Time
+	if c.ttl > 0 {
+		expireAt = time.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expireAt = expireAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruEntry[V]{key: key, value: value, expireAt: expireAt})
+	for c.capacity > 0 && c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// remove 移除条目，返回条目是否存在
+func (c *lruCache[V]) remove(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if ok {
+		c.removeElement(elem)
+	}
+	return ok
+}
+
+// clear 清空所有条目
+func (c *lruCache[V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// len 当前条目数（包括尚未清理的过期条目）
+func (c *lruCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// removeElement 移除链表元素，调用方需持有 c.mu
+func (c *lruCache[V]) removeElement(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry[V]).key)
+}
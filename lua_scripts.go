@@ -34,6 +34,33 @@ const (
 	// ScriptKeyRenewLock 续期分布式锁脚本的键名
 	ScriptKeyRenewLock = "renew_lock_script"
 
+	// ScriptKeyReentrantLock 可重入锁脚本的键名
+	ScriptKeyReentrantLock = "reentrant_lock_script"
+
+	// ScriptKeyReentrantUnlock 可重入解锁脚本的键名
+	ScriptKeyReentrantUnlock = "reentrant_unlock_script"
+
+	// ScriptKeyReentrantRenew 续期可重入锁脚本的键名
+	ScriptKeyReentrantRenew = "reentrant_renew_script"
+
+	// ScriptKeyFairLock 公平锁脚本的键名
+	ScriptKeyFairLock = "fair_lock_script"
+
+	// ScriptKeySemaphoreAcquire 信号量获取脚本的键名
+	ScriptKeySemaphoreAcquire = "semaphore_acquire_script"
+
+	// ScriptKeySemaphoreRelease 信号量释放脚本的键名
+	ScriptKeySemaphoreRelease = "semaphore_release_script"
+
+	// ScriptKeySemaphoreRefresh 信号量续期脚本的键名
+	ScriptKeySemaphoreRefresh = "semaphore_refresh_script"
+
+	// ScriptKeyLatchCountDown 倒计时门闩减计数脚本的键名
+	ScriptKeyLatchCountDown = "latch_count_down_script"
+
+	// ScriptKeyBarrierArrive 屏障到达脚本的键名
+	ScriptKeyBarrierArrive = "barrier_arrive_script"
+
 	// ScriptKeyMultiLock 多键锁脚本的键名
 	ScriptKeyMultiLock = "multi_lock_script"
 
@@ -42,6 +69,21 @@ const (
 
 	// ScriptKeyIncrWithLimitAndExpire 带上限和过期时间的递增脚本键名
 	ScriptKeyIncrWithLimitAndExpire = "incr_with_limit_and_expire_script"
+
+	// ScriptKeyZIncr 安全有序集合增分脚本的键名
+	ScriptKeyZIncr = "zincr_script"
+
+	// ScriptKeySlidingWindow 滑动窗口限流脚本的键名
+	ScriptKeySlidingWindow = "sliding_window_script"
+
+	// ScriptKeyTaskEnqueue 任务入队脚本的键名
+	ScriptKeyTaskEnqueue = "task_enqueue_script"
+
+	// ScriptKeyTaskPromote 延迟任务转入就绪流脚本的键名
+	ScriptKeyTaskPromote = "task_promote_script"
+
+	// ScriptKeyPriorityPop 优先级队列弹出脚本的键名
+	ScriptKeyPriorityPop = "priority_pop_script"
 )
 
 // Lua脚本内容定义
@@ -125,6 +167,21 @@ else
     return cur
 end`
 
+// ZIncrScript 安全有序集合增分脚本
+// 参数: KEYS[1] = key, ARGV[1] = 成员, ARGV[2] = 增加的分数, ARGV[3] = 分数上限
+// 返回: 增加后的分数（字符串形式以保留小数），增加后超过上限时分数被截断为上限值，当前分数已达上限则返回当前分数
+const ZIncrScript = `
+local cur = tonumber(redis.call('zscore', KEYS[1], ARGV[1]) or 0)
+local incr = tonumber(ARGV[2])
+local max = tonumber(ARGV[3])
+if cur >= max then
+    return tostring(cur)
+end
+if cur + incr > max then
+    incr = max - cur
+end
+return redis.call('zincrby', KEYS[1], incr, ARGV[1])`
+
 // CheckKeyExpireScript 检查键存在并设置过期时间
 // 参数: KEYS[1] = key, ARGV[1] = TTL秒数
 // 返回: 1表示成功设置过期时间，0表示键不存在
@@ -217,23 +274,257 @@ else
     return 0
 end`
 
+// ReentrantLockScript 可重入锁脚本，锁以哈希存储 {持有者: 重入次数}
+// 参数: KEYS[1] = 锁的key, ARGV[1] = 持有者令牌, ARGV[2] = 过期时间(毫秒)
+// 返回: 大于0表示获取成功后的重入次数，0表示锁被其他持有者占用，-1表示参数错误
+const ReentrantLockScript = `
+local key = KEYS[1]
+local value = ARGV[1]
+local ttl = tonumber(ARGV[2])
+
+-- 检查参数
+if not key or not value or not ttl or ttl <= 0 then
+    return -1
+end
+
+-- 锁不存在或由自己持有时增加重入次数并刷新过期时间
+if redis.call('EXISTS', key) == 0 or redis.call('HEXISTS', key, value) == 1 then
+    local count = redis.call('HINCRBY', key, value, 1)
+    redis.call('PEXPIRE', key, ttl)
+    return count
+end
+
+return 0`
+
+// ReentrantUnlockScript 可重入解锁脚本，重入次数减为0时删除锁
+// 参数: KEYS[1] = 锁的key, ARGV[1] = 持有者令牌
+// 返回: 剩余重入次数（0表示锁已释放），-1表示锁不存在或不由该持有者持有
+const ReentrantUnlockScript = `
+local key = KEYS[1]
+local value = ARGV[1]
+
+if redis.call('HEXISTS', key, value) == 0 then
+    return -1
+end
+
+local count = redis.call('HINCRBY', key, value, -1)
+if count <= 0 then
+    redis.call('DEL', key)
+    return 0
+end
+
+return count`
+
+// ReentrantRenewScript 续期可重入锁脚本
+// 参数: KEYS[1] = 锁的key, ARGV[1] = 持有者令牌, ARGV[2] = 新的过期时间(毫秒)
+// 返回: 1表示续期成功，0表示锁不存在或不由该持有者持有，-1表示参数错误
+const ReentrantRenewScript = `
+local key = KEYS[1]
+local value = ARGV[1]
+local ttl = tonumber(ARGV[2])
+
+if not key or not value or not ttl or ttl <= 0 then
+    return -1
+end
+
+if redis.call('HEXISTS', key, value) == 1 then
+    redis.call('PEXPIRE', key, ttl)
+    return 1
+end
+
+return 0`
+
+// FairLockScript 公平锁脚本，等待者按首次排队时间先后获得锁
+// 参数: KEYS[1] = 锁的key, KEYS[2] = 等待队列(有序集合), KEYS[3] = 等待者心跳(哈希), ARGV[1] = 令牌, ARGV[2] = 锁过期时间(毫秒), ARGV[3] = 当前时间(毫秒), ARGV[4] = 等待者超时(毫秒), ARGV[5] = 未获得锁时是否排队(1/0)
+// 返回: 1表示获取成功，0表示需要继续等待，-1表示参数错误
+const FairLockScript = `
+local key = KEYS[1]
+local queue = KEYS[2]
+local heartbeat = KEYS[3]
+local value = ARGV[1]
+local ttl = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local waiter_timeout = tonumber(ARGV[4])
+local enqueue = ARGV[5] == '1'
+
+if not value or not ttl or ttl <= 0 or not now or not waiter_timeout then
+    return -1
+end
+
+-- 清理心跳超时的等待者（进程崩溃或已放弃等待）
+local waiters = redis.call('ZRANGE', queue, 0, -1)
+for _, waiter in ipairs(waiters) do
+    local last = tonumber(redis.call('HGET', heartbeat, waiter))
+    if not last or last < now - waiter_timeout then
+        redis.call('ZREM', queue, waiter)
+        redis.call('HDEL', heartbeat, waiter)
+    end
+end
+
+local current = redis.call('GET', key)
+if current == value then
+    return 1
+end
+
+-- 锁空闲且自己位于队首（或队列为空）时获得锁
+if not current then
+    local head = redis.call('ZRANGE', queue, 0, 0)
+    if #head == 0 or head[1] == value then
+        redis.call('SET', key, value, 'PX', ttl)
+        redis.call('ZREM', queue, value)
+        redis.call('HDEL', heartbeat, value)
+        return 1
+    end
+end
+
+-- 排队并刷新心跳，首次排队的时间决定顺序
+if enqueue or redis.call('ZSCORE', queue, value) then
+    redis.call('ZADD', queue, 'NX', now, value)
+    redis.call('HSET', heartbeat, value, now)
+    redis.call('PEXPIRE', queue, waiter_timeout + ttl)
+    redis.call('PEXPIRE', heartbeat, waiter_timeout + ttl)
+end
+
+return 0`
+
+// SemaphoreAcquireScript 信号量获取脚本，每个持有者的许可带有独立的过期时间
+// 参数: KEYS[1] = 持有者许可数(哈希), KEYS[2] = 持有者过期时间(有序集合), ARGV[1] = 持有者, ARGV[2] = 申请的许可数(0表示只查询), ARGV[3] = 许可总数, ARGV[4] = 当前时间(毫秒), ARGV[5] = 持有过期时间(毫秒)
+// 返回: {是否获取成功(1/0), 获取后剩余可用许可数}
+const SemaphoreAcquireScript = `
+local holders = KEYS[1]
+local expiry = KEYS[2]
+local holder = ARGV[1]
+local n = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+local ttl = tonumber(ARGV[5])
+
+-- 回收过期持有者的许可
+local expired = redis.call('ZRANGEBYSCORE', expiry, '-inf', now)
+for _, h in ipairs(expired) do
+    redis.call('HDEL', holders, h)
+    redis.call('ZREM', expiry, h)
+end
+
+local used = 0
+for _, v in ipairs(redis.call('HVALS', holders)) do
+    used = used + tonumber(v)
+end
+
+if n <= 0 then
+    return {0, limit - used}
+end
+
+if used + n > limit then
+    return {0, limit - used}
+end
+
+redis.call('HINCRBY', holders, holder, n)
+redis.call('ZADD', expiry, now + ttl, holder)
+redis.call('PEXPIRE', holders, ttl)
+redis.call('PEXPIRE', expiry, ttl)
+
+return {1, limit - used - n}`
+
+// SemaphoreReleaseScript 信号量释放脚本
+// 参数: KEYS[1] = 持有者许可数(哈希), KEYS[2] = 持有者过期时间(有序集合), ARGV[1] = 持有者, ARGV[2] = 释放的许可数
+// 返回: 持有者剩余的许可数
+const SemaphoreReleaseScript = `
+local holders = KEYS[1]
+local expiry = KEYS[2]
+local holder = ARGV[1]
+local n = tonumber(ARGV[2])
+
+if redis.call('HEXISTS', holders, holder) == 0 then
+    return 0
+end
+
+local remaining = redis.call('HINCRBY', holders, holder, -n)
+if remaining <= 0 then
+    redis.call('HDEL', holders, holder)
+    redis.call('ZREM', expiry, holder)
+    return 0
+end
+
+return remaining`
+
+// SemaphoreRefreshScript 信号量续期脚本
+// 参数: KEYS[1] = 持有者许可数(哈希), KEYS[2] = 持有者过期时间(有序集合), ARGV[1] = 持有者, ARGV[2] = 当前时间(毫秒), ARGV[3] = 持有过期时间(毫秒)
+// 返回: 1表示续期成功，0表示未持有许可
+const SemaphoreRefreshScript = `
+local holders = KEYS[1]
+local expiry = KEYS[2]
+local holder = ARGV[1]
+local now = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+if redis.call('HEXISTS', holders, holder) == 0 then
+    return 0
+end
+
+redis.call('ZADD', expiry, now + ttl, holder)
+redis.call('PEXPIRE', holders, ttl)
+redis.call('PEXPIRE', expiry, ttl)
+
+return 1`
+
+// LatchCountDownScript 倒计时门闩减计数脚本，计数减到0时发布通知
+// 参数: KEYS[1] = 计数key, ARGV[1] = 通知频道
+// 返回: 剩余计数，-1表示门闩不存在（未初始化或已过期）
+const LatchCountDownScript = `
+local key = KEYS[1]
+
+local current = redis.call('GET', key)
+if not current then
+    return -1
+end
+
+current = tonumber(current)
+if current <= 0 then
+    return 0
+end
+
+local remaining = redis.call('DECR', key)
+if remaining == 0 then
+    redis.call('PUBLISH', ARGV[1], '0')
+end
+
+return remaining`
+
+// BarrierArriveScript 屏障到达脚本，到达数等于参与方数量时发布通知
+// 参数: KEYS[1] = 到达计数key, ARGV[1] = 参与方数量, ARGV[2] = 过期时间(毫秒), ARGV[3] = 通知频道
+// 返回: 当前到达数
+const BarrierArriveScript = `
+local key = KEYS[1]
+local parties = tonumber(ARGV[1])
+
+local arrived = redis.call('INCR', key)
+if arrived == 1 then
+    redis.call('PEXPIRE', key, ARGV[2])
+end
+if arrived == parties then
+    redis.call('PUBLISH', ARGV[3], arrived)
+end
+
+return arrived`
+
 // MultiLockScript 多键锁脚本（原子性操作）
 // 参数: KEYS = 多个锁的key, ARGV[1] = 锁的值(通常是UUID), ARGV[2] = 过期时间(毫秒)
-// 返回: 1表示所有锁获取成功，0表示至少有一个锁获取失败，-1表示参数错误
+// 返回: {状态, 冲突键序号}，状态 1=成功，0=有锁被其他客户端持有（序号为第一个冲突键在 KEYS 中的位置，从1开始），-1=参数错误
 const MultiLockScript = `
 local value = ARGV[1]
 local ttl = tonumber(ARGV[2])
 
 -- 检查参数
 if not value or not ttl or ttl <= 0 or #KEYS == 0 then
-    return -1
+    return {-1, 0}
 end
 
 -- 第一阶段：检查所有锁是否都可以获取
 for i, key in ipairs(KEYS) do
     local current = redis.call('GET', key)
     if current and current ~= value then
-        return 0  -- 有锁被其他客户端持有
+        return {0, i}  -- 有锁被其他客户端持有
     end
 end
 
@@ -242,7 +533,7 @@ for i, key in ipairs(KEYS) do
     redis.call('SET', key, value, 'PX', ttl)
 end
 
-return 1`
+return {1, 0}`
 
 // MultiUnlockScript 多键解锁脚本
 // 参数: KEYS = 多个锁的key, ARGV[1] = 锁的值(通常是UUID)
@@ -266,6 +557,90 @@ end
 
 return unlocked`
 
+// SlidingWindowScript 基于有序集合的滑动窗口限流脚本
+// 参数: KEYS[1] = 限流key, ARGV[1] = 当前时间(毫秒), ARGV[2] = 窗口大小(毫秒), ARGV[3] = 窗口内允许的请求数, ARGV[4] = 本次请求的唯一成员名
+// 返回: {是否允许(1/0), 剩余可用次数, 窗口重置时间(毫秒时间戳)}
+const SlidingWindowScript = `
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local member = ARGV[4]
+
+-- 清理窗口外的请求记录
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+    redis.call('ZADD', key, now, member)
+    count = count + 1
+    allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+-- 最早的一条记录滑出窗口的时间即为重置时间
+local reset = now + window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+    reset = tonumber(oldest[2]) + window
+end
+
+return {allowed, limit - count, reset}`
+
+// TaskEnqueueScript 任务入队脚本（支持去重和延迟）
+// 参数: KEYS[1] = 就绪流, KEYS[2] = 延迟任务有序集合, KEYS[3] = 去重键（可选）, ARGV[1] = 任务ID, ARGV[2] = 任务内容, ARGV[3] = 执行时间(毫秒时间戳，0表示立即执行), ARGV[4] = 去重键有效期(毫秒)
+// 返回: {是否新入队(1/0), 任务ID（重复时为已存在的任务ID）}
+const TaskEnqueueScript = `
+local id = ARGV[1]
+local payload = ARGV[2]
+local run_at = tonumber(ARGV[3])
+
+-- 去重：去重键有效期内重复入队返回已有任务ID
+if KEYS[3] then
+    local existing = redis.call('GET', KEYS[3])
+    if existing then
+        return {0, existing}
+    end
+    redis.call('SET', KEYS[3], id, 'PX', ARGV[4])
+end
+
+if run_at > 0 then
+    -- 成员格式为 "任务ID:任务内容"，任务ID中不含冒号
+    redis.call('ZADD', KEYS[2], run_at, id .. ':' .. payload)
+else
+    redis.call('XADD', KEYS[1], '*', 'task_id', id, 'payload', payload)
+end
+
+return {1, id}`
+
+// TaskPromoteScript 将到期的延迟任务转入就绪流
+// 参数: KEYS[1] = 就绪流, KEYS[2] = 延迟任务有序集合, ARGV[1] = 当前时间(毫秒), ARGV[2] = 单次最多转移数量
+// 返回: 转移的任务数量
+const TaskPromoteScript = `
+local due = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+
+for _, member in ipairs(due) do
+    local sep = string.find(member, ':', 1, true)
+    redis.call('XADD', KEYS[1], '*', 'task_id', string.sub(member, 1, sep - 1), 'payload', string.sub(member, sep + 1))
+    redis.call('ZREM', KEYS[2], member)
+end
+
+return #due`
+
+// PriorityPopScript 按分数从小到大弹出优先级队列中的元素
+// 参数: KEYS[1] = 队列key, ARGV[1] = 最多弹出数量
+// 返回: {成员1, 分数1, 成员2, 分数2, ...}
+const PriorityPopScript = `
+local count = tonumber(ARGV[1])
+local items = redis.call('ZRANGE', KEYS[1], 0, count - 1, 'WITHSCORES')
+
+for i = 1, #items, 2 do
+    redis.call('ZREM', KEYS[1], items[i])
+end
+
+return items`
+
 // RegisterAllScripts 注册所有Lua脚本到RedisManager
 func RegisterAllScripts(rm *RedisManager) {
 	rm.RegisterScript(ScriptKeyDecr, DecrScript)
@@ -278,9 +653,23 @@ func RegisterAllScripts(rm *RedisManager) {
 	rm.RegisterScript(ScriptKeyLock, LockScript)
 	rm.RegisterScript(ScriptKeyUnlock, UnlockScript)
 	rm.RegisterScript(ScriptKeyRenewLock, RenewLockScript)
+	rm.RegisterScript(ScriptKeyReentrantLock, ReentrantLockScript)
+	rm.RegisterScript(ScriptKeyReentrantUnlock, ReentrantUnlockScript)
+	rm.RegisterScript(ScriptKeyReentrantRenew, ReentrantRenewScript)
+	rm.RegisterScript(ScriptKeyFairLock, FairLockScript)
+	rm.RegisterScript(ScriptKeySemaphoreAcquire, SemaphoreAcquireScript)
+	rm.RegisterScript(ScriptKeySemaphoreRelease, SemaphoreReleaseScript)
+	rm.RegisterScript(ScriptKeySemaphoreRefresh, SemaphoreRefreshScript)
+	rm.RegisterScript(ScriptKeyLatchCountDown, LatchCountDownScript)
+	rm.RegisterScript(ScriptKeyBarrierArrive, BarrierArriveScript)
 	rm.RegisterScript(ScriptKeyMultiLock, MultiLockScript)
 	rm.RegisterScript(ScriptKeyMultiUnlock, MultiUnlockScript)
 	rm.RegisterScript(ScriptKeyIncrWithLimitAndExpire, IncrWithLimitAndExpireScript)
+	rm.RegisterScript(ScriptKeyZIncr, ZIncrScript)
+	rm.RegisterScript(ScriptKeySlidingWindow, SlidingWindowScript)
+	rm.RegisterScript(ScriptKeyTaskEnqueue, TaskEnqueueScript)
+	rm.RegisterScript(ScriptKeyTaskPromote, TaskPromoteScript)
+	rm.RegisterScript(ScriptKeyPriorityPop, PriorityPopScript)
 }
 
 func RegisterScripts(rm *RedisManager, scripts map[string]string) {
@@ -3,9 +3,9 @@ package redisx
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -19,11 +19,14 @@ type RedisClient interface {
 	Exists(ctx context.Context, keys ...string) *redis.IntCmd
 	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
 	TTL(ctx context.Context, key string) *redis.DurationCmd
+	PTTL(ctx context.Context, key string) *redis.DurationCmd
 	Rename(ctx context.Context, key, newKey string) *redis.StatusCmd
 	RenameNX(ctx context.Context, key, newKey string) *redis.BoolCmd
 	Type(ctx context.Context, key string) *redis.StatusCmd
 	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
 	GetSet(ctx context.Context, key string, value interface{}) *redis.StringCmd
+	Dump(ctx context.Context, key string) *redis.StringCmd
+	RestoreReplace(ctx context.Context, key string, ttl time.Duration, value string) *redis.StatusCmd
 
 	// String operations
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
@@ -65,6 +68,8 @@ type RedisClient interface {
 
 	// Sorted Set operations
 	ZAdd(ctx context.Context, key string, members ...redis.Z) *redis.IntCmd
+	ZAddArgs(ctx context.Context, key string, args redis.ZAddArgs) *redis.IntCmd
+	ZAddArgsIncr(ctx context.Context, key string, args redis.ZAddArgs) *redis.FloatCmd
 	ZRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd
 	ZRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
 	ZRevRange(ctx context.Context, key string, start, stop int64) *redis.StringSliceCmd
@@ -76,17 +81,118 @@ type RedisClient interface {
 	ZRank(ctx context.Context, key, member string) *redis.IntCmd
 	ZRevRank(ctx context.Context, key, member string) *redis.IntCmd
 	ZIncrBy(ctx context.Context, key string, increment float64, member string) *redis.FloatCmd
+	ZRangeByScore(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd
+	ZRevRangeByScore(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd
+	ZRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd
+	ZRevRangeByScoreWithScores(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.ZSliceCmd
+	ZPopMin(ctx context.Context, key string, count ...int64) *redis.ZSliceCmd
+	ZPopMax(ctx context.Context, key string, count ...int64) *redis.ZSliceCmd
+	BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) *redis.ZWithKeyCmd
+	BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) *redis.ZWithKeyCmd
+	ZRangeByLex(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd
+	ZRevRangeByLex(ctx context.Context, key string, opt *redis.ZRangeBy) *redis.StringSliceCmd
+	ZLexCount(ctx context.Context, key, min, max string) *redis.IntCmd
+	ZUnionStore(ctx context.Context, dest string, store *redis.ZStore) *redis.IntCmd
+	ZInterStore(ctx context.Context, destination string, store *redis.ZStore) *redis.IntCmd
+	ZDiffStore(ctx context.Context, destination string, keys ...string) *redis.IntCmd
 
 	// Scan
 	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) *redis.ScanCmd
 
 	// Bitmap operations
 	GetBit(ctx context.Context, key string, offset int64) *redis.IntCmd
 	SetBit(ctx context.Context, key string, offset int64, value int) *redis.IntCmd
 	BitCount(ctx context.Context, key string, bitCount *redis.BitCount) *redis.IntCmd
+	BitPos(ctx context.Context, key string, bit int64, pos ...int64) *redis.IntCmd
+	BitOpAnd(ctx context.Context, destKey string, keys ...string) *redis.IntCmd
+	BitOpOr(ctx context.Context, destKey string, keys ...string) *redis.IntCmd
+	BitOpXor(ctx context.Context, destKey string, keys ...string) *redis.IntCmd
+	BitOpNot(ctx context.Context, destKey string, key string) *redis.IntCmd
+
+	// HyperLogLog operations
+	PFAdd(ctx context.Context, key string, els ...interface{}) *redis.IntCmd
+	PFCount(ctx context.Context, keys ...string) *redis.IntCmd
+	PFMerge(ctx context.Context, dest string, keys ...string) *redis.StatusCmd
+
+	// Geo operations
+	GeoAdd(ctx context.Context, key string, geoLocation ...*redis.GeoLocation) *redis.IntCmd
+	GeoPos(ctx context.Context, key string, members ...string) *redis.GeoPosCmd
+	GeoDist(ctx context.Context, key string, member1, member2, unit string) *redis.FloatCmd
+	GeoSearchLocation(ctx context.Context, key string, q *redis.GeoSearchLocationQuery) *redis.GeoSearchLocationCmd
+
+	// Stream operations
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+	XRead(ctx context.Context, a *redis.XReadArgs) *redis.XStreamSliceCmd
+	XReadGroup(ctx context.Context, a *redis.XReadGroupArgs) *redis.XStreamSliceCmd
+	XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	XRevRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	XDel(ctx context.Context, stream string, ids ...string) *redis.IntCmd
+	XLen(ctx context.Context, stream string) *redis.IntCmd
+	XGroupCreate(ctx context.Context, stream, group, start string) *redis.StatusCmd
+	XGroupCreateMkStream(ctx context.Context, stream, group, start string) *redis.StatusCmd
+	XGroupDestroy(ctx context.Context, stream, group string) *redis.IntCmd
+	XGroupDelConsumer(ctx context.Context, stream, group, consumer string) *redis.IntCmd
+	XAck(ctx context.Context, stream, group string, ids ...string) *redis.IntCmd
+	XPending(ctx context.Context, stream, group string) *redis.XPendingCmd
+	XPendingExt(ctx context.Context, a *redis.XPendingExtArgs) *redis.XPendingExtCmd
+	XClaim(ctx context.Context, a *redis.XClaimArgs) *redis.XMessageSliceCmd
+	XAutoClaim(ctx context.Context, a *redis.XAutoClaimArgs) *redis.XAutoClaimCmd
+	XTrimMaxLen(ctx context.Context, key string, maxLen int64) *redis.IntCmd
+	XTrimMaxLenApprox(ctx context.Context, key string, maxLen, limit int64) *redis.IntCmd
+	XTrimMinID(ctx context.Context, key string, minID string) *redis.IntCmd
+	XTrimMinIDApprox(ctx context.Context, key string, minID string, limit int64) *redis.IntCmd
+	XInfoStream(ctx context.Context, key string) *redis.XInfoStreamCmd
+	XInfoGroups(ctx context.Context, key string) *redis.XInfoGroupsCmd
+	XInfoConsumers(ctx context.Context, key string, group string) *redis.XInfoConsumersCmd
+
+	// RedisBloom module operations
+	BFReserve(ctx context.Context, key string, errorRate float64, capacity int64) *redis.StatusCmd
+	BFAdd(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	BFExists(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	BFMAdd(ctx context.Context, key string, elements ...interface{}) *redis.BoolSliceCmd
+	BFMExists(ctx context.Context, key string, elements ...interface{}) *redis.BoolSliceCmd
+	CFReserve(ctx context.Context, key string, capacity int64) *redis.StatusCmd
+	CFAdd(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	CFAddNX(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	CFExists(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	CFMExists(ctx context.Context, key string, elements ...interface{}) *redis.BoolSliceCmd
+	CFDel(ctx context.Context, key string, element interface{}) *redis.BoolCmd
+	CFCount(ctx context.Context, key string, element interface{}) *redis.IntCmd
+	TopKReserve(ctx context.Context, key string, k int64) *redis.StatusCmd
+	TopKReserveWithOptions(ctx context.Context, key string, k int64, width, depth int64, decay float64) *redis.StatusCmd
+	TopKAdd(ctx context.Context, key string, elements ...interface{}) *redis.StringSliceCmd
+	TopKIncrBy(ctx context.Context, key string, elements ...interface{}) *redis.StringSliceCmd
+	TopKQuery(ctx context.Context, key string, elements ...interface{}) *redis.BoolSliceCmd
+	TopKList(ctx context.Context, key string) *redis.StringSliceCmd
+	TopKListWithCount(ctx context.Context, key string) *redis.MapStringIntCmd
+	CMSInitByDim(ctx context.Context, key string, width, depth int64) *redis.StatusCmd
+	CMSInitByProb(ctx context.Context, key string, errorRate, probability float64) *redis.StatusCmd
+	CMSIncrBy(ctx context.Context, key string, elements ...interface{}) *redis.IntSliceCmd
+	CMSQuery(ctx context.Context, key string, elements ...interface{}) *redis.IntSliceCmd
+	CMSMerge(ctx context.Context, destKey string, sourceKeys ...string) *redis.StatusCmd
+
+	// RediSearch module operations
+	FTCreate(ctx context.Context, index string, options *redis.FTCreateOptions, schema ...*redis.FieldSchema) *redis.StatusCmd
+	FTDropIndexWithArgs(ctx context.Context, index string, options *redis.FTDropIndexOptions) *redis.StatusCmd
+	FTSearchWithArgs(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd
+	FTAggregateWithArgs(ctx context.Context, index string, query string, options *redis.FTAggregateOptions) *redis.AggregateCmd
+
+	// Pub/Sub operations
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	SPublish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	PSubscribe(ctx context.Context, channels ...string) *redis.PubSub
+	SSubscribe(ctx context.Context, channels ...string) *redis.PubSub
+
+	// Generic command support
+	Do(ctx context.Context, args ...interface{}) *redis.Cmd
+	Process(ctx context.Context, cmd redis.Cmder) error
 
 	// Pipeline and Lua script support
 	Pipeline() redis.Pipeliner
+	TxPipeline() redis.Pipeliner
+	Watch(ctx context.Context, fn func(*redis.Tx) error, keys ...string) error
 	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
 	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
 	ScriptLoad(ctx context.Context, script string) *redis.StringCmd
@@ -97,11 +203,24 @@ type RedisClient interface {
 }
 
 // RedisStats Redis统计信息
+// 计数器均为原子操作，记录路径不加锁
 type RedisStats struct {
-	totalOps  int64
-	errorOps  int64
+	totalOps  atomic.Int64
+	errorOps  atomic.Int64
 	startTime time.Time
-	mu        sync.RWMutex
+	mu        sync.RWMutex // 保护 logger
+
+	// 热点键访问统计，未启用时为 nil
+	hotKeys *hotKeyTracker
+
+	// 统计日志输出，为空时使用 slog.Default()
+	logger Logger
+
+	// 按类别统计的命令错误数，下标与 errorClasses 对应
+	errorClassCounts [len(errorClasses)]atomic.Int64
+
+	// 各命名空间的读取计数：前缀 -> *namespaceCounters
+	namespaces sync.Map
 }
 
 // NewRedisStats 创建新的Redis统计
@@ -113,46 +232,223 @@ func NewRedisStats() *RedisStats {
 
 // IncrTotal 增加总操作数
 func (s *RedisStats) IncrTotal() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.totalOps++
+	s.totalOps.Add(1)
 }
 
 // IncrError 增加错误操作数
 func (s *RedisStats) IncrError() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.errorOps++
+	s.errorOps.Add(1)
+}
+
+// acquire 通过 canServe 检查并计入正在执行的操作数，返回 true 时调用方在操作结束后调用 release
+// 先计数再检查关闭状态，Shutdown 置位 closing 之后不会遗漏已通过检查的操作
+func (rm *RedisManager) acquire() bool {
+	rm.inflight.Add(1)
+	if !rm.canServe() {
+		rm.inflight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// unavailableError acquire 失败时返回的错误，已开始关闭时说明管理器已关闭
+func (rm *RedisManager) unavailableError() error {
+	if rm.closing.Load() {
+		return ErrConnectionFailed.WithMessage("redis manager is closed")
+	}
+	return ErrConnectionFailed
+}
+
+// release 结束 acquire 计入的操作
+func (rm *RedisManager) release() {
+	rm.inflight.Add(-1)
+}
+
+// beginLoad 计入一次缓存加载（loader 执行和写回缓存期间），已开始关闭时返回 false；返回 true 时调用方在结束后调用 endLoad
+func (rm *RedisManager) beginLoad() bool {
+	rm.inflight.Add(1)
+	rm.loading.Add(1)
+	if rm.closing.Load() {
+		rm.endLoad()
+		return false
+	}
+	return true
+}
+
+// endLoad 结束 beginLoad 计入的缓存加载
+func (rm *RedisManager) endLoad() {
+	rm.loading.Add(-1)
+	rm.inflight.Add(-1)
 }
 
 // GetStats 获取统计信息
 func (s *RedisStats) GetStats() (total, errors int64, uptime time.Duration) {
+	return s.totalOps.Load(), s.errorOps.Load(), time.Since(s.startTime)
+}
+
+// setLogger 设置统计日志输出
+func (s *RedisStats) setLogger(logger Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = logger
+}
+
+// getLogger 获取统计日志输出
+func (s *RedisStats) getLogger() Logger {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.totalOps, s.errorOps, time.Since(s.startTime)
+	if s.logger == nil {
+		return NewSlogLogger(nil)
+	}
+	return s.logger
 }
 
 // Proc 处理统计信息（打印或记录）
 func (s *RedisStats) Proc() {
+	logger := s.getLogger()
 	total, errors, uptime := s.GetStats()
-	log.Printf("Redis Stats - Total: %d, Errors: %d, Uptime: %v, Error Rate: %.2f%%",
-		total, errors, uptime, float64(errors)/float64(total)*100)
+	var errorRate float64
+	if total > 0 {
+		errorRate = float64(errors) / float64(total)
+	}
+	logger.Info("Redis stats", "total", total, "errors", errors, "uptime", uptime, "error_rate", errorRate)
+
+	for prefix, ns := range s.NamespaceStats() {
+		logger.Info("Redis namespace stats", "namespace", prefix, "hits", ns.Hits, "misses", ns.Misses,
+			"errors", ns.Errors, "hit_rate", ns.HitRate, "error_rate", ns.ErrorRate)
+	}
+
+	for _, key := range s.TopKeys(5) {
+		if key.Hot {
+			logger.Warn("Redis hot key detected", "key", key.Key, "estimated_count", key.Count,
+				"rate", key.Rate, "suggested_shards", key.Shards)
+		}
+	}
 }
 
 // RedisManager Redis管理器
 type RedisManager struct {
 	config       *RedisConfig
-	client       RedisClient
+	client       atomic.Pointer[clientHolder] // 当前客户端，通过 GetClient/activeClient 读取
 	isHealthy    bool
+	lastHealth   HealthStatus // 最近一次健康检查的结果，由 mu 保护
 	stats        *RedisStats
-	scripts      map[string]string // Lua脚本缓存
+	scripts      map[string]*luaScript // Lua脚本缓存
 	scriptsMutex sync.RWMutex
 	ctx          context.Context    // 默认context
 	cancel       context.CancelFunc // 取消函数
 
+	// 服务端模块探测结果缓存，modulesGen 在清除缓存时递增，moduleLoads 合并并发的探测
+	modules         map[string]bool
+	modulesDetected bool
+	modulesErr      error
+	modulesGen      uint64
+	modulesMutex    sync.Mutex
+	moduleLoads     loadGroup
+
+	// 与服务端实际协商的协议版本（2 或 3），延迟连接模式下建立连接前为 0
+	protocol atomic.Int32
+
+	// 结构化数据编解码器
+	codec      Codec
+	codecs     map[string]Codec // 按名称注册的编解码器，供命名空间配置引用
+	codecMutex sync.RWMutex
+
+	// 各命名空间旧版本数据的解码函数：前缀 -> 版本 -> 解码函数
+	schemaDecoders map[string]map[int]SchemaDecoder
+
+	// 同一进程内的缓存加载合并
+	loads loadGroup
+
+	// 同一进程内的并发读合并（GET、HGETALL）
+	reads         loadGroup
+	coalesceReads atomic.Bool
+
+	// 热点键的本地缓存，未启用时为 nil
+	hotCache *lruCache[string]
+
+	// 命名空间版本号的本地缓存
+	nsVersions namespaceVersions
+
+	// 分布式锁指标
+	lockMetrics lockMetrics
+
+	// 按命令汇总的耗时和错误统计
+	commandMetrics commandMetrics
+
+	// 链路追踪配置
+	tracing      TracingOptions
+	tracingMutex sync.RWMutex
+
+	// 复制延迟监控，未启用时为 nil
+	replication *replicationMonitor
+
+	// 健康状态变化的回调和订阅
+	health healthNotifier
+
+	// 集群拓扑变化的回调
+	topology topologyWatcher
+
+	// 统计快照的接收方，为空时使用 logStats 输出日志
+	statsSinks      []StatsSink
+	logStats        *LogStatsSink
+	statsSinksMutex sync.RWMutex
+
+	// 慢操作日志，未启用时为 nil
+	slowLog *slowLog
+
+	// 命令审计配置
+	audit      AuditOptions
+	auditMutex sync.RWMutex
+
+	// 是否跳过健康状态检查直接执行命令
+	bypassHealthGate atomic.Bool
+
+	// 熔断器，未启用时为 nil
+	breakers *circuitBreakers
+
+	// 封装层重试策略，未启用时为 nil
+	retry *retryPolicy
+
+	// 降级模式的本地快照和写入队列，未启用时为 nil
+	fallback *fallbackStore
+
+	// 自动流水线，未启用时为 nil
+	autoPipeline *autoPipeliner
+
+	// 调试模式，开启时输出每条命令
+	debug atomic.Bool
+
+	// 命令拦截器
+	interceptors      []Interceptor
+	interceptorsMutex sync.RWMutex
+
+	// 双写迁移，未启用时为 nil
+	mirror atomic.Pointer[mirror]
+
+	// 同时执行的命令数上限，未启用时为 nil
+	inflightLimit *inflightLimiter
+
+	// 注册脚本正在后台加载到各节点
+	loadingScripts atomic.Bool
+
+	// 日志输出
+	logger      Logger
+	loggerMutex sync.RWMutex
+
+	// 关闭状态：closing 置位后不再接受新操作，inflight 为正在执行的操作数，loading 为其中正在执行 loader 的缓存加载数，
+	// workers 为 Shutdown 时需要停止的后台任务
+	closing   atomic.Bool
+	inflight  atomic.Int64
+	loading   atomic.Int64
+	closeOnce sync.Once
+	workers   workerSet
+
 	// 健康检查和统计
 	healthTicker *time.Ticker
 	statsTicker  *time.Ticker
+	loops        sync.WaitGroup // 后台循环，Close 时等待其退出
+	reconnect    chan struct{}  // 延迟连接模式下客户端未建立时，通知健康检查循环立即重试
 	done         chan struct{}
 	mu           sync.RWMutex
 }
@@ -171,34 +467,125 @@ func NewRedisManager(config *RedisConfig) (*RedisManager, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	manager := &RedisManager{
-		config:  config,
-		stats:   NewRedisStats(),
-		scripts: make(map[string]string),
-		ctx:     ctx,
-		cancel:  cancel,
-		done:    make(chan struct{}),
+		config:    config,
+		stats:     NewRedisStats(),
+		scripts:   make(map[string]*luaScript),
+		codec:     JSONCodec{},
+		codecs:    map[string]Codec{CodecJSON: JSONCodec{}},
+		ctx:       ctx,
+		cancel:    cancel,
+		reconnect: make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+
+	manager.SetLogger(config.Logger)
+	manager.bypassHealthGate.Store(config.Common.BypassHealthGate)
+	manager.coalesceReads.Store(config.Common.CoalesceReads)
+
+	// 热点键检测
+	if config.HotKeys != nil {
+		manager.stats.hotKeys = newHotKeyTracker(*config.HotKeys)
+		if config.HotKeys.LocalCache {
+			manager.hotCache = newLRUCache[string](config.HotKeys.LocalSize, config.HotKeys.LocalTTL)
+		}
+	}
+
+	// 慢操作日志
+	if config.SlowLog != nil {
+		manager.slowLog = newSlowLog(*config.SlowLog)
+	}
+
+	// 复制延迟监控
+	if config.ReplicationLag != nil {
+		manager.replication = &replicationMonitor{config: *config.ReplicationLag}
+	}
+
+	// 熔断器
+	if config.CircuitBreaker != nil {
+		manager.breakers = &circuitBreakers{config: *config.CircuitBreaker}
+	}
+
+	// 并发限制
+	if config.Common.MaxInflight > 0 {
+		manager.inflightLimit = newInflightLimiter(config.Common.MaxInflight, config.Common.InflightWait)
+	}
+
+	// 重试策略
+	if config.Retry != nil {
+		manager.retry = newRetryPolicy(*config.Retry)
 	}
 
-	// 初始化客户端
-	if err := manager.initClient(); err != nil {
+	// 自动流水线
+	if config.AutoPipeline != nil {
+		manager.autoPipeline = newAutoPipeliner(manager, *config.AutoPipeline)
+	}
+
+	// 降级模式，恢复健康后重放排队的写入（命令钩子在之后第一条成功的命令时也会触发重放）
+	if config.Fallback != nil {
+		manager.fallback = newFallbackStore(*config.Fallback)
+		manager.OnHealthChange(func(healthy bool, err error) {
+			if healthy {
+				go manager.replayWrites()
+			}
+		})
+	}
+
+	// 注册所有Lua脚本，客户端建立后预加载到各节点
+	RegisterAllScripts(manager)
+
+	// Redis 重启或故障转移后脚本缓存可能为空，恢复健康时重新加载
+	manager.OnHealthChange(func(healthy bool, err error) {
+		if healthy {
+			go manager.loadScripts()
+		}
+	})
+
+	// 初始化客户端，延迟连接模式下失败时由健康检查循环重试
+	if err := manager.initClient(); err == nil {
+		// 探测实际使用的协议版本
+		manager.detectProtocol()
+		manager.loadScripts()
+	} else if config.Common.LazyConnect {
+		manager.Logger().Warn("Redis unavailable at startup, will retry in background", "error", err)
+	} else {
 		cancel()
 		return nil, fmt.Errorf("初始化Redis客户端失败: %w", err)
 	}
 
 	// 启动健康检查
-	go manager.startHealthCheck()
+	manager.startHealthCheck()
+
+	// 启动复制延迟监控（如果启用）
+	if manager.replication != nil {
+		manager.goLoop(manager.replicationLagLoop)
+	}
 
 	// 启动统计输出（如果启用）
 	if config.Common.EnableStats {
-		go manager.startStatsOutput()
+		manager.startStatsOutput()
 	}
 
-	// 注册所有Lua脚本
-	RegisterAllScripts(manager)
-
 	return manager, nil
 }
 
+// setClient 设置初始化成功的客户端并标记为健康
+func (rm *RedisManager) setClient(client RedisClient) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.client.Store(&clientHolder{client})
+	rm.isHealthy = true
+}
+
+// connect 延迟连接模式下建立客户端，只在健康检查循环中调用
+func (rm *RedisManager) connect() {
+	if err := rm.initClient(); err != nil {
+		rm.Logger().Debug("Redis connect failed", "error", err)
+		return
+	}
+	rm.detectProtocol()
+	rm.notifyHealthChange(true, nil)
+}
+
 // initClient 初始化Redis客户端
 func (rm *RedisManager) initClient() error {
 	switch rm.config.Mode {
@@ -213,6 +600,81 @@ func (rm *RedisManager) initClient() error {
 	}
 }
 
+// addHooks 为新建的客户端安装命令钩子，先安装的钩子在外层（拦截器和双写在最内层，拦截器拒绝的命令同样计入日志和指标）
+// 集群和 Ring 模式下追踪钩子安装在各节点上，span 记录实际执行命令的节点
+func (rm *RedisManager) addHooks(client interface{ AddHook(redis.Hook) }) {
+	client.AddHook(inflightHook{rm: rm})
+	client.AddHook(logHook{rm: rm})
+	client.AddHook(metricsHook{rm: rm})
+	if c, ok := client.(*redis.Client); ok {
+		addr := ""
+		if rm.config.Mode == ModeSingle {
+			addr = c.Options().Addr
+		}
+		client.AddHook(tracingHook{rm: rm, addr: addr})
+	} else {
+		rm.eachNode(client, func(node *redis.Client) {
+			node.AddHook(tracingHook{rm: rm, addr: node.Options().Addr})
+		})
+	}
+	if rm.inflightLimit != nil {
+		client.AddHook(inflightLimitHook{limiter: rm.inflightLimit})
+	}
+	client.AddHook(slowLogHook{rm: rm})
+	client.AddHook(auditHook{rm: rm})
+	client.AddHook(debugHook{rm: rm})
+	if rm.retry != nil {
+		client.AddHook(retryHook{rm: rm, policy: rm.retry})
+	}
+
+	cluster, isCluster := client.(*redis.ClusterClient)
+	if rm.breakers != nil && !isCluster {
+		client.AddHook(breakerHook{breaker: rm.circuitBreaker("")})
+	}
+	if rm.config.replicaOnly() {
+		client.AddHook(replicaOnlyHook{})
+	}
+	client.AddHook(interceptorHook{rm: rm})
+	if rm.hotCache != nil {
+		client.AddHook(hotKeyEvictHook{rm: rm})
+	}
+	if rm.trackingEnabled() {
+		rm.registerTracking(client)
+	}
+	if rm.fallback != nil {
+		client.AddHook(fallbackHook{rm: rm})
+	}
+	client.AddHook(mirrorHook{rm: rm})
+
+	if isCluster {
+		cluster.OnNewNode(func(node *redis.Client) {
+			node.AddHook(redirectHook{stats: rm.stats, moved: func() { go rm.refreshTopology() }})
+			if rm.breakers != nil {
+				node.AddHook(breakerHook{breaker: rm.circuitBreaker(node.Options().Addr)})
+			}
+			// 新发现的节点（扩容、故障转移后的新主节点）预加载注册的脚本
+			go rm.loadNodeScripts(node)
+		})
+	}
+}
+
+// eachNode 对客户端的每个节点调用 fn，集群和 Ring 模式包括之后新建的节点
+func (rm *RedisManager) eachNode(client interface{}, fn func(node *redis.Client)) {
+	switch c := client.(type) {
+	case *redis.Client:
+		fn(c)
+	case *redis.ClusterClient:
+		c.OnNewNode(fn)
+	case *redis.Ring:
+		// NewRing 时创建的分片不会触发 OnNewNode
+		_ = c.ForEachShard(rm.ctx, func(ctx context.Context, node *redis.Client) error {
+			fn(node)
+			return nil
+		})
+		c.OnNewNode(fn)
+	}
+}
+
 // initSingleClient 初始化单例Redis客户端
 func (rm *RedisManager) initSingleClient() error {
 	opts := &redis.Options{
@@ -228,9 +690,15 @@ func (rm *RedisManager) initSingleClient() error {
 		MaxRetries:      rm.config.Common.MaxRetries,
 		MinRetryBackoff: rm.config.Common.MinRetryBackoff,
 		MaxRetryBackoff: rm.config.Common.MaxRetryBackoff,
+
+		// 协议配置
+		Protocol:      rm.config.Common.Protocol,
+		UnstableResp3: rm.config.Common.UnstableResp3,
 	}
+	opts.OnConnect = rm.withTracking(opts.OnConnect)
 
 	client := redis.NewClient(opts)
+	rm.addHooks(client)
 
 	// 测试连接
 	if err := client.Ping(rm.ctx).Err(); err != nil {
@@ -238,9 +706,8 @@ func (rm *RedisManager) initSingleClient() error {
 		return ErrConnectionFailed.WithError(err)
 	}
 
-	rm.client = client
-	rm.isHealthy = true
-	log.Printf("Redis single client initialized successfully, addr: %s", rm.config.Single.Addr)
+	rm.setClient(client)
+	rm.Logger().Info("Redis single client initialized successfully")
 	return nil
 }
 
@@ -286,9 +753,31 @@ func (rm *RedisManager) initSentinelClient() error {
 		MaxRetries:      rm.config.Common.MaxRetries,
 		MinRetryBackoff: rm.config.Common.MinRetryBackoff,
 		MaxRetryBackoff: rm.config.Common.MaxRetryBackoff,
+
+		// 协议配置
+		Protocol:      rm.config.Common.Protocol,
+		UnstableResp3: rm.config.Common.UnstableResp3,
+	}
+	opts.OnConnect = rm.withTracking(opts.OnConnect)
+
+	// 只读副本模式：连接哨兵发现的从节点，写命令在发送前被拒绝
+	if config.Sentinel.ReplicaOnly {
+		opts.ReplicaOnly = true
+		client := redis.NewFailoverClient(opts)
+		rm.addHooks(client)
+
+		if err := client.Ping(rm.ctx).Err(); err != nil {
+			_ = client.Close()
+			return ErrConnectionFailed.WithError(err)
+		}
+
+		rm.setClient(client)
+		rm.Logger().Info("Redis sentinel replica-only client initialized successfully", "master", config.Sentinel.MasterName)
+		return nil
 	}
 
 	client := redis.NewFailoverClusterClient(opts)
+	rm.addHooks(client)
 
 	// 测试连接
 	if err := client.Ping(rm.ctx).Err(); err != nil {
@@ -296,10 +785,8 @@ func (rm *RedisManager) initSentinelClient() error {
 		return ErrConnectionFailed.WithError(err)
 	}
 
-	rm.client = client
-	rm.isHealthy = true
-	log.Printf("Redis sentinel client initialized successfully, master: %s, sentinels: %s",
-		config.Sentinel.MasterName, strings.Join(config.Sentinel.SentinelAddrs, ","))
+	rm.setClient(client)
+	rm.Logger().Info("Redis sentinel client initialized successfully", "master", config.Sentinel.MasterName)
 	return nil
 }
 
@@ -307,14 +794,7 @@ func (rm *RedisManager) initSentinelClient() error {
 func (rm *RedisManager) initRingClient() error {
 	config := rm.config.MasterSlave
 
-	// 构建Ring配置 - 使用配置中的实际地址
-	addrs := make(map[string]string)
-	for i, addr := range config.Addrs {
-		addrs[fmt.Sprintf("%d", i)] = addr
-	}
-
 	opts := &redis.RingOptions{
-		Addrs:    addrs,
 		Password: config.Password,
 		DB:       config.Database,
 
@@ -328,9 +808,18 @@ func (rm *RedisManager) initRingClient() error {
 		MaxRetries:      rm.config.Common.MaxRetries,
 		MinRetryBackoff: rm.config.Common.MinRetryBackoff,
 		MaxRetryBackoff: rm.config.Common.MaxRetryBackoff,
+
+		// 协议配置
+		Protocol:      rm.config.Common.Protocol,
+		UnstableResp3: rm.config.Common.UnstableResp3,
 	}
 
+	// 分片地址、权重和各分片的认证
+	config.ringOptions(opts)
+	opts.OnConnect = rm.withTracking(opts.OnConnect)
+
 	client := redis.NewRing(opts)
+	rm.addHooks(client)
 
 	// 测试连接
 	if err := client.Ping(rm.ctx).Err(); err != nil {
@@ -338,10 +827,8 @@ func (rm *RedisManager) initRingClient() error {
 		return ErrConnectionFailed.WithError(err)
 	}
 
-	rm.client = client
-	rm.isHealthy = true
-	log.Printf("Redis ring client initialized successfully, addrs: %s",
-		strings.Join(config.Addrs, ","))
+	rm.setClient(client)
+	rm.Logger().Info("Redis ring client initialized successfully")
 	return nil
 }
 
@@ -368,6 +855,10 @@ func (rm *RedisManager) initClusterClient() error {
 		MaxRetries:      rm.config.Common.MaxRetries,
 		MinRetryBackoff: rm.config.Common.MinRetryBackoff,
 		MaxRetryBackoff: rm.config.Common.MaxRetryBackoff,
+
+		// 协议配置
+		Protocol:      rm.config.Common.Protocol,
+		UnstableResp3: rm.config.Common.UnstableResp3,
 	}
 
 	// 设置集群默认值
@@ -375,7 +866,17 @@ func (rm *RedisManager) initClusterClient() error {
 		opts.MaxRedirects = 3
 	}
 
+	// ReadFrom(ReadReplica) 直接在从节点上读取，从节点连接须处于 READONLY 状态，否则返回 MOVED
+	// 未启用 ReadOnly 时 go-redis 不发送 READONLY，在此对每个节点连接发送（对主节点连接没有影响）
+	if !opts.ReadOnly && !opts.RouteByLatency && !opts.RouteRandomly {
+		opts.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+			return cn.ReadOnly(ctx).Err()
+		}
+	}
+	opts.OnConnect = rm.withTracking(opts.OnConnect)
+
 	client := redis.NewClusterClient(opts)
+	rm.addHooks(client)
 
 	// 测试连接
 	if err := client.Ping(rm.ctx).Err(); err != nil {
@@ -383,37 +884,88 @@ func (rm *RedisManager) initClusterClient() error {
 		return ErrConnectionFailed.WithError(err)
 	}
 
-	rm.client = client
-	rm.isHealthy = true
+	rm.setClient(client)
 
-	if rm.config.Cluster.ReadOnly {
-		log.Printf("Redis cluster client initialized successfully, addrs: %s, read_from_replica: enabled",
-			strings.Join(rm.config.Cluster.Addrs, ","))
-	} else {
-		log.Printf("Redis cluster client initialized successfully, addrs: %s",
-			strings.Join(rm.config.Cluster.Addrs, ","))
-	}
+	rm.Logger().Info("Redis cluster client initialized successfully", "read_from_replica", rm.config.Cluster.ReadOnly)
 	return nil
 }
 
+// detectProtocol 探测与服务端实际协商的协议版本
+// 配置为 RESP3 时，不支持 HELLO 命令的服务端（Redis 6.0 以下或部分代理）会由 go-redis 自动回退到 RESP2
+func (rm *RedisManager) detectProtocol() {
+	rm.protocol.Store(int32(rm.negotiatedProtocol()))
+}
+
+// negotiatedProtocol 通过 HELLO 查询当前连接的协议版本
+func (rm *RedisManager) negotiatedProtocol() int {
+	if rm.config.Common.Protocol != 3 {
+		return 2
+	}
+
+	// 不带参数的 HELLO 只返回当前连接信息，不会切换协议
+	info, err := rm.activeClient().Do(rm.ctx, "hello").Result()
+	if err != nil {
+		rm.Logger().Warn("Redis server does not support RESP3, falling back to RESP2", "error", err)
+		return 2
+	}
+
+	switch m := info.(type) {
+	case map[interface{}]interface{}:
+		if proto, ok := m["proto"].(int64); ok {
+			return int(proto)
+		}
+	case []interface{}:
+		for i := 0; i+1 < len(m); i += 2 {
+			if k, ok := m[i].(string); ok && k == "proto" {
+				if proto, ok := m[i+1].(int64); ok {
+					return int(proto)
+				}
+			}
+		}
+	}
+	return 2
+}
+
+// Protocol 返回与服务端实际协商的协议版本（2 或 3），延迟连接模式下尚未建立连接时返回 0
+func (rm *RedisManager) Protocol() int {
+	return int(rm.protocol.Load())
+}
+
 // startHealthCheck 启动健康检查
 func (rm *RedisManager) startHealthCheck() {
 	rm.healthTicker = time.NewTicker(rm.config.Common.HealthCheckInterval)
-	go rm.healthCheckLoop()
+	rm.goLoop(rm.healthCheckLoop)
 }
 
 // startStatsOutput 启动统计信息输出
 func (rm *RedisManager) startStatsOutput() {
 	rm.statsTicker = time.NewTicker(rm.config.Common.StatsInterval)
-	go rm.statsOutputLoop()
+	rm.goLoop(rm.statsOutputLoop)
+}
+
+// goLoop 启动后台循环，Close 时等待其退出
+func (rm *RedisManager) goLoop(loop func()) {
+	rm.loops.Add(1)
+	go func() {
+		defer rm.loops.Done()
+		loop()
+	}()
 }
 
 // healthCheckLoop 健康检查循环
 func (rm *RedisManager) healthCheckLoop() {
+	defer rm.healthTicker.Stop()
+	var lastConnect time.Time
 	for {
 		select {
 		case <-rm.healthTicker.C:
 			rm.performHealthCheck()
+		case <-rm.reconnect:
+			// 由操作触发的重连至少间隔一个拨号超时，避免 Redis 不可用期间连续重试
+			if time.Since(lastConnect) >= rm.config.Common.DialTimeout {
+				lastConnect = time.Now()
+				rm.performHealthCheck()
+			}
 		case <-rm.done:
 			return
 		}
@@ -422,65 +974,72 @@ func (rm *RedisManager) healthCheckLoop() {
 
 // statsOutputLoop 统计信息输出循环
 func (rm *RedisManager) statsOutputLoop() {
+	defer rm.statsTicker.Stop()
 	for {
 		select {
 		case <-rm.statsTicker.C:
-			rm.stats.Proc()
+			rm.emitStats()
 		case <-rm.done:
 			return
 		}
 	}
 }
 
-// performHealthCheck 执行健康检查
+// performHealthCheck 执行健康检查，延迟连接模式下客户端尚未建立时尝试建立连接
 func (rm *RedisManager) performHealthCheck() {
-	rm.mu.Lock()
-	defer rm.mu.Unlock()
+	if rm.config.Common.LazyConnect && rm.GetClient() == nil && !rm.closing.Load() {
+		rm.connect()
+		return
+	}
+	if changed, err := rm.checkHealth(); changed {
+		// 恢复后的服务端可能已重启，重新探测模块
+		if err == nil {
+			rm.invalidateModules()
+		}
+		rm.notifyHealthChange(err == nil, err)
+	}
+	// 没有 MOVED 流量时也能发现故障转移
+	rm.refreshTopology()
+}
 
-	if rm.client == nil {
+// checkHealth 检查连接并更新健康状态，返回状态是否发生变化
+func (rm *RedisManager) checkHealth() (bool, error) {
+	if rm.GetClient() == nil {
+		rm.mu.Lock()
+		defer rm.mu.Unlock()
 		rm.isHealthy = false
-		return
+		return false, nil
 	}
 
-	var err error
-	switch rm.config.Mode {
-	case ModeCluster:
-		// 集群模式：检查集群状态
-		if clusterClient, ok := rm.client.(*redis.ClusterClient); ok {
-			// 检查集群节点状态
-			err = clusterClient.ForEachMaster(rm.ctx, func(ctx context.Context, master *redis.Client) error {
-				return master.Ping(ctx).Err()
-			})
-		} else {
-			err = rm.client.Ping(rm.ctx).Err()
-		}
-	case ModeMasterSlave:
-		// 主从模式：根据是否启用哨兵采用不同检查策略
-		if rm.config.MasterSlave.Sentinel != nil && rm.config.MasterSlave.Sentinel.Enabled {
-			// 哨兵模式：检查故障转移客户端
-			err = rm.client.Ping(rm.ctx).Err()
-		} else {
-			// Ring模式：检查Ring客户端
-			if ringClient, ok := rm.client.(*redis.Ring); ok {
-				err = ringClient.Ping(rm.ctx).Err()
-			} else {
-				err = rm.client.Ping(rm.ctx).Err()
-			}
+	// 检查节点时不持有 rm.mu，避免个别节点响应慢时阻塞 canServe
+	start := time.Now()
+	nodes, err := rm.pingNodes()
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.mergeNodeHistory(nodes, start)
+
+	rm.lastHealth = HealthStatus{LastCheck: start, Latency: time.Since(start), Nodes: nodes}
+	if err != nil {
+		rm.lastHealth.Error = err.Error()
+	}
+	for _, node := range nodes {
+		if !node.Healthy {
+			rm.lastHealth.Degraded = err == nil
+			break
 		}
-	default:
-		// 单例模式：简单ping检查
-		err = rm.client.Ping(rm.ctx).Err()
 	}
 
 	wasHealthy := rm.isHealthy
 	rm.isHealthy = err == nil
 
 	if !rm.isHealthy && wasHealthy {
-		log.Printf("Redis health check failed (mode: %s): %v", rm.config.Mode, err)
+		rm.Logger().Error("Redis health check failed", "error_code", errorCodeOf(err).String(), "error", err)
 		rm.stats.IncrError()
 	} else if rm.isHealthy && !wasHealthy {
-		log.Printf("Redis health check recovered (mode: %s)", rm.config.Mode)
+		rm.Logger().Info("Redis health check recovered")
 	}
+	return rm.isHealthy != wasHealthy, err
 }
 
 // IsHealthy 检查Redis连接是否健康
@@ -490,57 +1049,108 @@ func (rm *RedisManager) IsHealthy() bool {
 	return rm.isHealthy
 }
 
+// SetBypassHealthGate 设置是否跳过健康状态检查，可在运行时切换，初始值取自 Common.BypassHealthGate
+func (rm *RedisManager) SetBypassHealthGate(bypass bool) {
+	rm.bypassHealthGate.Store(bypass)
+}
+
+// canServe 是否执行新操作：默认在健康检查失败期间直接返回 CONNECTION_FAILED；
+// 跳过健康状态检查时只要客户端未关闭就执行命令，由实际错误决定结果，健康状态只用于统计；
+// 启用熔断器时由熔断器代替健康状态决定，集群模式下按节点在命令钩子中判断
+func (rm *RedisManager) canServe() bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	client := rm.GetClient()
+	// 关闭期间仍有缓存加载未完成时继续执行命令，使其能够写回缓存
+	if client == nil || (rm.closing.Load() && rm.loading.Load() == 0) {
+		if client == nil && rm.config.Common.LazyConnect {
+			// 通知健康检查循环立即尝试建立连接
+			select {
+			case rm.reconnect <- struct{}{}:
+			default:
+			}
+		}
+		return false
+	}
+	if rm.breakers != nil {
+		if _, ok := client.(*redis.ClusterClient); ok {
+			return true
+		}
+		return rm.circuitBreaker("").ready()
+	}
+	return rm.isHealthy || rm.bypassHealthGate.Load()
+}
+
 // GetStats 获取统计信息
 func (rm *RedisManager) GetStats() *RedisStats {
 	return rm.stats
 }
 
-// Close 关闭Redis连接和管理器
+// Close 立即关闭Redis连接和管理器，正在执行的操作会失败，需要等待其完成时使用 Shutdown
 func (rm *RedisManager) Close() error {
-	rm.mu.Lock()
-	defer rm.mu.Unlock()
+	rm.closing.Store(true)
 
-	// 停止健康检查和统计输出
-	close(rm.done)
+	// 停止健康检查和统计输出，等待后台循环退出后再关闭客户端
+	rm.closeOnce.Do(func() {
+		close(rm.done)
+	})
+	rm.loops.Wait()
 
-	if rm.healthTicker != nil {
-		rm.healthTicker.Stop()
-	}
-
-	if rm.statsTicker != nil {
-		rm.statsTicker.Stop()
-	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
 
 	// 关闭Redis客户端
-	if rm.client != nil {
-		err := rm.client.Close()
-		rm.client = nil
+	if holder := rm.client.Swap(nil); holder != nil {
+		err := holder.Close()
 		rm.isHealthy = false
-		log.Printf("Redis manager closed")
+		rm.cancel()
+		rm.Logger().Info("Redis manager closed")
 		return err
 	}
 
 	return nil
 }
 
-// GetClient 获取Redis客户端（用于高级操作）
+// GetClient 获取Redis客户端（用于高级操作），尚未建立连接或已关闭时返回 nil
 func (rm *RedisManager) GetClient() RedisClient {
-	rm.mu.RLock()
-	defer rm.mu.RUnlock()
-	return rm.client
+	if holder := rm.client.Load(); holder != nil {
+		return holder.RedisClient
+	}
+	return nil
 }
 
-// RegisterScript 注册Lua脚本
+// clientHolder 以原子指针保存的客户端
+type clientHolder struct {
+	RedisClient
+}
+
+// disconnectedClient 没有可用客户端时使用的客户端，所有命令立即失败（CONNECTION_FAILED），不建立网络连接
+var disconnectedClient RedisClient = redis.NewClient(&redis.Options{
+	Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, ErrConnectionFailed
+	},
+	MaxRetries:    -1,
+	DialerRetries: 1,
+})
+
+// activeClient 返回当前客户端，尚未建立连接（延迟连接模式下启动失败）或已关闭时返回 disconnectedClient，调用方无需判断 nil
+func (rm *RedisManager) activeClient() RedisClient {
+	if client := rm.GetClient(); client != nil {
+		return client
+	}
+	return disconnectedClient
+}
+
+// RegisterScript 注册Lua脚本（不带版本），已注册的同名脚本被替换，见 RegisterScriptVersion
 func (rm *RedisManager) RegisterScript(name, script string) {
-	rm.scriptsMutex.Lock()
-	defer rm.scriptsMutex.Unlock()
-	rm.scripts[name] = script
+	rm.RegisterScriptVersion(name, "", script)
 }
 
 // GetScript 获取注册的Lua脚本
 func (rm *RedisManager) GetScript(name string) (string, bool) {
-	rm.scriptsMutex.RLock()
-	defer rm.scriptsMutex.RUnlock()
-	script, exists := rm.scripts[name]
-	return script, exists
+	script, exists := rm.registeredScript(name)
+	if !exists {
+		return "", false
+	}
+	return script.source, true
 }
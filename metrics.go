@@ -0,0 +1,156 @@
+package redisx
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultLatencyBuckets 命令耗时直方图的默认分桶上界（秒）
+var DefaultLatencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// CommandMetrics 单个命令的耗时和错误统计
+type CommandMetrics struct {
+	Command string
+	Count   int64               // 执行次数
+	Sum     time.Duration       // 累计耗时
+	Bounds  []float64           // 分桶上界（秒），取命令首次出现时的 DefaultLatencyBuckets
+	Buckets []int64             // 与 Bounds 对应的累积计数（耗时 <= 上界的次数）
+	Errors  map[ErrorCode]int64 // 按错误码分类的失败次数（不含键不存在）
+}
+
+// metricShards 每个命令的计数分片数，并发记录时随机选择分片以减少同一缓存行上的原子操作竞争
+const metricShards = 8
+
+// commandShard 一个计数分片，末尾填充避免相邻分片共享缓存行
+type commandShard struct {
+	count   atomic.Int64
+	sum     atomic.Int64 // 累计耗时（纳秒）
+	buckets []atomic.Int64
+	errors  [numErrorCodes]atomic.Int64
+	_       [64]byte
+}
+
+// commandCounters 单个命令的分片计数
+type commandCounters struct {
+	bounds []float64 // 创建时的分桶上界
+	shards [metricShards]commandShard
+}
+
+// newCommandCounters 创建命令计数
+func newCommandCounters() *commandCounters {
+	c := &commandCounters{bounds: append([]float64(nil), DefaultLatencyBuckets...)}
+	for i := range c.shards {
+		c.shards[i].buckets = make([]atomic.Int64, len(c.bounds))
+	}
+	return c
+}
+
+// commandMetrics 按命令名汇总的耗时直方图和错误计数，记录路径无锁
+type commandMetrics struct {
+	commands sync.Map // 命令名 -> *commandCounters
+}
+
+// record 记录一次命令执行
+func (m *commandMetrics) record(name string, latency time.Duration, err error) {
+	v, ok := m.commands.Load(name)
+	if !ok {
+		v, _ = m.commands.LoadOrStore(name, newCommandCounters())
+	}
+	c := v.(*commandCounters)
+
+	shard := &c.shards[rand.Uint32()%metricShards]
+	shard.count.Add(1)
+	shard.sum.Add(int64(latency))
+	seconds := latency.Seconds()
+	for i, bound := range c.bounds {
+		if seconds <= bound {
+			shard.buckets[i].Add(1)
+		}
+	}
+	if code := errorCodeOf(err); code != OK && code != KEY_NOT_FOUND && code >= 0 && code < numErrorCodes {
+		shard.errors[code].Add(1)
+	}
+}
+
+// snapshot 汇总各分片的当前统计，按命令名排序
+func (m *commandMetrics) snapshot() []CommandMetrics {
+	var result []CommandMetrics
+	m.commands.Range(func(k, v interface{}) bool {
+		c := v.(*commandCounters)
+		cm := CommandMetrics{
+			Command: k.(string),
+			Bounds:  c.bounds,
+			Buckets: make([]int64, len(c.bounds)),
+			Errors:  make(map[ErrorCode]int64),
+		}
+		for i := range c.shards {
+			shard := &c.shards[i]
+			cm.Count += shard.count.Load()
+			cm.Sum += time.Duration(shard.sum.Load())
+			for j := range shard.buckets {
+				cm.Buckets[j] += shard.buckets[j].Load()
+			}
+			for code := range shard.errors {
+				if n := shard.errors[code].Load(); n > 0 {
+					cm.Errors[ErrorCode(code)] += n
+				}
+			}
+		}
+		result = append(result, cm)
+		return true
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Command < result[j].Command
+	})
+	return result
+}
+
+// CommandMetrics 获取各命令的耗时和错误统计，流水线整体记为 "pipeline"
+func (rm *RedisManager) CommandMetrics() []CommandMetrics {
+	return rm.commandMetrics.snapshot()
+}
+
+// metricsHook 记录命令耗时和错误的 go-redis 钩子
+type metricsHook struct {
+	rm *RedisManager
+}
+
+// recordErrorClass 按类别计数命令错误，重定向由节点客户端上的 redirectHook 统计
+func (h metricsHook) recordErrorClass(err error) {
+	if class := ClassifyError(err); class != "" && class != ErrorClassRedirect {
+		h.rm.stats.recordErrorClass(class)
+	}
+}
+
+func (h metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.rm.commandMetrics.record(cmd.Name(), time.Since(start), err)
+		h.recordErrorClass(err)
+		return err
+	}
+}
+
+func (h metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.rm.commandMetrics.record("pipeline", time.Since(start), err)
+		for _, cmd := range cmds {
+			h.recordErrorClass(cmd.Err())
+		}
+		return err
+	}
+}
@@ -0,0 +1,44 @@
+package redisx
+
+import (
+	"testing"
+	"time"
+)
+
+// 并发记录路径的开销，go test -bench . -cpu 1,4,16 比较不同并发度下的结果
+
+func BenchmarkIncrTotal(b *testing.B) {
+	s := NewRedisStats()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.IncrTotal()
+		}
+	})
+}
+
+func BenchmarkRecordRead(b *testing.B) {
+	s := NewRedisStats()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.recordRead("user:", OK)
+		}
+	})
+}
+
+func BenchmarkCommandMetricsRecord(b *testing.B) {
+	var m commandMetrics
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.record("get", 300*time.Microsecond, nil)
+		}
+	})
+}
+
+func BenchmarkCommandMetricsRecordError(b *testing.B) {
+	var m commandMetrics
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.record("get", 300*time.Microsecond, ErrOperationTimeout)
+		}
+	})
+}
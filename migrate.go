@@ -0,0 +1,266 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MigrateCheckpoint 迁移进度检查点，Migrate 中断后以此继续
+// 集群和 Ring 模式下按地址顺序逐个扫描节点，Node 之前的节点已全部完成；迁移期间拓扑变化时需从头开始
+type MigrateCheckpoint struct {
+	Node   string `json:"node,omitempty"` // 正在扫描的节点地址
+	Cursor uint64 `json:"cursor"`         // 该节点下一次 SCAN 的游标
+}
+
+// MigrateProgress 迁移进度
+type MigrateProgress struct {
+	Scanned    int64             `json:"scanned"`
+	Migrated   int64             `json:"migrated"`
+	Skipped    int64             `json:"skipped"` // 目标中已存在（未设置 Replace）或扫描后已过期的键
+	Failed     int64             `json:"failed"`
+	Checkpoint MigrateCheckpoint `json:"checkpoint"` // 已完成批次之后的位置，迁移完成后为零值
+	Done       bool              `json:"done"`
+}
+
+// MigrateOptions 键迁移配置
+type MigrateOptions struct {
+	BatchSize  int64                       // 每批 SCAN 的建议数量，默认 100
+	RateLimit  int                         // 每秒最多迁移的键数，<=0 时不限速
+	Replace    bool                        // 覆盖目标中已存在的键，默认跳过
+	Checkpoint MigrateCheckpoint           // 从检查点继续，零值时从头开始
+	OnProgress func(p MigrateProgress)     // 每批完成后调用，可保存 p.Checkpoint 用于中断后继续
+	OnError    func(key string, err error) // 单个键迁移失败时调用，失败的键不影响后续迁移
+}
+
+// setDefaults 设置默认值
+func (o *MigrateOptions) setDefaults() {
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+}
+
+// migrateNode 迁移时扫描的源节点
+type migrateNode struct {
+	addr   string
+	client interface {
+		Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	}
+}
+
+// Migrate 把 src 中匹配 pattern（SCAN MATCH 语法，为空时迁移全部键）的键以 DUMP/RESTORE 复制到 dst，保留剩余过期时间
+// 集群和 Ring 模式下逐个扫描各节点；ctx 结束或扫描失败时返回已完成的进度和错误，可用其中的 Checkpoint 继续
+// 迁移期间 src 仍在写入的键可能迁移的是旧值，可配合 StartMirror 双写保证一致
+func Migrate(ctx context.Context, src, dst *RedisManager, pattern string, opts MigrateOptions) CacheResult[MigrateProgress] {
+	opts.setDefaults()
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	if !src.acquire() {
+		return NewCacheError[MigrateProgress](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer src.release()
+	if !dst.acquire() {
+		return NewCacheError[MigrateProgress](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer dst.release()
+
+	progress := MigrateProgress{Checkpoint: opts.Checkpoint}
+	nodes, err := migrateNodes(ctx, src.activeClient())
+	if err != nil {
+		src.stats.IncrError()
+		return CacheResult[MigrateProgress]{Val: progress, ErrCode: REDIS_INNER_ERROR, Err: err}
+	}
+
+	start := 0
+	if opts.Checkpoint.Node != "" {
+		start = sort.Search(len(nodes), func(i int) bool { return nodes[i].addr >= opts.Checkpoint.Node })
+	}
+
+	for i := start; i < len(nodes); i++ {
+		node := nodes[i]
+		cursor := uint64(0)
+		if node.addr == opts.Checkpoint.Node {
+			cursor = opts.Checkpoint.Cursor
+		}
+
+		for {
+			batchStart := time.Now()
+			keys, next, err := node.client.Scan(ctx, cursor, pattern, opts.BatchSize).Result()
+			if err != nil {
+				return CacheResult[MigrateProgress]{Val: progress, ErrCode: innerErrorCode(err), Err: err}
+			}
+
+			progress.Scanned += int64(len(keys))
+			if err := migrateKeys(ctx, src, dst, keys, &opts, &progress); err != nil {
+				return CacheResult[MigrateProgress]{Val: progress, ErrCode: innerErrorCode(err), Err: err}
+			}
+
+			cursor = next
+			progress.Checkpoint = MigrateCheckpoint{Node: node.addr, Cursor: cursor}
+			if cursor == 0 && i+1 < len(nodes) {
+				progress.Checkpoint = MigrateCheckpoint{Node: nodes[i+1].addr}
+			}
+			if cursor == 0 && i+1 == len(nodes) {
+				progress.Checkpoint = MigrateCheckpoint{}
+				progress.Done = true
+			}
+			if opts.OnProgress != nil {
+				opts.OnProgress(progress)
+			}
+			if cursor == 0 {
+				break
+			}
+
+			if err := migrateThrottle(ctx, opts.RateLimit, len(keys), batchStart); err != nil {
+				return CacheResult[MigrateProgress]{Val: progress, ErrCode: INTERRUPTED, Err: err}
+			}
+		}
+	}
+
+	progress.Done = true
+	return NewCacheResult(progress)
+}
+
+// migrateNodes 按地址排序的源节点：集群为各主节点，Ring 为各分片，其他模式为客户端本身
+// 无法获取集群状态或没有可用节点时返回错误，避免未迁移任何键却报告完成
+func migrateNodes(ctx context.Context, client RedisClient) ([]migrateNode, error) {
+	var mu sync.Mutex
+	var nodes []migrateNode
+	add := func(ctx context.Context, node *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		nodes = append(nodes, migrateNode{addr: node.Options().Addr, client: node})
+		return nil
+	}
+
+	var err error
+	switch c := client.(type) {
+	case *redis.ClusterClient:
+		err = c.ForEachMaster(ctx, add)
+	case *redis.Ring:
+		err = c.ForEachShard(ctx, add)
+	case *redis.Client:
+		err = add(ctx, c)
+	default:
+		nodes = append(nodes, migrateNode{client: client})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrConnectionFailed.WithMessage("no source nodes available for migration")
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].addr < nodes[j].addr
+	})
+	return nodes, nil
+}
+
+// migrateKeys 通过流水线读取一批键的 DUMP 和 PTTL，再通过流水线写入目标
+// 单个键的错误计入 Failed，连接错误等导致整批失败时返回错误
+func migrateKeys(ctx context.Context, src, dst *RedisManager, keys []string, opts *MigrateOptions, progress *MigrateProgress) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fail := func(key string, err error) {
+		progress.Failed++
+		if opts.OnError != nil {
+			opts.OnError(key, err)
+		}
+	}
+
+	readPipe := src.activeClient().Pipeline()
+	dumps := make([]*redis.StringCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		dumps[i] = readPipe.Dump(ctx, key)
+		ttls[i] = readPipe.PTTL(ctx, key)
+	}
+	var redisErr redis.Error
+	if _, err := readPipe.Exec(ctx); err != nil && !errors.As(err, &redisErr) {
+		return err
+	}
+
+	writePipe := dst.activeClient().Pipeline()
+	restores := make([]*redis.StatusCmd, len(keys))
+	for i, key := range keys {
+		data, err := dumps[i].Result()
+		if errors.Is(err, redis.Nil) {
+			// 扫描后已过期或被删除
+			progress.Skipped++
+			continue
+		} else if err != nil {
+			fail(key, err)
+			continue
+		}
+
+		ttl, err := ttls[i].Result()
+		if err != nil {
+			fail(key, err)
+			continue
+		}
+		if ttl == -2 {
+			progress.Skipped++
+			continue
+		}
+		if ttl < 0 {
+			ttl = 0
+		}
+
+		if opts.Replace {
+			restores[i] = writePipe.RestoreReplace(ctx, key, ttl, data)
+		} else {
+			restores[i] = writePipe.Restore(ctx, key, ttl, data)
+		}
+	}
+	if writePipe.Len() == 0 {
+		return nil
+	}
+	if _, err := writePipe.Exec(ctx); err != nil && !errors.As(err, &redisErr) {
+		return err
+	}
+
+	for i, cmd := range restores {
+		if cmd == nil {
+			continue
+		}
+		switch err := cmd.Err(); {
+		case err == nil:
+			progress.Migrated++
+		case redis.HasErrorPrefix(err, "BUSYKEY"):
+			progress.Skipped++
+		default:
+			fail(keys[i], err)
+		}
+	}
+	return nil
+}
+
+// migrateThrottle 按每秒键数限速，等待到本批次应占用的时间结束
+func migrateThrottle(ctx context.Context, rate, keys int, batchStart time.Time) error {
+	if rate <= 0 || keys == 0 {
+		return ctx.Err()
+	}
+
+	wait := time.Duration(keys)*time.Second/time.Duration(rate) - time.Since(batchStart)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
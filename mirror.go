@@ -0,0 +1,470 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 双写模式
+const (
+	MirrorSync  = "sync"  // 命令在源 Redis 执行成功后同步写入目标，调用方等待两边完成
+	MirrorAsync = "async" // 命令在源 Redis 执行成功后立即返回，由后台 goroutine 按顺序写入目标
+)
+
+// mirrorPollInterval 切换前等待异步队列清空的检查间隔
+const mirrorPollInterval = 10 * time.Millisecond
+
+// mirrorCommands 可以在目标上原样重放的写命令
+var mirrorCommands = map[string]bool{
+	"set": true, "setex": true, "psetex": true, "setnx": true, "getset": true, "getdel": true, "getex": true,
+	"mset": true, "msetnx": true, "append": true, "setrange": true, "setbit": true, "bitfield": true,
+	"incr": true, "incrby": true, "incrbyfloat": true, "decr": true, "decrby": true,
+	"del": true, "unlink": true, "expire": true, "pexpire": true, "expireat": true, "pexpireat": true, "persist": true,
+	"rename": true, "renamenx": true, "copy": true, "restore": true,
+	"hset": true, "hsetnx": true, "hmset": true, "hdel": true, "hincrby": true, "hincrbyfloat": true,
+	"lpush": true, "rpush": true, "lpushx": true, "rpushx": true, "lpop": true, "rpop": true,
+	"lset": true, "lrem": true, "ltrim": true, "linsert": true, "lmove": true, "rpoplpush": true,
+	"sadd": true, "srem": true, "smove": true,
+	"sinterstore": true, "sunionstore": true, "sdiffstore": true,
+	"zadd": true, "zrem": true, "zincrby": true, "zpopmin": true, "zpopmax": true,
+	"zremrangebyscore": true, "zremrangebyrank": true, "zremrangebylex": true,
+	"pfadd": true, "pfmerge": true, "geoadd": true,
+	"xdel": true,
+	// xadd、xtrim 由 mirrorStreamArgs 处理
+}
+
+// mirrorReconcileCommands 无法原样重放的写命令（脚本、阻塞弹出），执行后把涉及的键从源 Redis 复制到目标
+var mirrorReconcileCommands = map[string]bool{
+	"eval": true, "evalsha": true, "fcall": true,
+	"blpop": true, "brpop": true, "blmove": true, "brpoplpush": true, "bzpopmin": true, "bzpopmax": true,
+	"spop": true, // 随机弹出，两边弹出的成员可能不同
+}
+
+// MirrorOptions 双写迁移配置
+type MirrorOptions struct {
+	Target    *RedisManager // 迁移目标
+	Mode      string        // MirrorSync 或 MirrorAsync，默认 MirrorAsync
+	QueueSize int           // 异步模式的队列长度，默认 10000，队列满时命令涉及的键进入对账队列
+}
+
+// MirrorStatus 双写迁移状态
+type MirrorStatus struct {
+	Active   bool   `json:"active"`
+	Mode     string `json:"mode,omitempty"`
+	CutOver  bool   `json:"cut_over"` // 已切换到目标
+	Mirrored int64  `json:"mirrored"` // 已写入目标的命令数
+	Failed   int64  `json:"failed"`   // 写入目标失败的命令数，涉及的键已进入对账队列
+	Queued   int    `json:"queued"`   // 异步队列中等待写入目标的任务数
+	Pending  int    `json:"pending"`  // 等待对账的键数
+}
+
+// mirrorTask 异步写入目标的任务：重放的命令，或需要从源 Redis 复制的键
+type mirrorTask struct {
+	cmds []redis.Cmder
+	keys []string
+}
+
+// mirror 双写迁移
+type mirror struct {
+	source  *RedisManager
+	target  *RedisManager
+	mode    string
+	cutover atomic.Bool
+	queue   chan mirrorTask
+	active  atomic.Int64 // 正在写入目标的任务数
+	stop    chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]struct{} // 等待对账的键
+
+	mirrored atomic.Int64
+	failed   atomic.Int64
+}
+
+// StartMirror 开始双写迁移：此后在本管理器上执行成功的写命令同时写入 opts.Target，读操作仍在本管理器的 Redis 上执行
+// 脚本和阻塞弹出等无法原样重放的命令，以及写入目标失败的命令，涉及的键进入对账队列，通过 ReconcileMirror 从源 Redis 复制到目标
+// 开始双写之前已存在的数据需另行迁移（如 Migrate），迁移完成后调用 Cutover 切换到目标
+func (rm *RedisManager) StartMirror(opts MirrorOptions) error {
+	if opts.Target == nil || opts.Target == rm {
+		return ErrInvalidOperation.WithMessage("mirror target must be another manager")
+	}
+	if opts.Mode == "" {
+		opts.Mode = MirrorAsync
+	}
+	if opts.Mode != MirrorSync && opts.Mode != MirrorAsync {
+		return ErrInvalidOperation.WithMessage("unsupported mirror mode: " + opts.Mode)
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 10000
+	}
+	if rm.closing.Load() {
+		return ErrConnectionFailed.WithMessage("redis manager is closed")
+	}
+
+	m := &mirror{
+		source:  rm,
+		target:  opts.Target,
+		mode:    opts.Mode,
+		stop:    make(chan struct{}),
+		pending: make(map[string]struct{}),
+	}
+	if m.mode == MirrorAsync {
+		m.queue = make(chan mirrorTask, opts.QueueSize)
+	}
+	if !rm.mirror.CompareAndSwap(nil, m) {
+		return ErrInvalidOperation.WithMessage("mirror already started")
+	}
+	if m.queue != nil {
+		rm.goLoop(m.loop)
+	}
+
+	rm.Logger().Info("Redis mirror started", "mode", m.mode)
+	return nil
+}
+
+// StopMirror 停止双写，异步队列中尚未写入目标的任务被丢弃；已切换到目标时命令重新在本管理器的 Redis 上执行
+func (rm *RedisManager) StopMirror() {
+	if m := rm.mirror.Swap(nil); m != nil {
+		close(m.stop)
+		rm.Logger().Info("Redis mirror stopped", "dropped", len(m.queue), "pending", m.pendingCount())
+	}
+}
+
+// Cutover 切换到迁移目标：等待异步队列清空并完成对账后，本管理器上的所有命令（包括读）改为在目标上执行
+// 切换后不再写入原 Redis；等待期间仍有写入时，切换瞬间写入的命令可能只存在于原 Redis，建议在写入低峰或暂停写入时切换
+func (rm *RedisManager) Cutover(ctx context.Context) error {
+	m := rm.mirror.Load()
+	if m == nil {
+		return ErrInvalidOperation.WithMessage("mirror not started")
+	}
+
+	ticker := time.NewTicker(mirrorPollInterval)
+	defer ticker.Stop()
+	for len(m.queue) > 0 || m.active.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if res := rm.ReconcileMirror(); res.Err != nil {
+		return res.Err
+	}
+	m.cutover.Store(true)
+	rm.Logger().Info("Redis mirror cut over to target")
+	return nil
+}
+
+// ReconcileMirror 把对账队列中的键从本管理器的 Redis 复制到迁移目标（DUMP/RESTORE，保留过期时间，源中不存在的键在目标中删除），返回完成对账的键数
+// 复制失败的键保留在队列中，结果为 REDIS_INNER_ERROR
+func (rm *RedisManager) ReconcileMirror() CacheResult[int64] {
+	m := rm.mirror.Load()
+	if m == nil {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation.WithMessage("mirror not started"))
+	}
+
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	m.mu.Lock()
+	keys := make([]string, 0, len(m.pending))
+	for key := range m.pending {
+		keys = append(keys, key)
+	}
+	m.mu.Unlock()
+
+	var reconciled int64
+	var firstErr error
+	for _, key := range keys {
+		if err := m.copyKey(rm.ctx, key); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		m.mu.Lock()
+		delete(m.pending, key)
+		m.mu.Unlock()
+		reconciled++
+	}
+
+	if firstErr != nil {
+		rm.stats.IncrError()
+		return CacheResult[int64]{Val: reconciled, ErrCode: innerErrorCode(firstErr), Err: firstErr}
+	}
+	return NewCacheResult(reconciled)
+}
+
+// MirrorStatus 返回双写迁移状态
+func (rm *RedisManager) MirrorStatus() MirrorStatus {
+	m := rm.mirror.Load()
+	if m == nil {
+		return MirrorStatus{}
+	}
+	return MirrorStatus{
+		Active:   true,
+		Mode:     m.mode,
+		CutOver:  m.cutover.Load(),
+		Mirrored: m.mirrored.Load(),
+		Failed:   m.failed.Load(),
+		Queued:   len(m.queue),
+		Pending:  m.pendingCount(),
+	}
+}
+
+// pendingCount 等待对账的键数
+func (m *mirror) pendingCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.pending)
+}
+
+// addPending 把键加入对账队列
+func (m *mirror) addPending(keys []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		m.pending[key] = struct{}{}
+	}
+}
+
+// capture 收集源 Redis 上执行成功、需要写入目标的命令，返回重放任务
+func (m *mirror) capture(cmds []redis.Cmder) mirrorTask {
+	var task mirrorTask
+	for _, cmd := range cmds {
+		if cmd.Err() != nil && !errors.Is(cmd.Err(), redis.Nil) {
+			continue
+		}
+		name := cmd.Name()
+		switch {
+		case mirrorReconcileCommands[name]:
+			task.keys = append(task.keys, commandKeys(cmd)...)
+		case name == "xadd" || name == "xtrim":
+			if args := mirrorStreamArgs(cmd); args != nil {
+				task.cmds = append(task.cmds, redis.NewCmd(context.Background(), args...))
+			} else {
+				task.keys = append(task.keys, commandKeys(cmd)...)
+			}
+		case mirrorCommands[name]:
+			task.cmds = append(task.cmds, redis.NewCmd(context.Background(), cmd.Args()...))
+		}
+	}
+	return task
+}
+
+// mirrorStreamArgs XADD/XTRIM 在目标上重放的参数：XADD 的自动 ID（*）替换为源 Redis 返回的 ID，使两边的消息 ID 一致
+// 近似裁剪（~）删除的消息取决于各自的内部节点结构，两边结果可能不同，返回 nil 改为从源 Redis 复制整个流
+func mirrorStreamArgs(cmd redis.Cmder) []interface{} {
+	args := slices.Clone(cmd.Args())
+	for i := 2; i < len(args); i++ {
+		switch strings.ToLower(fmt.Sprint(args[i])) {
+		case "nomkstream":
+		case "maxlen", "minid":
+			if i+1 < len(args) {
+				switch fmt.Sprint(args[i+1]) {
+				case "~":
+					return nil
+				case "=":
+					i++
+				}
+			}
+			i++ // 裁剪阈值
+		case "limit":
+			return nil
+		default:
+			if cmd.Name() == "xtrim" {
+				return args
+			}
+			id := mirrorStreamID(cmd)
+			if id == "" {
+				return nil
+			}
+			args[i] = id
+			return args
+		}
+	}
+	if cmd.Name() == "xtrim" {
+		return args
+	}
+	return nil
+}
+
+// mirrorStreamID 源 Redis 上 XADD 返回的消息 ID
+func mirrorStreamID(cmd redis.Cmder) string {
+	switch cmd := cmd.(type) {
+	case *redis.StringCmd:
+		return cmd.Val()
+	case *redis.Cmd:
+		id, _ := cmd.Text()
+		return id
+	}
+	return ""
+}
+
+// dispatch 同步模式下立即写入目标，异步模式下加入队列，队列满时涉及的键进入对账队列
+func (m *mirror) dispatch(ctx context.Context, task mirrorTask) {
+	if len(task.cmds) == 0 && len(task.keys) == 0 {
+		return
+	}
+	if m.queue == nil {
+		m.apply(ctx, task)
+		return
+	}
+
+	select {
+	case m.queue <- task:
+	default:
+		m.failed.Add(int64(len(task.cmds)))
+		m.addPending(task.keys)
+		for _, cmd := range task.cmds {
+			m.addPending(commandKeys(cmd))
+		}
+	}
+}
+
+// loop 异步模式下按顺序把队列中的任务写入目标
+func (m *mirror) loop() {
+	for {
+		select {
+		case task := <-m.queue:
+			m.active.Add(1)
+			m.apply(m.source.ctx, task)
+			m.active.Add(-1)
+		case <-m.stop:
+			return
+		case <-m.source.done:
+			return
+		}
+	}
+}
+
+// apply 在目标上重放命令并复制需要对账的键，失败的键进入对账队列
+func (m *mirror) apply(ctx context.Context, task mirrorTask) {
+	client := m.target.GetClient()
+
+	for _, cmd := range task.cmds {
+		if client == nil {
+			m.failed.Add(1)
+			m.addPending(commandKeys(cmd))
+			continue
+		}
+		if err := client.Process(ctx, cmd); err != nil && !errors.Is(err, redis.Nil) {
+			m.failed.Add(1)
+			m.addPending(commandKeys(cmd))
+			m.source.Logger().Debug("Redis mirror write failed", "command", cmd.Name(), "error", err)
+			continue
+		}
+		m.mirrored.Add(1)
+	}
+
+	for _, key := range task.keys {
+		if err := m.copyKey(ctx, key); err != nil {
+			m.addPending([]string{key})
+			m.source.Logger().Debug("Redis mirror reconcile failed", "key", key, "error", err)
+		}
+	}
+}
+
+// copyKey 以 DUMP/RESTORE 把键从源 Redis 复制到目标，保留剩余过期时间；源中不存在时在目标中删除
+func (m *mirror) copyKey(ctx context.Context, key string) error {
+	source, target := m.source.GetClient(), m.target.GetClient()
+	if source == nil || target == nil {
+		return ErrConnectionFailed
+	}
+
+	data, err := source.Dump(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return target.Del(ctx, key).Err()
+	} else if err != nil {
+		return err
+	}
+
+	ttl, err := source.PTTL(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	return target.RestoreReplace(ctx, key, ttl, data).Err()
+}
+
+// forward 切换后在目标上执行命令
+func (m *mirror) forward(ctx context.Context, cmds []redis.Cmder) error {
+	client := m.target.GetClient()
+	if client == nil {
+		for _, cmd := range cmds {
+			cmd.SetErr(ErrConnectionFailed)
+		}
+		return ErrConnectionFailed
+	}
+	if len(cmds) == 1 {
+		return client.Process(ctx, cmds[0])
+	}
+
+	// 事务流水线由 MULTI/EXEC 包裹，去掉后在目标上以事务流水线执行
+	pipe := client.Pipeline()
+	if len(cmds) >= 2 && cmds[0].Name() == "multi" && cmds[len(cmds)-1].Name() == "exec" {
+		pipe = client.TxPipeline()
+		cmds = cmds[1 : len(cmds)-1]
+	}
+	for _, cmd := range cmds {
+		_ = pipe.Process(ctx, cmd)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// mirrorHook 双写迁移的 go-redis 钩子，安装在最内层：只有通过拦截器并执行成功的命令才写入目标
+type mirrorHook struct {
+	rm *RedisManager
+}
+
+func (h mirrorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h mirrorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		m := h.rm.mirror.Load()
+		if m == nil {
+			return next(ctx, cmd)
+		}
+		if m.cutover.Load() {
+			return m.forward(ctx, []redis.Cmder{cmd})
+		}
+
+		err := next(ctx, cmd)
+		m.dispatch(ctx, m.capture([]redis.Cmder{cmd}))
+		return err
+	}
+}
+
+func (h mirrorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		m := h.rm.mirror.Load()
+		if m == nil {
+			return next(ctx, cmds)
+		}
+		if m.cutover.Load() {
+			return m.forward(ctx, cmds)
+		}
+
+		err := next(ctx, cmds)
+		m.dispatch(ctx, m.capture(cmds))
+		return err
+	}
+}
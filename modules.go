@@ -0,0 +1,119 @@
+package redisx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 常用 Redis 模块在 MODULE LIST 中的名称
+const (
+	// ModuleBloom RedisBloom 模块（BF/CF/TOPK/CMS 命令）
+	ModuleBloom = "bf"
+	// ModuleSearch RediSearch 模块（FT 命令）
+	ModuleSearch = "search"
+)
+
+// HasModule 检查服务端是否加载了指定模块（结果会被缓存，健康检查恢复或检测到集群拓扑变化后重新探测）
+func (rm *RedisManager) HasModule(name string) CacheResult[bool] {
+	modules, err := rm.detectModules()
+	if err != nil {
+		return NewCacheError[bool](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(modules[strings.ToLower(name)])
+}
+
+// detectModules 获取缓存的模块探测结果，尚未探测时执行 MODULE LIST
+// 并发调用共享同一次探测，执行期间不持有 modulesMutex
+func (rm *RedisManager) detectModules() (map[string]bool, error) {
+	rm.modulesMutex.Lock()
+	if rm.modulesDetected {
+		modules, err := rm.modules, rm.modulesErr
+		rm.modulesMutex.Unlock()
+		return modules, err
+	}
+	gen := rm.modulesGen
+	rm.modulesMutex.Unlock()
+
+	val, err := rm.moduleLoads.do("module list", func() (interface{}, error) {
+		modules, err := rm.listModules()
+		// 服务端明确拒绝 MODULE LIST（如托管服务禁用）时缓存该结果，避免每次调用都重试
+		var redisErr redis.Error
+		if err != nil && !errors.As(err, &redisErr) {
+			return nil, err
+		}
+
+		rm.modulesMutex.Lock()
+		// 探测期间缓存已被清除时丢弃本次结果，下次调用重新探测
+		if rm.modulesGen == gen {
+			rm.modules, rm.modulesErr, rm.modulesDetected = modules, err, true
+		}
+		rm.modulesMutex.Unlock()
+		return modules, err
+	})
+	modules, _ := val.(map[string]bool)
+	return modules, err
+}
+
+// invalidateModules 清除模块探测结果，重连后的服务端或拓扑变化后的节点可能加载了不同的模块
+func (rm *RedisManager) invalidateModules() {
+	rm.modulesMutex.Lock()
+	rm.modules, rm.modulesErr, rm.modulesDetected = nil, nil, false
+	rm.modulesGen++
+	rm.modulesMutex.Unlock()
+}
+
+// listModules 通过 MODULE LIST 获取已加载的模块
+func (rm *RedisManager) listModules() (map[string]bool, error) {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return nil, ErrConnectionFailed
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().Do(rm.ctx, "module", "list").Slice()
+	if err != nil {
+		rm.stats.IncrError()
+		return nil, err
+	}
+
+	modules := make(map[string]bool, len(val))
+	for _, entry := range val {
+		// RESP2 返回 ["name", "bf", "ver", 20612, ...]，RESP3 返回 map
+		switch m := entry.(type) {
+		case []interface{}:
+			for i := 0; i+1 < len(m); i += 2 {
+				if k, ok := m[i].(string); ok && k == "name" {
+					modules[strings.ToLower(fmt.Sprint(m[i+1]))] = true
+				}
+			}
+		case map[interface{}]interface{}:
+			if name, ok := m["name"]; ok {
+				modules[strings.ToLower(fmt.Sprint(name))] = true
+			}
+		}
+	}
+
+	return modules, nil
+}
+
+// requireModule 在执行模块命令前检查模块是否可用
+// 无法执行 MODULE LIST（如托管服务禁用了该命令）时不做拦截，交由命令本身的错误判断
+func (rm *RedisManager) requireModule(name string) *RedisError {
+	result := rm.HasModule(name)
+	if result.IsOK() && !result.Val {
+		return ErrModuleNotLoaded.WithMessage("module not loaded: " + name)
+	}
+	return nil
+}
+
+// moduleErrorCode 将模块命令的错误映射为错误码，未知命令说明模块不存在
+func moduleErrorCode(err error) ErrorCode {
+	if strings.HasPrefix(strings.ToLower(err.Error()), "err unknown command") {
+		return MODULE_NOT_LOADED
+	}
+	return REDIS_INNER_ERROR
+}
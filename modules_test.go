@@ -0,0 +1,62 @@
+package redisx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// MODULE LIST 执行期间不持有 modulesMutex，并发调用只发送一次；期间清除缓存时结果不被缓存
+func TestHasModuleConcurrentDetect(t *testing.T) {
+	var lists atomic.Int64
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	s := newFakeServer(t, func(args []string) string {
+		if args[0] != "module" {
+			return ""
+		}
+		lists.Add(1)
+		received <- struct{}{}
+		<-release
+		return "*1\r\n*4\r\n$4\r\nname\r\n$2\r\nbf\r\n$3\r\nver\r\n:20612\r\n"
+	})
+	rm := newTestManager(t, s.addr, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res := rm.HasModule(ModuleBloom); !res.IsOK() || !res.Val {
+				t.Errorf("HasModule = %v, %v, want true", res.Val, res.Err)
+			}
+		}()
+	}
+	<-received
+	time.Sleep(20 * time.Millisecond)
+
+	invalidated := make(chan struct{})
+	go func() {
+		rm.invalidateModules()
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("invalidateModules blocked by MODULE LIST")
+	}
+
+	close(release)
+	wg.Wait()
+	if n := lists.Load(); n != 1 {
+		t.Fatalf("MODULE LIST sent %d times, want 1", n)
+	}
+
+	if res := rm.HasModule(ModuleBloom); !res.IsOK() || !res.Val {
+		t.Fatalf("HasModule = %v, %v, want true", res.Val, res.Err)
+	}
+	if n := lists.Load(); n != 2 {
+		t.Fatalf("MODULE LIST sent %d times after invalidation, want 2", n)
+	}
+}
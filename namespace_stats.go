@@ -0,0 +1,62 @@
+package redisx
+
+import "sync/atomic"
+
+// NamespaceStats 命名空间的读取统计
+type NamespaceStats struct {
+	Hits      int64
+	Misses    int64
+	Errors    int64
+	HitRate   float64 // Hits / (Hits + Misses)
+	ErrorRate float64 // Errors / (Hits + Misses + Errors)
+}
+
+// namespaceCounters 命名空间的读取计数
+type namespaceCounters struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+// recordRead 记录一次命名空间内的读取结果
+func (s *RedisStats) recordRead(prefix string, code ErrorCode) {
+	v, ok := s.namespaces.Load(prefix)
+	if !ok {
+		v, _ = s.namespaces.LoadOrStore(prefix, &namespaceCounters{})
+	}
+	c := v.(*namespaceCounters)
+
+	switch code {
+	case OK:
+		c.hits.Add(1)
+	case KEY_NOT_FOUND:
+		c.misses.Add(1)
+	default:
+		c.errors.Add(1)
+	}
+}
+
+// NamespaceStats 获取各命名空间（按配置的前缀）的读取统计，只包含发生过读取的命名空间
+func (s *RedisStats) NamespaceStats() map[string]NamespaceStats {
+	stats := make(map[string]NamespaceStats)
+	s.namespaces.Range(func(k, v interface{}) bool {
+		c := v.(*namespaceCounters)
+		ns := NamespaceStats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+		if lookups := ns.Hits + ns.Misses; lookups > 0 {
+			ns.HitRate = float64(ns.Hits) / float64(lookups)
+		}
+		if total := ns.Hits + ns.Misses + ns.Errors; total > 0 {
+			ns.ErrorRate = float64(ns.Errors) / float64(total)
+		}
+		stats[k.(string)] = ns
+		return true
+	})
+	return stats
+}
+
+// recordNamespaceRead 键属于已配置的命名空间时记录读取结果
+func (rm *RedisManager) recordNamespaceRead(key string, code ErrorCode) {
+	if ns := rm.namespaceFor(key); ns != nil {
+		rm.stats.recordRead(ns.Prefix, code)
+	}
+}
@@ -3,6 +3,8 @@ package redisx
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -27,33 +29,53 @@ const (
 func (rm *RedisManager) get(codecType CodecType, key string) interface{} {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		if codecType == StringType {
 			return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 		}
 		return NewCacheError[[]byte](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
+
+	// 热点键优先读取本地缓存
+	hot := rm.stats.recordAccess(key) && rm.hotCache != nil
+	if hot {
+		if cached, ok := rm.hotCache.get(key); ok {
+			if codecType == StringType {
+				return NewCacheResult(cached)
+			}
+			return NewCacheResult([]byte(cached))
+		}
+	}
 
 	var val interface{}
 	var err error
 	switch codecType {
 	case StringType:
-		val, err = rm.client.Get(rm.ctx, key).Result()
+		val, err = rm.getString(key)
 		if errors.Is(err, redis.Nil) {
 			return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
 		} else if err != nil {
 			rm.stats.IncrError()
 			return NewCacheError[string](REDIS_INNER_ERROR, err)
 		}
+		if hot {
+			rm.hotCache.set(key, val.(string))
+		}
 		return NewCacheResult(val.(string))
 	case ByteArrayType:
-		val, err = rm.client.Get(rm.ctx, key).Bytes()
+		var s string
+		s, err = rm.getString(key)
+		val = []byte(s)
 		if errors.Is(err, redis.Nil) {
 			return NewCacheError[[]byte](KEY_NOT_FOUND, ErrKeyNotFound)
 		} else if err != nil {
 			rm.stats.IncrError()
 			return NewCacheError[[]byte](REDIS_INNER_ERROR, err)
 		}
+		if hot {
+			rm.hotCache.set(key, s)
+		}
 		return NewCacheResult(val.([]byte))
 	}
 
@@ -62,30 +84,69 @@ func (rm *RedisManager) get(codecType CodecType, key string) interface{} {
 
 }
 
-// GetS 获取字符串值
+// GetS 获取字符串值，启用降级模式时 Redis 不可达则返回本地快照中的值（Stale 为 true）
 func (rm *RedisManager) GetS(key string) CacheResult[string] {
-	return rm.get(StringType, key).(CacheResult[string])
+	res := rm.get(StringType, key).(CacheResult[string])
+	rm.recordNamespaceRead(key, res.ErrCode)
+	switch res.ErrCode {
+	case OK:
+		rm.rememberValue(key, res.Val)
+	case KEY_NOT_FOUND:
+		rm.forgetValues(key)
+	default:
+		if val, ok := rm.staleValue(key, res.Err); ok {
+			return CacheResult[string]{Val: val, ErrCode: OK, Stale: true}
+		}
+	}
+	return res
 }
 
-// GetB 获取字节数组值
+// GetB 获取字节数组值，启用降级模式时 Redis 不可达则返回本地快照中的值（Stale 为 true）
 func (rm *RedisManager) GetB(key string) CacheResult[[]byte] {
-	return rm.get(ByteArrayType, key).(CacheResult[[]byte])
+	res := rm.get(ByteArrayType, key).(CacheResult[[]byte])
+	rm.recordNamespaceRead(key, res.ErrCode)
+	switch res.ErrCode {
+	case OK:
+		rm.rememberValue(key, string(res.Val))
+	case KEY_NOT_FOUND:
+		rm.forgetValues(key)
+	default:
+		if val, ok := rm.staleValue(key, res.Err); ok {
+			return CacheResult[[]byte]{Val: []byte(val), ErrCode: OK, Stale: true}
+		}
+	}
+	return res
 }
 
-// set 内部方法：设置值（支持字符串和字节数组）
+// set 内部方法：设置值（支持字符串和字节数组），过期时间按配置的抖动比例随机调整
 func (rm *RedisManager) set(codecType CodecType, key string, value interface{}, expiration time.Duration) CacheResult[string] {
+	return rm.setTTL(key, value, expiration, rm.jitterTTL(key, expiration))
+}
+
+// setTTL 内部方法：以已调整的过期时间 ttl 设置值，降级排队时记录调用方的 expiration
+func (rm *RedisManager) setTTL(key string, value interface{}, expiration, ttl time.Duration) CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
-		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	if !rm.acquire() {
+		if rm.queueSet(key, value, expiration, ErrConnectionFailed) {
+			return CacheResult[string]{Val: "OK", ErrCode: OK, Stale: true}
+		}
+		return NewCacheError[string](CONNECTION_FAILED, rm.unavailableError())
 	}
+	defer rm.release()
 
-	val, err := rm.client.Set(rm.ctx, key, value, expiration).Result()
+	cmd := setCmd(rm.ctx, key, value, ttl)
+	rm.process(cmd)
+	val, err := cmd.Result()
 	if err != nil {
 		rm.stats.IncrError()
+		if rm.queueSet(key, value, expiration, err) {
+			return CacheResult[string]{Val: "OK", ErrCode: OK, Stale: true}
+		}
 		return NewCacheError[string](REDIS_INNER_ERROR, err)
 	}
 
+	rm.rememberValue(key, fallbackString(value))
 	return NewCacheResult(val)
 }
 
@@ -103,11 +164,12 @@ func (rm *RedisManager) SetB(key string, value []byte, expiration time.Duration)
 func (rm *RedisManager) SetNX(key string, value string, expiration time.Duration) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SetNX(rm.ctx, key, value, expiration).Result()
+	val, err := rm.activeClient().SetNX(rm.ctx, key, value, expiration).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -120,11 +182,12 @@ func (rm *RedisManager) SetNX(key string, value string, expiration time.Duration
 func (rm *RedisManager) GetSet(key string, value string) CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.GetSet(rm.ctx, key, value).Result()
+	val, err := rm.activeClient().GetSet(rm.ctx, key, value).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
@@ -140,7 +203,7 @@ func (rm *RedisManager) GetSet(key string, value string) CacheResult[string] {
 func (rm *RedisManager) mget(codecType CodecType, keys ...string) interface{} {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		switch codecType {
 		case StringType:
 			return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
@@ -149,10 +212,18 @@ func (rm *RedisManager) mget(codecType CodecType, keys ...string) interface{} {
 		}
 		return NewCacheError[interface{}](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
+
+	for _, key := range keys {
+		rm.stats.recordAccess(key)
+	}
 
-	val, err := rm.client.MGet(rm.ctx, keys...).Result()
+	val, err := rm.mgetValues(rm.ctx, keys)
 	if err != nil {
 		rm.stats.IncrError()
+		for _, key := range keys {
+			rm.recordNamespaceRead(key, REDIS_INNER_ERROR)
+		}
 		switch codecType {
 		case StringType:
 			return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -162,6 +233,14 @@ func (rm *RedisManager) mget(codecType CodecType, keys ...string) interface{} {
 		return NewCacheError[interface{}](REDIS_INNER_ERROR, err)
 	}
 
+	for i, key := range keys {
+		if val[i] == nil {
+			rm.recordNamespaceRead(key, KEY_NOT_FOUND)
+		} else {
+			rm.recordNamespaceRead(key, OK)
+		}
+	}
+
 	switch codecType {
 	case StringType:
 		// 转换 []interface{} 为 []string
@@ -186,25 +265,26 @@ func (rm *RedisManager) mget(codecType CodecType, keys ...string) interface{} {
 	return NewCacheError[interface{}](INVALID_OPERATION, ErrInvalidOperation)
 }
 
-// MGetS 批量获取多个键的字符串值
+// MGetS 批量获取多个键的字符串值，集群模式下键分布在多个哈希槽时按槽拆分并按原顺序返回
 func (rm *RedisManager) MGetS(keys ...string) CacheResult[[]string] {
 	return rm.mget(StringType, keys...).(CacheResult[[]string])
 }
 
-// MGetB 批量获取多个键的字节数组值
+// MGetB 批量获取多个键的字节数组值，集群模式下键分布在多个哈希槽时按槽拆分并按原顺序返回
 func (rm *RedisManager) MGetB(keys ...string) CacheResult[[][]byte] {
 	return rm.mget(ByteArrayType, keys...).(CacheResult[[][]byte])
 }
 
-// MSet 批量设置多个键值对
+// MSet 批量设置多个键值对，集群模式下键分布在多个哈希槽时按槽拆分执行（不再保证整体原子性）
 func (rm *RedisManager) MSet(pairs ...interface{}) CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.MSet(rm.ctx, pairs...).Result()
+	val, err := rm.msetPairs(rm.ctx, pairs)
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[string](REDIS_INNER_ERROR, err)
@@ -217,11 +297,12 @@ func (rm *RedisManager) MSet(pairs ...interface{}) CacheResult[string] {
 func (rm *RedisManager) Incr(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Incr(rm.ctx, key).Result()
+	val, err := rm.activeClient().Incr(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -234,11 +315,12 @@ func (rm *RedisManager) Incr(key string) CacheResult[int64] {
 func (rm *RedisManager) IncrBy(key string, value int64) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.IncrBy(rm.ctx, key, value).Result()
+	val, err := rm.activeClient().IncrBy(rm.ctx, key, value).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -251,11 +333,12 @@ func (rm *RedisManager) IncrBy(key string, value int64) CacheResult[int64] {
 func (rm *RedisManager) Decr(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Decr(rm.ctx, key).Result()
+	val, err := rm.activeClient().Decr(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -268,11 +351,12 @@ func (rm *RedisManager) Decr(key string) CacheResult[int64] {
 func (rm *RedisManager) DecrBy(key string, value int64) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.DecrBy(rm.ctx, key, value).Result()
+	val, err := rm.activeClient().DecrBy(rm.ctx, key, value).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -283,20 +367,28 @@ func (rm *RedisManager) DecrBy(key string, value int64) CacheResult[int64] {
 
 // ==== Key Operations ====
 
-// Del 删除一个或多个键
+// Del 删除一个或多个键，集群模式下按哈希槽拆分执行
 func (rm *RedisManager) Del(keys ...string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
-		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	if !rm.acquire() {
+		if rm.queueDels(keys, ErrConnectionFailed) {
+			return CacheResult[int64]{ErrCode: OK, Stale: true}
+		}
+		return NewCacheError[int64](CONNECTION_FAILED, rm.unavailableError())
 	}
+	defer rm.release()
 
-	val, err := rm.client.Del(rm.ctx, keys...).Result()
+	val, err := rm.delKeys(rm.ctx, keys)
 	if err != nil {
 		rm.stats.IncrError()
+		if rm.queueDels(keys, err) {
+			return CacheResult[int64]{ErrCode: OK, Stale: true}
+		}
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
 	}
 
+	rm.forgetValues(keys...)
 	return NewCacheResult(val)
 }
 
@@ -304,11 +396,12 @@ func (rm *RedisManager) Del(keys ...string) CacheResult[int64] {
 func (rm *RedisManager) DelCtx(ctx context.Context, keys ...string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Del(ctx, keys...).Result()
+	val, err := rm.delKeys(ctx, keys)
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -321,11 +414,12 @@ func (rm *RedisManager) DelCtx(ctx context.Context, keys ...string) CacheResult[
 func (rm *RedisManager) Rename(oldKey, newKey string) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Rename(rm.ctx, oldKey, newKey).Result()
+	val, err := rm.activeClient().Rename(rm.ctx, oldKey, newKey).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -338,11 +432,12 @@ func (rm *RedisManager) Rename(oldKey, newKey string) CacheResult[bool] {
 func (rm *RedisManager) RenameNX(oldKey, newKey string) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.RenameNX(rm.ctx, oldKey, newKey).Result()
+	val, err := rm.activeClient().RenameNX(rm.ctx, oldKey, newKey).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -355,11 +450,12 @@ func (rm *RedisManager) RenameNX(oldKey, newKey string) CacheResult[bool] {
 func (rm *RedisManager) Exists(keys ...string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Exists(rm.ctx, keys...).Result()
+	val, err := rm.activeClient().Exists(rm.ctx, keys...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -372,15 +468,16 @@ func (rm *RedisManager) Exists(keys ...string) CacheResult[int64] {
 	return NewCacheResult(val)
 }
 
-// Expire 设置键的过期时间
+// Expire 设置键的过期时间，过期时间按配置的抖动比例随机调整
 func (rm *RedisManager) Expire(key string, expiration time.Duration) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Expire(rm.ctx, key, expiration).Result()
+	val, err := rm.activeClient().Expire(rm.ctx, key, rm.jitterTTL(key, expiration)).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -393,11 +490,12 @@ func (rm *RedisManager) Expire(key string, expiration time.Duration) CacheResult
 func (rm *RedisManager) TTL(key string) CacheResult[time.Duration] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[time.Duration](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.TTL(rm.ctx, key).Result()
+	val, err := rm.activeClient().TTL(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[time.Duration](REDIS_INNER_ERROR, err)
@@ -410,15 +508,39 @@ func (rm *RedisManager) TTL(key string) CacheResult[time.Duration] {
 	return NewCacheResult(val)
 }
 
+// PTTL 获取键的剩余生存时间（毫秒精度），键不存在时返回 KEY_NOT_FOUND，未设置过期时间时返回 -1
+func (rm *RedisManager) PTTL(key string) CacheResult[time.Duration] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[time.Duration](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().PTTL(rm.ctx, key).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[time.Duration](REDIS_INNER_ERROR, err)
+	}
+
+	// go-redis 对 -2/-1 不做单位换算
+	if val == -2 {
+		return NewCacheError[time.Duration](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(val)
+}
+
 // Type 获取键的数据类型
 func (rm *RedisManager) Type(key string) CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Type(rm.ctx, key).Result()
+	val, err := rm.activeClient().Type(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[string](REDIS_INNER_ERROR, err)
@@ -435,11 +557,12 @@ func (rm *RedisManager) Type(key string) CacheResult[string] {
 func (rm *RedisManager) Keys(pattern string) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Keys(rm.ctx, pattern).Result()
+	val, err := rm.activeClient().Keys(rm.ctx, pattern).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -454,11 +577,12 @@ func (rm *RedisManager) Keys(pattern string) CacheResult[[]string] {
 func (rm *RedisManager) LPush(key string, values ...interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.LPush(rm.ctx, key, values...).Result()
+	val, err := rm.activeClient().LPush(rm.ctx, key, values...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -471,11 +595,12 @@ func (rm *RedisManager) LPush(key string, values ...interface{}) CacheResult[int
 func (rm *RedisManager) RPush(key string, values ...interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.RPush(rm.ctx, key, values...).Result()
+	val, err := rm.activeClient().RPush(rm.ctx, key, values...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -488,11 +613,12 @@ func (rm *RedisManager) RPush(key string, values ...interface{}) CacheResult[int
 func (rm *RedisManager) LPop(key string) CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.LPop(rm.ctx, key).Result()
+	val, err := rm.activeClient().LPop(rm.ctx, key).Result()
 	if errors.Is(err, redis.Nil) {
 		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
 	} else if err != nil {
@@ -507,11 +633,12 @@ func (rm *RedisManager) LPop(key string) CacheResult[string] {
 func (rm *RedisManager) LRange(key string, start, stop int64) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.LRange(rm.ctx, key, start, stop).Result()
+	val, err := rm.activeClient().LRange(rm.ctx, key, start, stop).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -524,11 +651,12 @@ func (rm *RedisManager) LRange(key string, start, stop int64) CacheResult[[]stri
 func (rm *RedisManager) LLen(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.LLen(rm.ctx, key).Result()
+	val, err := rm.activeClient().LLen(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -547,11 +675,12 @@ func (rm *RedisManager) LLen(key string) CacheResult[int64] {
 func (rm *RedisManager) hset(codecType CodecType, key, field string, value interface{}) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HSet(rm.ctx, key, field, value).Result()
+	val, err := rm.activeClient().HSet(rm.ctx, key, field, value).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -574,14 +703,15 @@ func (rm *RedisManager) HSetB(key, field string, value []byte) CacheResult[bool]
 func (rm *RedisManager) HMSet(key string, fields map[string]interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
 	if len(fields) == 0 {
 		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation)
 	}
-	result, err := rm.client.HSet(rm.ctx, key, fields).Result()
+	result, err := rm.activeClient().HSet(rm.ctx, key, fields).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -594,7 +724,7 @@ func (rm *RedisManager) HMSet(key string, fields map[string]interface{}) CacheRe
 func (rm *RedisManager) hmget(codecType CodecType, key string, fields ...string) interface{} {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		switch codecType {
 		case StringType:
 			return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
@@ -603,8 +733,9 @@ func (rm *RedisManager) hmget(codecType CodecType, key string, fields ...string)
 		}
 		return NewCacheError[[]interface{}](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HMGet(rm.ctx, key, fields...).Result()
+	val, err := rm.activeClient().HMGet(rm.ctx, key, fields...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		switch codecType {
@@ -663,11 +794,12 @@ func (rm *RedisManager) HMGetB(key string, fields ...string) CacheResult[[][]byt
 func (rm *RedisManager) HExists(key, field string) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HExists(rm.ctx, key, field).Result()
+	val, err := rm.activeClient().HExists(rm.ctx, key, field).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -680,11 +812,12 @@ func (rm *RedisManager) HExists(key, field string) CacheResult[bool] {
 func (rm *RedisManager) HKeys(key string) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HKeys(rm.ctx, key).Result()
+	val, err := rm.activeClient().HKeys(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -697,11 +830,12 @@ func (rm *RedisManager) HKeys(key string) CacheResult[[]string] {
 func (rm *RedisManager) HVals(key string) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HVals(rm.ctx, key).Result()
+	val, err := rm.activeClient().HVals(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -714,11 +848,12 @@ func (rm *RedisManager) HVals(key string) CacheResult[[]string] {
 func (rm *RedisManager) HLen(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HLen(rm.ctx, key).Result()
+	val, err := rm.activeClient().HLen(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -735,7 +870,7 @@ func (rm *RedisManager) HLen(key string) CacheResult[int64] {
 func (rm *RedisManager) hget(codecType CodecType, key, field string) interface{} {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		switch codecType {
 		case StringType:
 			return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
@@ -744,14 +879,18 @@ func (rm *RedisManager) hget(codecType CodecType, key, field string) interface{}
 		}
 		return NewCacheError[interface{}](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
+
+	cmd := redis.NewStringCmd(rm.ctx, "hget", key, field)
+	rm.process(cmd)
 
 	var val interface{}
 	var err error
 	switch codecType {
 	case StringType:
-		val, err = rm.client.HGet(rm.ctx, key, field).Result()
+		val, err = cmd.Result()
 	case ByteArrayType:
-		val, err = rm.client.HGet(rm.ctx, key, field).Bytes()
+		val, err = cmd.Bytes()
 	}
 	if errors.Is(err, redis.Nil) {
 		switch codecType {
@@ -796,11 +935,12 @@ func (rm *RedisManager) HGetB(key, field string) CacheResult[[]byte] {
 func (rm *RedisManager) HGetAll(key string) CacheResult[map[string]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[map[string]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HGetAll(rm.ctx, key).Result()
+	val, err := rm.hGetAll(key)
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[map[string]string](REDIS_INNER_ERROR, err)
@@ -813,11 +953,12 @@ func (rm *RedisManager) HGetAll(key string) CacheResult[map[string]string] {
 func (rm *RedisManager) HDel(key string, fields ...string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HDel(rm.ctx, key, fields...).Result()
+	val, err := rm.activeClient().HDel(rm.ctx, key, fields...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -830,11 +971,12 @@ func (rm *RedisManager) HDel(key string, fields ...string) CacheResult[int64] {
 func (rm *RedisManager) HIncrBy(key, field string, incr int64) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.HIncrBy(rm.ctx, key, field, incr).Result()
+	val, err := rm.activeClient().HIncrBy(rm.ctx, key, field, incr).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -849,11 +991,12 @@ func (rm *RedisManager) HIncrBy(key, field string, incr int64) CacheResult[int64
 func (rm *RedisManager) SAdd(key string, members ...interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SAdd(rm.ctx, key, members...).Result()
+	val, err := rm.activeClient().SAdd(rm.ctx, key, members...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -866,11 +1009,12 @@ func (rm *RedisManager) SAdd(key string, members ...interface{}) CacheResult[int
 func (rm *RedisManager) SRem(key string, members ...interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SRem(rm.ctx, key, members...).Result()
+	val, err := rm.activeClient().SRem(rm.ctx, key, members...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -883,11 +1027,12 @@ func (rm *RedisManager) SRem(key string, members ...interface{}) CacheResult[int
 func (rm *RedisManager) SMembers(key string) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SMembers(rm.ctx, key).Result()
+	val, err := rm.activeClient().SMembers(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -900,11 +1045,12 @@ func (rm *RedisManager) SMembers(key string) CacheResult[[]string] {
 func (rm *RedisManager) SIsMember(key string, member string) CacheResult[bool] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SIsMember(rm.ctx, key, member).Result()
+	val, err := rm.activeClient().SIsMember(rm.ctx, key, member).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[bool](REDIS_INNER_ERROR, err)
@@ -917,11 +1063,12 @@ func (rm *RedisManager) SIsMember(key string, member string) CacheResult[bool] {
 func (rm *RedisManager) SCard(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SCard(rm.ctx, key).Result()
+	val, err := rm.activeClient().SCard(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -940,11 +1087,12 @@ func (rm *RedisManager) SCard(key string) CacheResult[int64] {
 func (rm *RedisManager) ZAdd(key string, score float64, member string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZAdd(rm.ctx, key, redis.Z{Score: score, Member: member}).Result()
+	val, err := rm.activeClient().ZAdd(rm.ctx, key, redis.Z{Score: score, Member: member}).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -957,11 +1105,31 @@ func (rm *RedisManager) ZAdd(key string, score float64, member string) CacheResu
 func (rm *RedisManager) ZAddMultiple(key string, members ...redis.Z) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZAdd(rm.ctx, key, members...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZAddArgs 带选项添加有序集合成员
+// 支持 NX/XX（仅新增/仅更新）、GT/LT（仅当新分数更大/更小时更新）以及 CH（返回值包含被更新的成员数）
+func (rm *RedisManager) ZAddArgs(key string, args redis.ZAddArgs) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZAdd(rm.ctx, key, members...).Result()
+	val, err := rm.activeClient().ZAddArgs(rm.ctx, key, args).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -970,15 +1138,57 @@ func (rm *RedisManager) ZAddMultiple(key string, members ...redis.Z) CacheResult
 	return NewCacheResult(val)
 }
 
+// ZAddArgsIncr 带选项以 INCR 模式添加有序集合成员，返回成员的新分数
+// INCR 模式只允许一个成员；因 NX/XX/GT/LT 条件不满足而未更新时返回 KEY_NOT_FOUND
+func (rm *RedisManager) ZAddArgsIncr(key string, args redis.ZAddArgs) CacheResult[float64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[float64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZAddArgsIncr(rm.ctx, key, args).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[float64](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[float64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZAddNX 仅添加不存在的成员，不更新已有成员的分数
+func (rm *RedisManager) ZAddNX(key string, members ...redis.Z) CacheResult[int64] {
+	return rm.ZAddArgs(key, redis.ZAddArgs{NX: true, Members: members})
+}
+
+// ZAddXX 仅更新已存在成员的分数，不添加新成员
+func (rm *RedisManager) ZAddXX(key string, members ...redis.Z) CacheResult[int64] {
+	return rm.ZAddArgs(key, redis.ZAddArgs{XX: true, Members: members})
+}
+
+// ZAddGT 仅当新分数大于当前分数时更新（不存在的成员会被添加），适用于"只保留最高分"的排行榜
+func (rm *RedisManager) ZAddGT(key string, members ...redis.Z) CacheResult[int64] {
+	return rm.ZAddArgs(key, redis.ZAddArgs{GT: true, Members: members})
+}
+
+// ZAddLT 仅当新分数小于当前分数时更新（不存在的成员会被添加）
+func (rm *RedisManager) ZAddLT(key string, members ...redis.Z) CacheResult[int64] {
+	return rm.ZAddArgs(key, redis.ZAddArgs{LT: true, Members: members})
+}
+
 // ZRem 删除有序集合成员
 func (rm *RedisManager) ZRem(key string, members ...interface{}) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRem(rm.ctx, key, members...).Result()
+	val, err := rm.activeClient().ZRem(rm.ctx, key, members...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -991,11 +1201,12 @@ func (rm *RedisManager) ZRem(key string, members ...interface{}) CacheResult[int
 func (rm *RedisManager) ZRange(key string, start, stop int64) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRange(rm.ctx, key, start, stop).Result()
+	val, err := rm.activeClient().ZRange(rm.ctx, key, start, stop).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -1008,11 +1219,12 @@ func (rm *RedisManager) ZRange(key string, start, stop int64) CacheResult[[]stri
 func (rm *RedisManager) ZRangeWithScores(key string, start, stop int64) CacheResult[[]redis.Z] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRangeWithScores(rm.ctx, key, start, stop).Result()
+	val, err := rm.activeClient().ZRangeWithScores(rm.ctx, key, start, stop).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
@@ -1025,11 +1237,12 @@ func (rm *RedisManager) ZRangeWithScores(key string, start, stop int64) CacheRes
 func (rm *RedisManager) ZRevRange(key string, start, stop int64) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRevRange(rm.ctx, key, start, stop).Result()
+	val, err := rm.activeClient().ZRevRange(rm.ctx, key, start, stop).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
@@ -1042,11 +1255,12 @@ func (rm *RedisManager) ZRevRange(key string, start, stop int64) CacheResult[[]s
 func (rm *RedisManager) ZRevRangeWithScores(key string, start, stop int64) CacheResult[[]redis.Z] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRevRangeWithScores(rm.ctx, key, start, stop).Result()
+	val, err := rm.activeClient().ZRevRangeWithScores(rm.ctx, key, start, stop).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
@@ -1059,11 +1273,12 @@ func (rm *RedisManager) ZRevRangeWithScores(key string, start, stop int64) Cache
 func (rm *RedisManager) ZScore(key string, member string) CacheResult[float64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[float64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZScore(rm.ctx, key, member).Result()
+	val, err := rm.activeClient().ZScore(rm.ctx, key, member).Result()
 	if errors.Is(err, redis.Nil) {
 		return NewCacheError[float64](KEY_NOT_FOUND, ErrKeyNotFound)
 	} else if err != nil {
@@ -1078,11 +1293,12 @@ func (rm *RedisManager) ZScore(key string, member string) CacheResult[float64] {
 func (rm *RedisManager) ZCard(key string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZCard(rm.ctx, key).Result()
+	val, err := rm.activeClient().ZCard(rm.ctx, key).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1099,11 +1315,12 @@ func (rm *RedisManager) ZCard(key string) CacheResult[int64] {
 func (rm *RedisManager) ZCount(key string, min, max string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZCount(rm.ctx, key, min, max).Result()
+	val, err := rm.activeClient().ZCount(rm.ctx, key, min, max).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1116,11 +1333,12 @@ func (rm *RedisManager) ZCount(key string, min, max string) CacheResult[int64] {
 func (rm *RedisManager) ZRank(key string, member string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRank(rm.ctx, key, member).Result()
+	val, err := rm.activeClient().ZRank(rm.ctx, key, member).Result()
 	if errors.Is(err, redis.Nil) {
 		return NewCacheError[int64](KEY_NOT_FOUND, ErrKeyNotFound)
 	} else if err != nil {
@@ -1135,11 +1353,12 @@ func (rm *RedisManager) ZRank(key string, member string) CacheResult[int64] {
 func (rm *RedisManager) ZRevRank(key string, member string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZRevRank(rm.ctx, key, member).Result()
+	val, err := rm.activeClient().ZRevRank(rm.ctx, key, member).Result()
 	if errors.Is(err, redis.Nil) {
 		return NewCacheError[int64](KEY_NOT_FOUND, ErrKeyNotFound)
 	} else if err != nil {
@@ -1154,11 +1373,12 @@ func (rm *RedisManager) ZRevRank(key string, member string) CacheResult[int64] {
 func (rm *RedisManager) ZIncrBy(key string, increment float64, member string) CacheResult[float64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[float64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.ZIncrBy(rm.ctx, key, increment, member).Result()
+	val, err := rm.activeClient().ZIncrBy(rm.ctx, key, increment, member).Result()
 	if err != nil {
 		rm.stats.IncrError()
 
@@ -1168,42 +1388,204 @@ func (rm *RedisManager) ZIncrBy(key string, increment float64, member string) Ca
 	return NewCacheResult(val)
 }
 
-type ScanResult struct {
-	Keys   []string
-	Cursor uint64
+// ZRangeByScore 按分数范围获取有序集合成员
+// min/max 支持 "-inf"、"+inf" 以及 "(" 开区间前缀；count 为0时不限制返回数量
+func (rm *RedisManager) ZRangeByScore(key string, min, max string, offset, count int64) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZRangeByScore(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
 }
 
-// Scan 扫描键
-func (rm *RedisManager) Scan(cursor uint64, match string, count int64) CacheResult[ScanResult] {
+// ZRangeByScoreWithScores 按分数范围获取有序集合成员及分数
+func (rm *RedisManager) ZRangeByScoreWithScores(key string, min, max string, offset, count int64) CacheResult[[]redis.Z] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
-		return NewCacheError[ScanResult](CONNECTION_FAILED, ErrConnectionFailed)
+	if !rm.acquire() {
+		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	page, cursor, err := rm.client.Scan(rm.ctx, cursor, match, count).Result()
+	val, err := rm.activeClient().ZRangeByScoreWithScores(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
 	if err != nil {
 		rm.stats.IncrError()
-		return NewCacheError[ScanResult](REDIS_INNER_ERROR, err)
+		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
 	}
 
-	res := ScanResult{
-		Keys:   page,
-		Cursor: cursor,
+	return NewCacheResult(val)
+}
+
+// ZRevRangeByScore 按分数范围获取有序集合成员（逆序）
+// 注意逆序查询时 max 在前、min 在后
+func (rm *RedisManager) ZRevRangeByScore(key string, max, min string, offset, count int64) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	return NewCacheResult(res)
+	val, err := rm.activeClient().ZRevRangeByScore(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
 }
 
-// GetBit 获取位
-func (rm *RedisManager) GetBit(key string, offset int64) CacheResult[int64] {
+// ZRevRangeByScoreWithScores 按分数范围获取有序集合成员及分数（逆序）
+func (rm *RedisManager) ZRevRangeByScoreWithScores(key string, max, min string, offset, count int64) CacheResult[[]redis.Z] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZRevRangeByScoreWithScores(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZPopMin 弹出分数最低的 count 个成员
+// 有序集合为空或不存在时返回 KEY_NOT_FOUND
+func (rm *RedisManager) ZPopMin(key string, count int64) CacheResult[[]redis.Z] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZPopMin(rm.ctx, key, count).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
+	}
+
+	if len(val) == 0 {
+		return NewCacheError[[]redis.Z](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZPopMax 弹出分数最高的 count 个成员
+// 有序集合为空或不存在时返回 KEY_NOT_FOUND
+func (rm *RedisManager) ZPopMax(key string, count int64) CacheResult[[]redis.Z] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.Z](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZPopMax(rm.ctx, key, count).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.Z](REDIS_INNER_ERROR, err)
+	}
+
+	if len(val) == 0 {
+		return NewCacheError[[]redis.Z](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZRangeByLex 按字典序范围获取有序集合成员（要求所有成员分数相同）
+// min/max 需以 "[" (闭区间) 或 "(" (开区间) 开头，或使用 "-"、"+" 表示无穷；count 为0时不限制返回数量
+func (rm *RedisManager) ZRangeByLex(key string, min, max string, offset, count int64) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZRangeByLex(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZRevRangeByLex 按字典序范围获取有序集合成员（逆序）
+// 注意逆序查询时 max 在前、min 在后
+func (rm *RedisManager) ZRevRangeByLex(key string, max, min string, offset, count int64) CacheResult[[]string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().ZRevRangeByLex(rm.ctx, key, &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ZLexCount 统计字典序范围内的成员数量
+func (rm *RedisManager) ZLexCount(key string, min, max string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.GetBit(rm.ctx, key, offset).Result()
+	val, err := rm.activeClient().ZLexCount(rm.ctx, key, min, max).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1212,15 +1594,17 @@ func (rm *RedisManager) GetBit(key string, offset int64) CacheResult[int64] {
 	return NewCacheResult(val)
 }
 
-// SetBit 设置位
-func (rm *RedisManager) SetBit(key string, offset int64, value int) CacheResult[int64] {
+// ZUnionStore 计算多个有序集合的并集并存储到 dest，返回结果集合的成员数量
+// store.Weights 为各集合分数的乘法因子，store.Aggregate 可选 "SUM"（默认）、"MIN"、"MAX"
+func (rm *RedisManager) ZUnionStore(dest string, store *redis.ZStore) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.SetBit(rm.ctx, key, offset, value).Result()
+	val, err := rm.activeClient().ZUnionStore(rm.ctx, dest, store).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1229,16 +1613,16 @@ func (rm *RedisManager) SetBit(key string, offset int64, value int) CacheResult[
 	return NewCacheResult(val)
 }
 
-// BitCount 统计位
-func (rm *RedisManager) BitCount(key string) CacheResult[int64] {
+// ZInterStore 计算多个有序集合的交集并存储到 dest，返回结果集合的成员数量
+func (rm *RedisManager) ZInterStore(dest string, store *redis.ZStore) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.BitCount(rm.ctx, key, nil).Result()
-
+	val, err := rm.activeClient().ZInterStore(rm.ctx, dest, store).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1247,19 +1631,16 @@ func (rm *RedisManager) BitCount(key string) CacheResult[int64] {
 	return NewCacheResult(val)
 }
 
-// BitCountWithRange 统计位
-func (rm *RedisManager) BitCountWithRange(key string, start, end int64) CacheResult[int64] {
+// ZDiffStore 计算第一个有序集合与其余集合的差集并存储到 dest，返回结果集合的成员数量
+func (rm *RedisManager) ZDiffStore(dest string, keys ...string) CacheResult[int64] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.BitCount(rm.ctx, key, &redis.BitCount{
-		Start: start,
-		End:   end,
-	}).Result()
-
+	val, err := rm.activeClient().ZDiffStore(rm.ctx, dest, keys...).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[int64](REDIS_INNER_ERROR, err)
@@ -1268,33 +1649,759 @@ func (rm *RedisManager) BitCountWithRange(key string, start, end int64) CacheRes
 	return NewCacheResult(val)
 }
 
-// ==== Script Operations ====
+// MemberScore 有序集合成员的分数查询结果
+type MemberScore struct {
+	Member string
+	Score  float64
+	Found  bool // 成员是否存在于有序集合中
+}
 
-// Eval 执行Lua脚本
-func (rm *RedisManager) Eval(script string, keys []string, args ...interface{}) CacheResult[interface{}] {
+// ZMScore 批量获取多个成员的分数（一次往返）
+// 结果顺序与 members 一致，不存在的成员 Found 为 false
+func (rm *RedisManager) ZMScore(key string, members ...string) CacheResult[[]MemberScore] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
-		return NewCacheError[interface{}](CONNECTION_FAILED, ErrConnectionFailed)
+	if !rm.acquire() {
+		return NewCacheError[[]MemberScore](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if len(members) == 0 {
+		return NewCacheError[[]MemberScore](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	// go-redis 的 ZMScore 会把不存在的成员解析为0，这里使用原始命令以保留存在性信息
+	args := make([]interface{}, 0, len(members)+2)
+	args = append(args, "zmscore", key)
+	for _, m := range members {
+		args = append(args, m)
 	}
 
-	val, err := rm.client.Eval(rm.ctx, script, keys, args...).Result()
+	val, err := rm.activeClient().Do(rm.ctx, args...).Slice()
 	if err != nil {
 		rm.stats.IncrError()
-		return NewCacheError[interface{}](REDIS_INNER_ERROR, err)
+		return NewCacheError[[]MemberScore](REDIS_INNER_ERROR, err)
 	}
 
-	return NewCacheResult(val)
+	result, err := memberScores(members, val)
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]MemberScore](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(result)
 }
 
-// EvalScript 执行注册的Lua脚本
-func (rm *RedisManager) EvalScript(name string, keys []string, args ...interface{}) CacheResult[interface{}] {
-	script, exists := rm.GetScript(name)
-	if !exists {
-		return NewCacheError[interface{}](INVALID_OPERATION, ErrInvalidOperation.WithMessage("script not found: "+name))
+// memberScores 解析 ZMSCORE 的原始回复，nil 表示成员不存在
+func memberScores(members []string, val []interface{}) ([]MemberScore, error) {
+	result := make([]MemberScore, len(members))
+	for i, m := range members {
+		result[i].Member = m
+		if i >= len(val) || val[i] == nil {
+			continue
+		}
+
+		var score float64
+		var err error
+		switch v := val[i].(type) {
+		case string:
+			score, err = strconv.ParseFloat(v, 64)
+		case float64:
+			score = v
+		case int64:
+			score = float64(v)
+		default:
+			err = fmt.Errorf("unexpected score type %T", v)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result[i].Score = score
+		result[i].Found = true
+	}
+	return result, nil
+}
+
+// unblockRetryInterval context 取消后重试 CLIENT UNBLOCK 的间隔
+const unblockRetryInterval = 10 * time.Millisecond
+
+// blockingConn 返回 key 所在节点的专用连接和该节点的客户端；direct 表示连接不经过管理器的命令钩子
+func (rm *RedisManager) blockingConn(ctx context.Context, key string) (conn *redis.Conn, node *redis.Client, direct bool, err error) {
+	switch client := rm.activeClient().(type) {
+	case *redis.ClusterClient:
+		node, err = client.MasterForKey(ctx, key)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return node.Conn(), node, true, nil
+	case *redis.Ring:
+		node, err = client.GetShardClientForKey(key)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return node.Conn(), node, true, nil
+	case *redis.Client:
+		return client.Conn(), client, false, nil
+	default:
+		return nil, nil, false, ErrInvalidOperation.WithMessage("blocking commands are not supported by this client")
+	}
+}
+
+// blockingTimeoutHook 把阻塞命令的超时参数改为精确到毫秒的秒数，go-redis 会把不足1秒的部分向上取整
+type blockingTimeoutHook struct {
+	timeout string
+}
+
+func (h blockingTimeoutHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h blockingTimeoutHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if blockingCommands[cmd.Name()] {
+			args := cmd.Args()
+			args[len(args)-1] = h.timeout
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h blockingTimeoutHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// bzpop 内部方法：阻塞弹出有序集合成员（支持最小值和最大值）
+// 在专用连接上按调用方的超时阻塞一次；context取消时通过 CLIENT UNBLOCK 解除阻塞，已弹出的成员不会丢失
+func (rm *RedisManager) bzpop(ctx context.Context, max bool, timeout time.Duration, keys ...string) CacheResult[*redis.ZWithKey] {
+	rm.stats.IncrTotal()
+
+	if len(keys) == 0 {
+		return NewCacheError[*redis.ZWithKey](INVALID_OPERATION, ErrInvalidOperation.WithMessage("no keys specified"))
+	}
+	if !rm.acquire() {
+		return NewCacheError[*redis.ZWithKey](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	// timeout 为0时以context的截止时间为准，两者都没有则一直阻塞直到context取消
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	interrupted := func() CacheResult[*redis.ZWithKey] {
+		err := ctx.Err()
+		if err == nil {
+			return NewCacheError[*redis.ZWithKey](TIMEOUT, ErrOperationTimeout)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewCacheError[*redis.ZWithKey](TIMEOUT, ErrOperationTimeout.WithError(err))
+		}
+		return NewCacheError[*redis.ZWithKey](INTERRUPTED, ErrInterrupted.WithError(err))
+	}
+	if ctx.Err() != nil {
+		return interrupted()
+	}
+
+	var wait time.Duration
+	if !deadline.IsZero() {
+		wait = time.Until(deadline)
+		if wait < time.Millisecond {
+			return interrupted()
+		}
 	}
 
-	return rm.Eval(script, keys, args...)
+	conn, node, direct, err := rm.blockingConn(ctx, keys[0])
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[*redis.ZWithKey](errorCodeOf(err), err)
+	}
+	defer conn.Close()
+
+	id, err := conn.ClientID(ctx).Result()
+	if err != nil {
+		if ctx.Err() != nil {
+			return interrupted()
+		}
+		rm.stats.IncrError()
+		return NewCacheError[*redis.ZWithKey](innerErrorCode(err), err)
+	}
+	// context 可能在命令到达服务端之前取消，此时连接尚未阻塞，UNBLOCK 返回0，需要重试直到命令返回
+	finished := make(chan struct{})
+	defer close(finished)
+	stop := context.AfterFunc(ctx, func() {
+		for {
+			if n, err := node.ClientUnblock(context.Background(), id).Result(); err == nil && n > 0 {
+				return
+			}
+			select {
+			case <-finished:
+				return
+			case <-time.After(unblockRetryInterval):
+			}
+		}
+	})
+	defer stop()
+
+	// 截止时间由服务端的超时控制，读超时由 go-redis 按等待时长放宽；传给 go-redis 的时长只影响读超时，至少1秒避免其截断告警
+	conn.AddHook(blockingTimeoutHook{timeout: strconv.FormatFloat(float64(wait.Milliseconds())/1000, 'f', -1, 64)})
+	readWait := wait
+	if wait > 0 && wait < time.Second {
+		readWait = time.Second
+	}
+	var cmd *redis.ZWithKeyCmd
+	if max {
+		cmd = conn.BZPopMax(context.WithoutCancel(ctx), readWait, keys...)
+	} else {
+		cmd = conn.BZPopMin(context.WithoutCancel(ctx), readWait, keys...)
+	}
+
+	// 直接在节点上执行的命令没有经过双写和热点键缓存的钩子
+	if direct {
+		if m := rm.mirror.Load(); m != nil {
+			m.dispatch(ctx, m.capture([]redis.Cmder{cmd}))
+		}
+		if rm.hotCache != nil {
+			rm.evictHotKeysFor(cmd)
+		}
+	}
+
+	val, err := cmd.Result()
+	if errors.Is(err, redis.Nil) {
+		return interrupted()
+	} else if err != nil {
+		if ctx.Err() != nil {
+			return interrupted()
+		}
+		rm.stats.IncrError()
+		return NewCacheError[*redis.ZWithKey](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BZPopMin 阻塞弹出分数最低的成员
+// 在给定的多个键中按顺序查找第一个非空有序集合；超时返回 TIMEOUT，context取消返回 INTERRUPTED
+func (rm *RedisManager) BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) CacheResult[*redis.ZWithKey] {
+	return rm.bzpop(ctx, false, timeout, keys...)
+}
+
+// BZPopMax 阻塞弹出分数最高的成员
+// 在给定的多个键中按顺序查找第一个非空有序集合；超时返回 TIMEOUT，context取消返回 INTERRUPTED
+func (rm *RedisManager) BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) CacheResult[*redis.ZWithKey] {
+	return rm.bzpop(ctx, true, timeout, keys...)
+}
+
+type ScanResult struct {
+	Keys   []string
+	Cursor uint64
+}
+
+// Scan 扫描键
+func (rm *RedisManager) Scan(cursor uint64, match string, count int64) CacheResult[ScanResult] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[ScanResult](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	page, cursor, err := rm.activeClient().Scan(rm.ctx, cursor, match, count).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[ScanResult](REDIS_INNER_ERROR, err)
+	}
+
+	res := ScanResult{
+		Keys:   page,
+		Cursor: cursor,
+	}
+
+	return NewCacheResult(res)
+}
+
+// zscanIterCount ZScanIterator 每次向服务端请求的建议数量
+const zscanIterCount = 100
+
+// ZScanIterator 有序集合的增量迭代器，基于 ZSCAN 分批拉取成员及分数
+// 迭代期间集合被修改时，成员可能被重复返回，这是 ZSCAN 本身的语义
+type ZScanIterator struct {
+	rm     *RedisManager
+	key    string
+	match  string
+	cursor uint64
+	page   []string
+	pos    int
+	done   bool
+	val    redis.Z
+	err    error
+}
+
+// ZScanIter 创建有序集合迭代器，match 为空时匹配全部成员
+//
+// 用法:
+//
+//	it := rm.ZScanIter("leaderboard", "")
+//	for it.Next() {
+//	    z := it.Val()
+//	}
+//	if err := it.Err(); err != nil { ... }
+func (rm *RedisManager) ZScanIter(key, match string) *ZScanIterator {
+	return &ZScanIterator{
+		rm:    rm,
+		key:   key,
+		match: match,
+	}
+}
+
+// Next 前进到下一个成员，没有更多成员或发生错误时返回 false
+func (it *ZScanIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	// ZSCAN 返回 member、score 交替排列的扁平列表
+	for it.pos+1 >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+
+	score, err := strconv.ParseFloat(it.page[it.pos+1], 64)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.val = redis.Z{Member: it.page[it.pos], Score: score}
+	it.pos += 2
+	return true
+}
+
+// fetch 拉取下一批数据
+func (it *ZScanIterator) fetch() bool {
+	rm := it.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		it.err = ErrConnectionFailed
+		return false
+	}
+	defer rm.release()
+
+	page, cursor, err := rm.activeClient().ZScan(rm.ctx, it.key, it.cursor, it.match, zscanIterCount).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		it.err = ErrOperationFailed.WithError(err)
+		return false
+	}
+
+	it.page = page
+	it.pos = 0
+	it.cursor = cursor
+	it.done = cursor == 0
+	return true
+}
+
+// Val 返回当前成员及分数
+func (it *ZScanIterator) Val() redis.Z {
+	return it.val
+}
+
+// Err 返回迭代过程中遇到的错误
+func (it *ZScanIterator) Err() error {
+	return it.err
+}
+
+// GetBit 获取位
+func (rm *RedisManager) GetBit(key string, offset int64) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().GetBit(rm.ctx, key, offset).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// SetBit 设置位
+func (rm *RedisManager) SetBit(key string, offset int64, value int) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().SetBit(rm.ctx, key, offset, value).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BitCount 统计位
+func (rm *RedisManager) BitCount(key string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().BitCount(rm.ctx, key, nil).Result()
+
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BitCountWithRange 统计位（start/end 为字节偏移）
+func (rm *RedisManager) BitCountWithRange(key string, start, end int64) CacheResult[int64] {
+	// 不显式传入单位，以兼容不支持 BYTE/BIT 参数的 Redis 7.0 以下版本
+	return rm.BitCountWithUnit(key, start, end, "")
+}
+
+// BitCountWithUnit 按指定单位统计范围内的位（需要 Redis 7.0+ 才支持 BIT 单位）
+// unit 取值 redis.BitCountIndexByte（"BYTE"）或 redis.BitCountIndexBit（"BIT"），为空时使用服务端默认的字节单位
+func (rm *RedisManager) BitCountWithUnit(key string, start, end int64, unit string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if unit != "" && unit != redis.BitCountIndexByte && unit != redis.BitCountIndexBit {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation.WithMessage("invalid bitcount unit: "+unit))
+	}
+
+	val, err := rm.activeClient().BitCount(rm.ctx, key, &redis.BitCount{
+		Start: start,
+		End:   end,
+		Unit:  unit,
+	}).Result()
+
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BitPos 查找第一个值为 bit(0或1) 的位，start/end 为字节偏移（支持负数，-1表示最后一个字节）
+// 未找到时返回 -1；查找0且未指定明确范围时，若全部为1则返回字符串之后的第一个位
+func (rm *RedisManager) BitPos(key string, bit int64, start, end int64) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().BitPos(rm.ctx, key, bit, start, end).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// bitop 内部方法：执行位运算并将结果存储到 destKey，返回结果字符串的长度（字节）
+func (rm *RedisManager) bitop(op string, destKey string, keys ...string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if len(keys) == 0 {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	var cmd *redis.IntCmd
+	switch op {
+	case "and":
+		cmd = rm.activeClient().BitOpAnd(rm.ctx, destKey, keys...)
+	case "or":
+		cmd = rm.activeClient().BitOpOr(rm.ctx, destKey, keys...)
+	case "xor":
+		cmd = rm.activeClient().BitOpXor(rm.ctx, destKey, keys...)
+	case "not":
+		cmd = rm.activeClient().BitOpNot(rm.ctx, destKey, keys[0])
+	default:
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	val, err := cmd.Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// BitOpAnd 对多个位图做按位与，结果存储到 destKey
+func (rm *RedisManager) BitOpAnd(destKey string, keys ...string) CacheResult[int64] {
+	return rm.bitop("and", destKey, keys...)
+}
+
+// BitOpOr 对多个位图做按位或，结果存储到 destKey
+func (rm *RedisManager) BitOpOr(destKey string, keys ...string) CacheResult[int64] {
+	return rm.bitop("or", destKey, keys...)
+}
+
+// BitOpXor 对多个位图做按位异或，结果存储到 destKey
+func (rm *RedisManager) BitOpXor(destKey string, keys ...string) CacheResult[int64] {
+	return rm.bitop("xor", destKey, keys...)
+}
+
+// BitOpNot 对位图按位取反，结果存储到 destKey
+func (rm *RedisManager) BitOpNot(destKey string, key string) CacheResult[int64] {
+	return rm.bitop("not", destKey, key)
+}
+
+// ==== HyperLogLog Operations ====
+
+// PFAdd 向 HyperLogLog 添加元素，基数估计值发生变化时返回 true
+func (rm *RedisManager) PFAdd(key string, els ...interface{}) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().PFAdd(rm.ctx, key, els...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[bool](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val == 1)
+}
+
+// PFCount 获取一个或多个 HyperLogLog 的基数估计值（多个键时返回并集的基数）
+func (rm *RedisManager) PFCount(keys ...string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().PFCount(rm.ctx, keys...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// PFMerge 合并多个 HyperLogLog 到 dest
+func (rm *RedisManager) PFMerge(dest string, keys ...string) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().PFMerge(rm.ctx, dest, keys...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// DailyHLLKey 生成按天分片的 HyperLogLog 键名，格式为 prefix + "20060102"
+func DailyHLLKey(prefix string, day time.Time) string {
+	return prefix + day.Format("20060102")
+}
+
+// rollingHLLKeys 生成以 end 为最后一天、向前共 days 天的按天键名
+func rollingHLLKeys(prefix string, end time.Time, days int) []string {
+	keys := make([]string, 0, days)
+	for i := 0; i < days; i++ {
+		keys = append(keys, DailyHLLKey(prefix, end.AddDate(0, 0, -i)))
+	}
+	return keys
+}
+
+// PFCountRolling 统计滚动窗口（如最近7天/30天）内的去重数量
+// 按天的键由 DailyHLLKey(prefix, day) 生成；集群模式下 prefix 需包含哈希标签（如 "{uv}:"）保证所有键位于同一槽
+func (rm *RedisManager) PFCountRolling(prefix string, end time.Time, days int) CacheResult[int64] {
+	if days <= 0 {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	return rm.PFCount(rollingHLLKeys(prefix, end, days)...)
+}
+
+// PFMergeRolling 将滚动窗口内的按天 HyperLogLog 合并到 dest，并可选设置过期时间（ttl 为0时不设置）
+// 适用于需要反复读取同一窗口统计结果的场景，避免每次都对多个键做并集计算
+func (rm *RedisManager) PFMergeRolling(dest, prefix string, end time.Time, days int, ttl time.Duration) CacheResult[int64] {
+	if days <= 0 {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation)
+	}
+
+	merged := rm.PFMerge(dest, rollingHLLKeys(prefix, end, days)...)
+	if !merged.IsOK() {
+		return NewCacheError[int64](merged.ErrCode, merged.Err)
+	}
+
+	if ttl > 0 {
+		expired := rm.Expire(dest, ttl)
+		if !expired.IsOK() {
+			return NewCacheError[int64](expired.ErrCode, expired.Err)
+		}
+	}
+
+	return rm.PFCount(dest)
+}
+
+// ==== Geo Operations ====
+
+// GeoAdd 添加地理位置成员，返回新增的成员数量（更新已有成员坐标不计入）
+func (rm *RedisManager) GeoAdd(key string, locations ...*redis.GeoLocation) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().GeoAdd(rm.ctx, key, locations...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// GeoPos 获取成员的经纬度，不存在的成员对应位置为 nil
+func (rm *RedisManager) GeoPos(key string, members ...string) CacheResult[[]*redis.GeoPos] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]*redis.GeoPos](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().GeoPos(rm.ctx, key, members...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]*redis.GeoPos](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// GeoDist 计算两个成员之间的距离，unit 可选 m、km、ft、mi（默认 km）
+func (rm *RedisManager) GeoDist(key string, member1, member2, unit string) CacheResult[float64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[float64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().GeoDist(rm.ctx, key, member1, member2, unit).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[float64](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[float64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// GeoSearchLocation 按半径或矩形范围搜索成员（GEOSEARCH），可返回距离和坐标
+func (rm *RedisManager) GeoSearchLocation(key string, q *redis.GeoSearchLocationQuery) CacheResult[[]redis.GeoLocation] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.GeoLocation](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().GeoSearchLocation(rm.ctx, key, q).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.GeoLocation](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ==== Script Operations ====
+
+// Eval 执行Lua脚本
+func (rm *RedisManager) Eval(script string, keys []string, args ...interface{}) CacheResult[interface{}] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[interface{}](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().Eval(rm.ctx, script, keys, args...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[interface{}](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// EvalScript 执行注册的Lua脚本，通过 EVALSHA 只发送脚本的 SHA1，服务端未缓存时自动回退到 EVAL
+func (rm *RedisManager) EvalScript(name string, keys []string, args ...interface{}) CacheResult[interface{}] {
+	script, exists := rm.registeredScript(name)
+	if !exists {
+		return NewCacheError[interface{}](INVALID_OPERATION, ErrInvalidOperation.WithMessage("script not found: "+name))
+	}
+
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[interface{}](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.evalScript(script, keys, args...)
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[interface{}](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
 }
 
 // ==== Utility Operations ====
@@ -1303,11 +2410,12 @@ func (rm *RedisManager) EvalScript(name string, keys []string, args ...interface
 func (rm *RedisManager) Ping() CacheResult[string] {
 	rm.stats.IncrTotal()
 
-	if !rm.IsHealthy() {
+	if !rm.acquire() {
 		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rm.release()
 
-	val, err := rm.client.Ping(rm.ctx).Result()
+	val, err := rm.activeClient().Ping(rm.ctx).Result()
 	if err != nil {
 		rm.stats.IncrError()
 		return NewCacheError[string](REDIS_INNER_ERROR, err)
@@ -0,0 +1,80 @@
+package redisx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingServer 模拟 BZPOPMIN 阻塞和 CLIENT UNBLOCK：连接阻塞之前收到的 UNBLOCK 返回0
+type blockingServer struct {
+	mu         sync.Mutex
+	blocked    bool
+	unblocked  chan struct{}
+	onClientID func()
+	blockDelay time.Duration // 模拟 BZPOPMIN 在网络中的延迟
+}
+
+func (s *blockingServer) handle(args []string) string {
+	switch {
+	case args[0] == "client" && args[1] == "id":
+		if s.onClientID != nil {
+			s.onClientID()
+		}
+		return ":7\r\n"
+	case args[0] == "client" && args[1] == "unblock":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.blocked {
+			return ":0\r\n"
+		}
+		s.blocked = false
+		close(s.unblocked)
+		return ":1\r\n"
+	case args[0] == "bzpopmin":
+		time.Sleep(s.blockDelay)
+		s.mu.Lock()
+		s.blocked = true
+		s.mu.Unlock()
+		<-s.unblocked
+		return "_\r\n"
+	}
+	return ""
+}
+
+func TestBZPopMinCancel(t *testing.T) {
+	s := &blockingServer{unblocked: make(chan struct{})}
+	rm := newTestManager(t, newFakeServer(t, s.handle).addr, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	res := rm.BZPopMin(ctx, 0, "queue")
+	if res.ErrCode != INTERRUPTED {
+		t.Fatalf("BZPopMin = %v, %v, want INTERRUPTED", res.ErrCode, res.Err)
+	}
+}
+
+// context 在 CLIENT ID 之后、BZPOPMIN 到达服务端之前取消，第一次 UNBLOCK 落空
+func TestBZPopMinCancelBeforeBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &blockingServer{unblocked: make(chan struct{}), onClientID: cancel, blockDelay: 50 * time.Millisecond}
+	rm := newTestManager(t, newFakeServer(t, s.handle).addr, nil)
+
+	done := make(chan CacheResult[bool], 1)
+	go func() {
+		res := rm.BZPopMin(ctx, 0, "queue")
+		done <- CacheResult[bool]{ErrCode: res.ErrCode, Err: res.Err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.ErrCode != INTERRUPTED {
+			t.Fatalf("BZPopMin = %v, %v, want INTERRUPTED", res.ErrCode, res.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("BZPopMin still blocked after context was cancelled")
+	}
+}
@@ -0,0 +1,140 @@
+package redisx
+
+import (
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// pipelineSplit 集群模式下按哈希槽拆分的跨槽命令：子命令在流水线中占据 [start, start+count)，Exec 后合并为 cmd 的结果
+type pipelineSplit struct {
+	start int
+	count int
+	cmd   redis.Cmder
+	merge func(execErr error)
+}
+
+// split 记录拆分后的命令，queue 负责把子命令加入流水线
+func (rp *RedisPipeline) split(cmd redis.Cmder, queue func(), merge func(execErr error)) {
+	start := rp.pipe.Len()
+	queue()
+	rp.splits = append(rp.splits, pipelineSplit{start: start, count: rp.pipe.Len() - start, cmd: cmd, merge: merge})
+}
+
+// stitch 合并拆分命令的结果，并在 Exec 返回的命令列表中用原命令替换其子命令
+func (rp *RedisPipeline) stitch(cmders []redis.Cmder, execErr error) []redis.Cmder {
+	if len(rp.splits) == 0 {
+		return cmders
+	}
+
+	result := make([]redis.Cmder, 0, len(cmders))
+	next := 0
+	for _, s := range rp.splits {
+		s.merge(execErr)
+		result = append(result, cmders[next:min(s.start, len(cmders))]...)
+		result = append(result, s.cmd)
+		next = min(s.start+s.count, len(cmders))
+	}
+	result = append(result, cmders[next:]...)
+	rp.splits = nil
+	return result
+}
+
+// subCmdErr 子命令的错误；go-redis 在连接错误重试耗尽后不一定写入各命令，此时使用 Exec 返回的错误
+func subCmdErr(sub redis.Cmder, execErr error) error {
+	if err := sub.Err(); err != nil {
+		return err
+	}
+	var redisErr redis.Error
+	if execErr != nil && !errors.As(execErr, &redisErr) {
+		return execErr
+	}
+	return nil
+}
+
+// keysArgs 构造命令参数
+func keysArgs(name string, keys []string) []interface{} {
+	args := make([]interface{}, 0, len(keys)+1)
+	args = append(args, name)
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	return args
+}
+
+// sumBySlot 按哈希槽拆分 DEL、EXISTS 等返回计数的多键命令，结果为各子命令之和
+func (rp *RedisPipeline) sumBySlot(name string, keys []string, groups []slotGroup,
+	queue func(keys ...string) *redis.IntCmd) *redis.IntCmd {
+	cmd := redis.NewIntCmd(rp.rm.ctx, keysArgs(name, keys)...)
+	subs := make([]*redis.IntCmd, len(groups))
+	rp.split(cmd, func() {
+		for i, g := range groups {
+			subs[i] = queue(g.keys...)
+		}
+	}, func(execErr error) {
+		var sum int64
+		for _, sub := range subs {
+			if err := subCmdErr(sub, execErr); err != nil {
+				cmd.SetErr(err)
+				return
+			}
+			sum += sub.Val()
+		}
+		cmd.SetVal(sum)
+	})
+	return cmd
+}
+
+// mgetBySlot 按哈希槽拆分 MGET，结果按原始键顺序合并
+func (rp *RedisPipeline) mgetBySlot(keys []string, groups []slotGroup) *redis.SliceCmd {
+	cmd := redis.NewSliceCmd(rp.rm.ctx, keysArgs("mget", keys)...)
+	subs := make([]*redis.SliceCmd, len(groups))
+	rp.split(cmd, func() {
+		for i, g := range groups {
+			subs[i] = rp.pipe.MGet(rp.rm.ctx, g.keys...)
+		}
+	}, func(execErr error) {
+		val := make([]interface{}, len(keys))
+		for i, sub := range subs {
+			if err := subCmdErr(sub, execErr); err != nil {
+				cmd.SetErr(err)
+				return
+			}
+			for j, v := range sub.Val() {
+				val[groups[i].indices[j]] = v
+			}
+		}
+		cmd.SetVal(val)
+	})
+	return cmd
+}
+
+// msetBySlot 按哈希槽拆分 MSET，各槽分别写入，不保证整体原子性
+func (rp *RedisPipeline) msetBySlot(keys []string, values []interface{}, groups []slotGroup) *redis.StatusCmd {
+	args := make([]interface{}, 0, 2*len(keys)+1)
+	args = append(args, "mset")
+	for i, key := range keys {
+		args = append(args, key, values[i])
+	}
+	cmd := redis.NewStatusCmd(rp.rm.ctx, args...)
+
+	subs := make([]*redis.StatusCmd, len(groups))
+	rp.split(cmd, func() {
+		for i, g := range groups {
+			pairs := make([]interface{}, 0, 2*len(g.keys))
+			for j, key := range g.keys {
+				pairs = append(pairs, key, values[g.indices[j]])
+			}
+			subs[i] = rp.pipe.MSet(rp.rm.ctx, pairs...)
+		}
+	}, func(execErr error) {
+		for _, sub := range subs {
+			if err := subCmdErr(sub, execErr); err != nil {
+				cmd.SetErr(err)
+				return
+			}
+		}
+		cmd.SetVal("OK")
+	})
+	return cmd
+}
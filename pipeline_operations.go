@@ -1,6 +1,7 @@
 package redisx
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -8,15 +9,17 @@ import (
 )
 
 // RedisPipeline 包装的Pipeline，提供统一的错误处理
+// 集群模式下 go-redis 按节点拆分流水线并发执行；键分布在多个哈希槽的 Del、Exists、MGet、MSet 在此按槽拆分为子命令，Exec 后合并回原命令
 type RedisPipeline struct {
-	pipe redis.Pipeliner
-	rm   *RedisManager
+	pipe   redis.Pipeliner
+	rm     *RedisManager
+	splits []pipelineSplit
 }
 
 // Pipeline 获取包装的Pipeline
 func (rm *RedisManager) Pipeline() *RedisPipeline {
 	return &RedisPipeline{
-		pipe: rm.client.Pipeline(),
+		pipe: rm.activeClient().Pipeline(),
 		rm:   rm,
 	}
 }
@@ -25,13 +28,20 @@ func (rm *RedisManager) Pipeline() *RedisPipeline {
 
 // Exec 执行Pipeline并统一处理错误
 func (rp *RedisPipeline) Exec() CacheResult[[]redis.Cmder] {
+	return rp.ExecCtx(rp.rm.ctx)
+}
+
+// ExecCtx 以调用方的 context 执行Pipeline，命令钩子（审计、链路追踪等）看到的是该 context
+func (rp *RedisPipeline) ExecCtx(ctx context.Context) CacheResult[[]redis.Cmder] {
 	rp.rm.stats.IncrTotal()
 
-	if !rp.rm.IsHealthy() {
+	if !rp.rm.acquire() {
 		return NewCacheError[[]redis.Cmder](CONNECTION_FAILED, ErrConnectionFailed)
 	}
+	defer rp.rm.release()
 
-	cmders, err := rp.pipe.Exec(rp.rm.ctx)
+	cmders, err := rp.pipe.Exec(ctx)
+	cmders = rp.stitch(cmders, err)
 	if err != nil {
 
 		if errors.Is(err, redis.Nil) {
@@ -56,10 +66,20 @@ func (rp *RedisPipeline) Get(key string) *redis.StringCmd {
 }
 
 func (rp *RedisPipeline) Del(keys ...string) *redis.IntCmd {
+	if groups := rp.rm.splitBySlot(keys); groups != nil {
+		return rp.sumBySlot("del", keys, groups, func(keys ...string) *redis.IntCmd {
+			return rp.pipe.Del(rp.rm.ctx, keys...)
+		})
+	}
 	return rp.pipe.Del(rp.rm.ctx, keys...)
 }
 
 func (rp *RedisPipeline) Exists(keys ...string) *redis.IntCmd {
+	if groups := rp.rm.splitBySlot(keys); groups != nil {
+		return rp.sumBySlot("exists", keys, groups, func(keys ...string) *redis.IntCmd {
+			return rp.pipe.Exists(rp.rm.ctx, keys...)
+		})
+	}
 	return rp.pipe.Exists(rp.rm.ctx, keys...)
 }
 
@@ -97,10 +117,20 @@ func (rp *RedisPipeline) SetNX(key string, value interface{}, expiration time.Du
 }
 
 func (rp *RedisPipeline) MGet(keys ...string) *redis.SliceCmd {
+	if groups := rp.rm.splitBySlot(keys); groups != nil {
+		return rp.mgetBySlot(keys, groups)
+	}
 	return rp.pipe.MGet(rp.rm.ctx, keys...)
 }
 
 func (rp *RedisPipeline) MSet(pairs ...interface{}) *redis.StatusCmd {
+	if rp.rm.config.Mode == ModeCluster {
+		if keys, values, ok := flattenPairs(pairs); ok {
+			if groups := rp.rm.splitBySlot(keys); groups != nil {
+				return rp.msetBySlot(keys, values, groups)
+			}
+		}
+	}
 	return rp.pipe.MSet(rp.rm.ctx, pairs...)
 }
 
@@ -232,6 +262,30 @@ func (rp *RedisPipeline) ZAdd(key string, member interface{}, score float64) *re
 	return rp.pipe.ZAdd(rp.rm.ctx, key, redis.Z{Score: score, Member: member})
 }
 
+func (rp *RedisPipeline) ZAddArgs(key string, args redis.ZAddArgs) *redis.IntCmd {
+	return rp.pipe.ZAddArgs(rp.rm.ctx, key, args)
+}
+
+func (rp *RedisPipeline) ZAddArgsIncr(key string, args redis.ZAddArgs) *redis.FloatCmd {
+	return rp.pipe.ZAddArgsIncr(rp.rm.ctx, key, args)
+}
+
+func (rp *RedisPipeline) ZAddGT(key string, members ...redis.Z) *redis.IntCmd {
+	return rp.pipe.ZAddGT(rp.rm.ctx, key, members...)
+}
+
+func (rp *RedisPipeline) ZAddLT(key string, members ...redis.Z) *redis.IntCmd {
+	return rp.pipe.ZAddLT(rp.rm.ctx, key, members...)
+}
+
+func (rp *RedisPipeline) ZAddNX(key string, members ...redis.Z) *redis.IntCmd {
+	return rp.pipe.ZAddNX(rp.rm.ctx, key, members...)
+}
+
+func (rp *RedisPipeline) ZAddXX(key string, members ...redis.Z) *redis.IntCmd {
+	return rp.pipe.ZAddXX(rp.rm.ctx, key, members...)
+}
+
 func (rp *RedisPipeline) ZRem(key string, members ...interface{}) *redis.IntCmd {
 	return rp.pipe.ZRem(rp.rm.ctx, key, members...)
 }
@@ -280,6 +334,91 @@ func (rp *RedisPipeline) ZIncrBy(key string, increment float64, member string) *
 	return rp.pipe.ZIncrBy(rp.rm.ctx, key, increment, member)
 }
 
+func (rp *RedisPipeline) ZRangeByScore(key string, min, max string, offset, count int64) *redis.StringSliceCmd {
+	return rp.pipe.ZRangeByScore(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZRangeByScoreWithScores(key string, min, max string, offset, count int64) *redis.ZSliceCmd {
+	return rp.pipe.ZRangeByScoreWithScores(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZRevRangeByScore(key string, max, min string, offset, count int64) *redis.StringSliceCmd {
+	return rp.pipe.ZRevRangeByScore(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZRevRangeByScoreWithScores(key string, max, min string, offset, count int64) *redis.ZSliceCmd {
+	return rp.pipe.ZRevRangeByScoreWithScores(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZPopMin(key string, count int64) *redis.ZSliceCmd {
+	return rp.pipe.ZPopMin(rp.rm.ctx, key, count)
+}
+
+func (rp *RedisPipeline) ZPopMax(key string, count int64) *redis.ZSliceCmd {
+	return rp.pipe.ZPopMax(rp.rm.ctx, key, count)
+}
+
+func (rp *RedisPipeline) ZRangeByLex(key string, min, max string, offset, count int64) *redis.StringSliceCmd {
+	return rp.pipe.ZRangeByLex(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZRevRangeByLex(key string, max, min string, offset, count int64) *redis.StringSliceCmd {
+	return rp.pipe.ZRevRangeByLex(rp.rm.ctx, key, &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: count})
+}
+
+func (rp *RedisPipeline) ZLexCount(key string, min, max string) *redis.IntCmd {
+	return rp.pipe.ZLexCount(rp.rm.ctx, key, min, max)
+}
+
+func (rp *RedisPipeline) ZUnionStore(dest string, store *redis.ZStore) *redis.IntCmd {
+	return rp.pipe.ZUnionStore(rp.rm.ctx, dest, store)
+}
+
+func (rp *RedisPipeline) ZInterStore(dest string, store *redis.ZStore) *redis.IntCmd {
+	return rp.pipe.ZInterStore(rp.rm.ctx, dest, store)
+}
+
+func (rp *RedisPipeline) ZDiffStore(dest string, keys ...string) *redis.IntCmd {
+	return rp.pipe.ZDiffStore(rp.rm.ctx, dest, keys...)
+}
+
+// ZMScore 与 RedisManager.ZMScore 一致，不存在的成员 Found 为 false（go-redis 的 ZMScore 会将其解析为0）
+func (rp *RedisPipeline) ZMScore(key string, members ...string) *MemberScoresCmd {
+	args := make([]interface{}, 0, len(members)+2)
+	args = append(args, "zmscore", key)
+	for _, m := range members {
+		args = append(args, m)
+	}
+	return &MemberScoresCmd{cmd: rp.pipe.Do(rp.rm.ctx, args...), members: members}
+}
+
+// MemberScoresCmd 流水线中 ZMScore 的结果，Exec 之后读取
+type MemberScoresCmd struct {
+	cmd     *redis.Cmd
+	members []string
+}
+
+// Result 各成员的分数，顺序与请求的成员一致
+func (c *MemberScoresCmd) Result() ([]MemberScore, error) {
+	val, err := c.cmd.Slice()
+	if err != nil {
+		return nil, err
+	}
+	return memberScores(c.members, val)
+}
+
+// Val 各成员的分数，出错时为 nil
+func (c *MemberScoresCmd) Val() []MemberScore {
+	val, _ := c.Result()
+	return val
+}
+
+// Err 命令的错误
+func (c *MemberScoresCmd) Err() error {
+	_, err := c.Result()
+	return err
+}
+
 // GetBit
 func (rp *RedisPipeline) GetBit(key string, offset int64) *redis.IntCmd {
 	return rp.pipe.GetBit(rp.rm.ctx, key, offset)
@@ -298,6 +437,91 @@ func (rp *RedisPipeline) BitCount(key string, bitStart, bitEnd int64) *redis.Int
 	})
 }
 
+// BitCountWithUnit
+func (rp *RedisPipeline) BitCountWithUnit(key string, bitStart, bitEnd int64, unit string) *redis.IntCmd {
+	return rp.pipe.BitCount(rp.rm.ctx, key, &redis.BitCount{
+		Start: bitStart,
+		End:   bitEnd,
+		Unit:  unit,
+	})
+}
+
+// BitPos
+func (rp *RedisPipeline) BitPos(key string, bit int64, start, end int64) *redis.IntCmd {
+	return rp.pipe.BitPos(rp.rm.ctx, key, bit, start, end)
+}
+
+// BitOp
+func (rp *RedisPipeline) BitOpAnd(destKey string, keys ...string) *redis.IntCmd {
+	return rp.pipe.BitOpAnd(rp.rm.ctx, destKey, keys...)
+}
+
+func (rp *RedisPipeline) BitOpOr(destKey string, keys ...string) *redis.IntCmd {
+	return rp.pipe.BitOpOr(rp.rm.ctx, destKey, keys...)
+}
+
+func (rp *RedisPipeline) BitOpXor(destKey string, keys ...string) *redis.IntCmd {
+	return rp.pipe.BitOpXor(rp.rm.ctx, destKey, keys...)
+}
+
+func (rp *RedisPipeline) BitOpNot(destKey string, key string) *redis.IntCmd {
+	return rp.pipe.BitOpNot(rp.rm.ctx, destKey, key)
+}
+
+// HyperLogLog operations
+func (rp *RedisPipeline) PFAdd(key string, els ...interface{}) *redis.IntCmd {
+	return rp.pipe.PFAdd(rp.rm.ctx, key, els...)
+}
+
+func (rp *RedisPipeline) PFCount(keys ...string) *redis.IntCmd {
+	return rp.pipe.PFCount(rp.rm.ctx, keys...)
+}
+
+func (rp *RedisPipeline) PFMerge(dest string, keys ...string) *redis.StatusCmd {
+	return rp.pipe.PFMerge(rp.rm.ctx, dest, keys...)
+}
+
+// Geo operations
+func (rp *RedisPipeline) GeoAdd(key string, locations ...*redis.GeoLocation) *redis.IntCmd {
+	return rp.pipe.GeoAdd(rp.rm.ctx, key, locations...)
+}
+
+func (rp *RedisPipeline) GeoPos(key string, members ...string) *redis.GeoPosCmd {
+	return rp.pipe.GeoPos(rp.rm.ctx, key, members...)
+}
+
+func (rp *RedisPipeline) GeoDist(key string, member1, member2, unit string) *redis.FloatCmd {
+	return rp.pipe.GeoDist(rp.rm.ctx, key, member1, member2, unit)
+}
+
+// Stream operations
+// XAdd 参数校验失败时直接返回带错误的命令，该命令不会加入管道
+func (rp *RedisPipeline) XAdd(stream string, values map[string]interface{}, opts *XAddOptions) *redis.StringCmd {
+	args, rerr := xAddArgs(stream, values, opts)
+	if rerr != nil {
+		cmd := redis.NewStringCmd(rp.rm.ctx, "xadd", stream)
+		cmd.SetErr(rerr)
+		return cmd
+	}
+	return rp.pipe.XAdd(rp.rm.ctx, args)
+}
+
+func (rp *RedisPipeline) XAck(stream, group string, ids ...string) *redis.IntCmd {
+	return rp.pipe.XAck(rp.rm.ctx, stream, group, ids...)
+}
+
+func (rp *RedisPipeline) XTrimMaxLenApprox(stream string, maxLen int64) *redis.IntCmd {
+	return rp.pipe.XTrimMaxLenApprox(rp.rm.ctx, stream, maxLen, 0)
+}
+
+func (rp *RedisPipeline) XTrimMinIDApprox(stream, minID string) *redis.IntCmd {
+	return rp.pipe.XTrimMinIDApprox(rp.rm.ctx, stream, minID, 0)
+}
+
+func (rp *RedisPipeline) XLen(stream string) *redis.IntCmd {
+	return rp.pipe.XLen(rp.rm.ctx, stream)
+}
+
 // 获取原始的Pipeliner（用于高级用法）
 func (rp *RedisPipeline) GetPipeliner() redis.Pipeliner {
 	return rp.pipe
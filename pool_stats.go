@@ -0,0 +1,48 @@
+package redisx
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PoolStats 连接池统计，集群和 Ring 模式为所有节点连接池的合计
+type PoolStats struct {
+	Hits         uint32        // 从连接池取到空闲连接的次数
+	Misses       uint32        // 连接池无空闲连接、需要新建连接的次数
+	Timeouts     uint32        // 等待连接超时的次数，持续增长说明连接池耗尽
+	WaitCount    uint32        // 等待连接的次数
+	WaitDuration time.Duration // 等待连接的累计时间
+	TotalConns   uint32        // 当前连接总数
+	IdleConns    uint32        // 当前空闲连接数
+	StaleConns   uint32        // 因过期被移除的连接数
+	PoolSize     int           // 配置的单节点连接池大小
+}
+
+// InUse 当前正在使用的连接数
+func (s PoolStats) InUse() uint32 {
+	if s.TotalConns < s.IdleConns {
+		return 0
+	}
+	return s.TotalConns - s.IdleConns
+}
+
+// PoolStats 获取底层 go-redis 连接池统计，客户端已关闭时返回零值
+func (rm *RedisManager) PoolStats() PoolStats {
+	stats := PoolStats{PoolSize: rm.config.Common.PoolSize}
+
+	pooled, ok := rm.GetClient().(interface{ PoolStats() *redis.PoolStats })
+	if !ok {
+		return stats
+	}
+	s := pooled.PoolStats()
+	stats.Hits = s.Hits
+	stats.Misses = s.Misses
+	stats.Timeouts = s.Timeouts
+	stats.WaitCount = s.WaitCount
+	stats.WaitDuration = time.Duration(s.WaitDurationNs)
+	stats.TotalConns = s.TotalConns
+	stats.IdleConns = s.IdleConns
+	stats.StaleConns = s.StaleConns
+	return stats
+}
@@ -0,0 +1,174 @@
+package redisx
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// MaxQueuePriority 优先级上限，优先级取值范围为 [0, MaxQueuePriority]，数值越大越先出队
+	MaxQueuePriority = 1000
+
+	// priorityScale 分数中优先级部分的权重，低位为入队时间（毫秒），保证同优先级先进先出
+	priorityScale = 1e12
+	// priorityEpoch 入队时间的起点（2020-01-01 UTC），缩小分数范围以保证 float64 精度
+	priorityEpoch = 1577836800000
+)
+
+// PriorityItem 优先级队列中的元素
+type PriorityItem struct {
+	Value    string
+	Priority int
+}
+
+// PriorityQueue 基于有序集合的优先级队列
+// 分数 = (MaxQueuePriority - 优先级) * 1e12 + 入队时间，分数最小的元素最先出队
+// 元素值在队列中唯一，重复入队会更新其优先级和入队时间
+type PriorityQueue struct {
+	rm  *RedisManager
+	key string
+}
+
+// NewPriorityQueue 创建优先级队列
+func NewPriorityQueue(rm *RedisManager, key string) *PriorityQueue {
+	return &PriorityQueue{
+		rm:  rm,
+		key: key,
+	}
+}
+
+// priorityScore 计算元素的分数
+func priorityScore(priority int, now time.Time) float64 {
+	return float64(MaxQueuePriority-priority)*priorityScale + float64(now.UnixMilli()-priorityEpoch)
+}
+
+// scorePriority 从分数还原优先级
+func scorePriority(score float64) int {
+	return MaxQueuePriority - int(math.Floor(score/priorityScale))
+}
+
+// validPriority 检查优先级是否在允许范围内
+func validPriority(priority int) bool {
+	return priority >= 0 && priority <= MaxQueuePriority
+}
+
+// Push 入队单个元素，返回是否为新元素
+func (pq *PriorityQueue) Push(value string, priority int) CacheResult[bool] {
+	if !validPriority(priority) {
+		return NewCacheError[bool](INVALID_OPERATION, ErrInvalidOperation.WithMessage("priority out of range"))
+	}
+
+	res := pq.rm.ZAdd(pq.key, priorityScore(priority, time.Now()), value)
+	if !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+
+	return NewCacheResult(res.Val == 1)
+}
+
+// PushBatch 通过 Pipeline 批量入队，返回新增的元素数量
+// 同一批次中的元素入队时间相同，同优先级时按元素值的字典序出队
+func (pq *PriorityQueue) PushBatch(items []PriorityItem, batchSize int) CacheResult[int64] {
+	if len(items) == 0 {
+		return NewCacheResult[int64](0)
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	members := make([]redis.Z, len(items))
+	now := time.Now()
+	for i, item := range items {
+		if !validPriority(item.Priority) {
+			return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation.WithMessage("priority out of range"))
+		}
+		members[i] = redis.Z{
+			Score:  priorityScore(item.Priority, now),
+			Member: item.Value,
+		}
+	}
+
+	pipe := pq.rm.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, (len(members)+batchSize-1)/batchSize)
+	for start := 0; start < len(members); start += batchSize {
+		end := start + batchSize
+		if end > len(members) {
+			end = len(members)
+		}
+		cmds = append(cmds, pipe.ZAddMultiple(pq.key, members[start:end]...))
+	}
+
+	if res := pipe.Exec(); !res.IsOK() {
+		return NewCacheError[int64](res.ErrCode, res.Err)
+	}
+
+	var added int64
+	for _, cmd := range cmds {
+		added += cmd.Val()
+	}
+
+	return NewCacheResult(added)
+}
+
+// Pop 原子地弹出最多 count 个优先级最高的元素，队列为空时返回空列表
+func (pq *PriorityQueue) Pop(count int64) CacheResult[[]PriorityItem] {
+	if count <= 0 {
+		count = 1
+	}
+
+	result := pq.rm.EvalScript(ScriptKeyPriorityPop, []string{pq.key}, count)
+	if !result.IsOK() {
+		return NewCacheError[[]PriorityItem](result.ErrCode, result.Err)
+	}
+
+	vals, ok := result.Val.([]interface{})
+	if !ok || len(vals)%2 != 0 {
+		return NewCacheError[[]PriorityItem](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	items := make([]PriorityItem, 0, len(vals)/2)
+	for i := 0; i < len(vals); i += 2 {
+		member, ok1 := vals[i].(string)
+		scoreStr, ok2 := vals[i+1].(string)
+		if !ok1 || !ok2 {
+			return NewCacheError[[]PriorityItem](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+		}
+		score, err := strconv.ParseFloat(scoreStr, 64)
+		if err != nil {
+			return NewCacheError[[]PriorityItem](REDIS_INNER_ERROR, err)
+		}
+		items = append(items, PriorityItem{Value: member, Priority: scorePriority(score)})
+	}
+
+	return NewCacheResult(items)
+}
+
+// PopBlocking 阻塞弹出优先级最高的元素，超时返回 TIMEOUT，context取消返回 INTERRUPTED
+func (pq *PriorityQueue) PopBlocking(ctx context.Context, timeout time.Duration) CacheResult[PriorityItem] {
+	res := pq.rm.BZPopMin(ctx, timeout, pq.key)
+	if !res.IsOK() {
+		return NewCacheError[PriorityItem](res.ErrCode, res.Err)
+	}
+
+	member, _ := res.Val.Member.(string)
+	return NewCacheResult(PriorityItem{Value: member, Priority: scorePriority(res.Val.Score)})
+}
+
+// Len 获取队列长度
+func (pq *PriorityQueue) Len() CacheResult[int64] {
+	return pq.rm.ZCard(pq.key)
+}
+
+// Remove 从队列中移除指定元素
+func (pq *PriorityQueue) Remove(values ...string) CacheResult[int64] {
+	members := make([]interface{}, len(values))
+	for i, v := range values {
+		members[i] = v
+	}
+	return pq.rm.ZRem(pq.key, members...)
+}
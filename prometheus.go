@@ -0,0 +1,297 @@
+package redisx
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultPrometheusNamespace 默认的指标名前缀
+const DefaultPrometheusNamespace = "redisx"
+
+// PrometheusOptions Prometheus 指标输出配置
+type PrometheusOptions struct {
+	Namespace   string            // 指标名前缀，默认 "redisx"
+	ConstLabels map[string]string // 附加到所有指标的固定标签（如 service、instance），不能与内置标签重名
+}
+
+// MetricType 指标类型
+type MetricType string
+
+const (
+	MetricCounter   MetricType = "counter"
+	MetricGauge     MetricType = "gauge"
+	MetricHistogram MetricType = "histogram"
+)
+
+// MetricFamily 一组同名指标，Name 不含命名空间前缀；所有指标另带 MetricLabels 返回的内置标签
+type MetricFamily struct {
+	Name    string
+	Help    string
+	Type    MetricType
+	Labels  []string // 样本的可变标签名
+	Samples []MetricSample
+}
+
+// MetricSample 一个样本，LabelValues 与 MetricFamily.Labels 一一对应
+// 直方图的 Value 为累计耗时（秒），Count 为样本数，Buckets 为各上界（秒）的累积计数
+type MetricSample struct {
+	LabelValues []string
+	Value       float64
+	Count       uint64
+	Buckets     map[float64]uint64
+}
+
+// metricFamilies 所有指标的定义，Metrics 按此顺序输出
+var metricFamilies = []MetricFamily{
+	{Name: "operations_total", Type: MetricCounter, Help: "Total number of operations."},
+	{Name: "errors_total", Type: MetricCounter, Help: "Total number of failed operations."},
+	{Name: "uptime_seconds", Type: MetricGauge, Help: "Seconds since the manager was created."},
+	{Name: "errors_by_class_total", Type: MetricCounter, Help: "Failed commands by error class.", Labels: []string{"class"}},
+	{Name: "up", Type: MetricGauge, Help: "Whether the last health check succeeded."},
+	{Name: "command_errors_total", Type: MetricCounter, Help: "Failed commands by error code.", Labels: []string{"command", "code"}},
+	{Name: "command_duration_seconds", Type: MetricHistogram, Help: "Command latency in seconds.", Labels: []string{"command"}},
+	{Name: "pool_hits_total", Type: MetricCounter, Help: "Number of times a free connection was found in the pool."},
+	{Name: "pool_misses_total", Type: MetricCounter, Help: "Number of times a free connection was not found in the pool."},
+	{Name: "pool_timeouts_total", Type: MetricCounter, Help: "Number of times a wait timeout occurred."},
+	{Name: "pool_wait_total", Type: MetricCounter, Help: "Number of times a connection was waited for."},
+	{Name: "pool_wait_seconds_total", Type: MetricCounter, Help: "Total time spent waiting for a connection."},
+	{Name: "pool_total_connections", Type: MetricGauge, Help: "Number of connections in the pool."},
+	{Name: "pool_idle_connections", Type: MetricGauge, Help: "Number of idle connections in the pool."},
+	{Name: "pool_stale_connections_total", Type: MetricCounter, Help: "Number of stale connections removed from the pool."},
+	{Name: "replica_offset_lag_bytes", Type: MetricGauge, Help: "Replication offset lag of each replica behind its master.", Labels: []string{"master", "replica"}},
+	{Name: "replica_lag_seconds", Type: MetricGauge, Help: "Seconds since the replica last acknowledged its master.", Labels: []string{"master", "replica"}},
+	{Name: "namespace_reads_total", Type: MetricCounter, Help: "Reads by namespace and result.", Labels: []string{"namespace", "result"}},
+}
+
+// MetricFamilies 获取所有指标的定义（不含样本），可用于 prometheus.Collector 的 Describe
+func MetricFamilies() []MetricFamily {
+	return append([]MetricFamily(nil), metricFamilies...)
+}
+
+// MetricLabels 所有指标共有的内置标签
+func (rm *RedisManager) MetricLabels() map[string]string {
+	return map[string]string{"mode": string(rm.config.Mode)}
+}
+
+// Metrics 采集所有指标，顺序与 MetricFamilies 相同；PrometheusHandler 和 redisxprom 子包的 Collector 都基于该结果输出
+func (rm *RedisManager) Metrics() []MetricFamily {
+	families := MetricFamilies()
+	byName := make(map[string]*MetricFamily, len(families))
+	for i := range families {
+		byName[families[i].Name] = &families[i]
+	}
+	add := func(name string, value float64, labels ...string) {
+		f := byName[name]
+		f.Samples = append(f.Samples, MetricSample{LabelValues: labels, Value: value})
+	}
+
+	total, errors, uptime := rm.stats.GetStats()
+	add("operations_total", float64(total))
+	add("errors_total", float64(errors))
+	add("uptime_seconds", uptime.Seconds())
+
+	classes := rm.stats.ErrorClasses()
+	for _, class := range errorClasses {
+		add("errors_by_class_total", float64(classes[class]), string(class))
+	}
+
+	up := 0.0
+	if rm.IsHealthy() {
+		up = 1
+	}
+	add("up", up)
+
+	commands := rm.CommandMetrics()
+	for _, c := range commands {
+		codes := make([]ErrorCode, 0, len(c.Errors))
+		for code := range c.Errors {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+		for _, code := range codes {
+			add("command_errors_total", float64(c.Errors[code]), c.Command, code.String())
+		}
+	}
+
+	durations := byName["command_duration_seconds"]
+	for _, c := range commands {
+		buckets := make(map[float64]uint64, len(c.Bounds))
+		for i, bound := range c.Bounds {
+			buckets[bound] = uint64(c.Buckets[i])
+		}
+		durations.Samples = append(durations.Samples, MetricSample{
+			LabelValues: []string{c.Command},
+			Value:       c.Sum.Seconds(),
+			Count:       uint64(c.Count),
+			Buckets:     buckets,
+		})
+	}
+
+	pool := rm.PoolStats()
+	add("pool_hits_total", float64(pool.Hits))
+	add("pool_misses_total", float64(pool.Misses))
+	add("pool_timeouts_total", float64(pool.Timeouts))
+	add("pool_wait_total", float64(pool.WaitCount))
+	add("pool_wait_seconds_total", pool.WaitDuration.Seconds())
+	add("pool_total_connections", float64(pool.TotalConns))
+	add("pool_idle_connections", float64(pool.IdleConns))
+	add("pool_stale_connections_total", float64(pool.StaleConns))
+
+	for _, r := range rm.ReplicationLag() {
+		add("replica_offset_lag_bytes", float64(r.OffsetLag), r.Master, r.Replica)
+		add("replica_lag_seconds", r.Lag.Seconds(), r.Master, r.Replica)
+	}
+
+	namespaces := rm.stats.NamespaceStats()
+	prefixes := make([]string, 0, len(namespaces))
+	for prefix := range namespaces {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		ns := namespaces[prefix]
+		add("namespace_reads_total", float64(ns.Hits), prefix, "hit")
+		add("namespace_reads_total", float64(ns.Misses), prefix, "miss")
+		add("namespace_reads_total", float64(ns.Errors), prefix, "error")
+	}
+	return families
+}
+
+var (
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Validate 检查命名空间和固定标签名是否合法，固定标签不能与内置标签（mode、le 及各指标的可变标签）重名
+func (o *PrometheusOptions) Validate() error {
+	if o.Namespace != "" && !metricNamePattern.MatchString(o.Namespace) {
+		return ErrInvalidConfig.WithMessage("invalid prometheus namespace: " + o.Namespace)
+	}
+
+	reserved := map[string]bool{"mode": true, "le": true}
+	for _, f := range metricFamilies {
+		for _, label := range f.Labels {
+			reserved[label] = true
+		}
+	}
+	for name := range o.ConstLabels {
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return ErrInvalidConfig.WithMessage("invalid prometheus label name: " + name)
+		}
+		if reserved[name] {
+			return ErrInvalidConfig.WithMessage("prometheus const label conflicts with built-in label: " + name)
+		}
+	}
+	return nil
+}
+
+// PrometheusHandler 返回以 Prometheus 文本格式输出指标的 http.Handler，可直接挂载到 /metrics
+// 指标包括操作总数、按错误码分类的命令失败数、命令耗时直方图、连接池状态、健康状态和各命名空间的读取计数
+// 这是独立的抓取端点，不依赖 prometheus/client_golang；需要注册到应用已有的 Registry 时使用 redisxprom 子包的 Collector
+// 应用已有 /metrics 时应挂载到单独的路径（如 /metrics/redis）并在抓取配置中单独添加，多个实例应以 ConstLabels 区分
+// ConstLabels 不合法时记录错误日志，抓取返回 500
+func (rm *RedisManager) PrometheusHandler(opts *PrometheusOptions) http.Handler {
+	o := PrometheusOptions{Namespace: DefaultPrometheusNamespace}
+	if opts != nil {
+		if opts.Namespace != "" {
+			o.Namespace = opts.Namespace
+		}
+		o.ConstLabels = opts.ConstLabels
+	}
+	if err := o.Validate(); err != nil {
+		rm.Logger().Error("Redis prometheus handler disabled", "error", err)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		rm.writePrometheus(bw, o)
+		_ = bw.Flush()
+	})
+}
+
+// promWriter 输出 Prometheus 文本格式
+type promWriter struct {
+	w      *bufio.Writer
+	prefix string
+	labels []string // 已格式化的固定标签 name="value"
+}
+
+// header 输出指标的 HELP 和 TYPE 行
+func (p *promWriter) header(name, typ, help string) {
+	fmt.Fprintf(p.w, "# HELP %s_%s %s\n# TYPE %s_%s %s\n", p.prefix, name, help, p.prefix, name, typ)
+}
+
+// sample 输出一个样本，labels 为交替的标签名和值
+func (p *promWriter) sample(name string, value float64, labels ...string) {
+	all := append([]string(nil), p.labels...)
+	for i := 0; i+1 < len(labels); i += 2 {
+		all = append(all, labels[i]+`="`+escapeLabelValue(labels[i+1])+`"`)
+	}
+
+	p.w.WriteString(p.prefix + "_" + name)
+	if len(all) > 0 {
+		p.w.WriteString("{" + strings.Join(all, ",") + "}")
+	}
+	p.w.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+// escapeLabelValue 转义标签值中的反斜杠、双引号和换行
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// writePrometheus 输出所有指标，没有样本的指标不输出
+func (rm *RedisManager) writePrometheus(w *bufio.Writer, o PrometheusOptions) {
+	p := &promWriter{w: w, prefix: o.Namespace}
+
+	constLabels := rm.MetricLabels()
+	for name, value := range o.ConstLabels {
+		constLabels[name] = value
+	}
+	names := make([]string, 0, len(constLabels))
+	for name := range constLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p.labels = append(p.labels, name+`="`+escapeLabelValue(constLabels[name])+`"`)
+	}
+
+	for _, f := range rm.Metrics() {
+		if len(f.Samples) == 0 {
+			continue
+		}
+		p.header(f.Name, string(f.Type), f.Help)
+		for _, s := range f.Samples {
+			labels := make([]string, 0, 2*len(f.Labels)+2)
+			for i, name := range f.Labels {
+				labels = append(labels, name, s.LabelValues[i])
+			}
+			if f.Type != MetricHistogram {
+				p.sample(f.Name, s.Value, labels...)
+				continue
+			}
+
+			bounds := make([]float64, 0, len(s.Buckets))
+			for bound := range s.Buckets {
+				bounds = append(bounds, bound)
+			}
+			sort.Float64s(bounds)
+			for _, bound := range bounds {
+				p.sample(f.Name+"_bucket", float64(s.Buckets[bound]), append(labels, "le", strconv.FormatFloat(bound, 'g', -1, 64))...)
+			}
+			p.sample(f.Name+"_bucket", float64(s.Count), append(labels, "le", "+Inf")...)
+			p.sample(f.Name+"_sum", s.Value, labels...)
+			p.sample(f.Name+"_count", float64(s.Count), labels...)
+		}
+	}
+}
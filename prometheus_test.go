@@ -0,0 +1,46 @@
+package redisx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusOptionsValidate(t *testing.T) {
+	for _, name := range []string{"mode", "le", "command", "__name__", "bad-name"} {
+		o := PrometheusOptions{ConstLabels: map[string]string{name: "x"}}
+		if err := o.Validate(); err == nil {
+			t.Errorf("const label %q accepted", name)
+		}
+	}
+	o := PrometheusOptions{Namespace: "app_redis", ConstLabels: map[string]string{"service": "api"}}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	rm := newTestManager(t, newFakeServer(t, nil).addr, nil)
+	rm.GetS("key")
+
+	w := httptest.NewRecorder()
+	rm.PrometheusHandler(&PrometheusOptions{ConstLabels: map[string]string{"service": "api"}}).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`redisx_operations_total{mode="single",service="api"}`,
+		`redisx_command_duration_seconds_bucket{mode="single",service="api",command="get",le="+Inf"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("output missing %s", want)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	rm.PrometheusHandler(&PrometheusOptions{ConstLabels: map[string]string{"mode": "x"}}).
+		ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("conflicting const label served with status %d", w.Code)
+	}
+}
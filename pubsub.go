@@ -0,0 +1,221 @@
+package redisx
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MessageHandler 订阅消息处理函数
+// 返回的错误只会被记录，不会中断订阅
+type MessageHandler func(ctx context.Context, msg *redis.Message) error
+
+// Subscription 订阅句柄，后台协程持续接收消息并交给处理函数
+type Subscription struct {
+	rm       *RedisManager
+	pubsub   *redis.PubSub
+	handler  MessageHandler
+	cancel   context.CancelFunc
+	done     chan struct{}
+	closeErr error
+	handling atomic.Bool // handler 正在执行
+}
+
+// ==== Publish ====
+
+// Publish 发布消息，返回收到消息的订阅者数量
+func (rm *RedisManager) Publish(channel string, message interface{}) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().Publish(rm.ctx, channel, message).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// SPublish 向分片频道发布消息（Redis 7.0+）
+// 集群模式下消息只在频道所属槽的分片内传播，而不是广播到所有节点
+func (rm *RedisManager) SPublish(channel string, message interface{}) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().SPublish(rm.ctx, channel, message).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ==== Subscribe ====
+
+// Subscribe 订阅频道，消息在后台协程中交给 handler 处理
+// ctx 取消或调用 Subscription.Close 时停止订阅
+func (rm *RedisManager) Subscribe(ctx context.Context, handler MessageHandler, channels ...string) CacheResult[*Subscription] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*Subscription](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	return rm.startSubscription(ctx, rm.activeClient().Subscribe(ctx, channels...), handler)
+}
+
+// PSubscribe 按 glob 模式订阅频道（如 "events:*"）
+// handler 收到的 msg.Pattern 为匹配的模式，msg.Channel 为消息实际发布到的频道
+func (rm *RedisManager) PSubscribe(ctx context.Context, handler MessageHandler, patterns ...string) CacheResult[*Subscription] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*Subscription](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	return rm.startSubscription(ctx, rm.activeClient().PSubscribe(ctx, patterns...), handler)
+}
+
+// SSubscribe 订阅分片频道（Redis 7.0+）
+// 集群模式下同一次订阅的多个频道需位于同一个槽，可使用哈希标签（如 "{order}:created"）
+func (rm *RedisManager) SSubscribe(ctx context.Context, handler MessageHandler, channels ...string) CacheResult[*Subscription] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*Subscription](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	return rm.startSubscription(ctx, rm.activeClient().SSubscribe(ctx, channels...), handler)
+}
+
+// startSubscription 确认订阅成功后启动消息处理协程
+func (rm *RedisManager) startSubscription(ctx context.Context, pubsub *redis.PubSub, handler MessageHandler) CacheResult[*Subscription] {
+	if handler == nil {
+		_ = pubsub.Close()
+		return NewCacheError[*Subscription](INVALID_OPERATION, ErrInvalidOperation.WithMessage("message handler is nil"))
+	}
+
+	// 等待服务端的订阅确认，尽早暴露连接或参数错误
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		rm.stats.IncrError()
+		return NewCacheError[*Subscription](REDIS_INNER_ERROR, err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		rm:      rm,
+		pubsub:  pubsub,
+		handler: handler,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go sub.loop(subCtx)
+	// Shutdown 等待正在执行的 handler 返回
+	rm.addWorker(sub, func() {
+		sub.cancel()
+		<-sub.done
+	})
+
+	return NewCacheResult(sub)
+}
+
+// loop 消息处理循环
+func (s *Subscription) loop(ctx context.Context) {
+	defer close(s.done)
+	defer func() {
+		s.closeErr = s.pubsub.Close()
+		s.rm.removeWorker(s)
+	}()
+
+	ch := s.pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			s.handling.Store(true)
+			err := s.handler(ctx, msg)
+			s.handling.Store(false)
+			if err != nil {
+				s.rm.Logger().Warn("Redis pubsub handler failed", "channel", msg.Channel, "error", err)
+			}
+		}
+	}
+}
+
+// Close 取消订阅并等待消息处理协程退出
+// handler 正在执行时（包括在 handler 内调用）只取消订阅、不等待，避免 handler 内调用时死锁，订阅在 handler 返回后关闭
+func (s *Subscription) Close() error {
+	s.cancel()
+	if s.handling.Load() {
+		return nil
+	}
+	<-s.done
+	return s.closeErr
+}
+
+// ==== Typed Pub/Sub ====
+
+// PoisonMessageHandler 消息无法解码时的处理函数
+type PoisonMessageHandler func(ctx context.Context, msg *redis.Message, err error)
+
+// PublishTyped 使用管理器的编解码器序列化 v 后发布
+func PublishTyped[T any](rm *RedisManager, channel string, v T) CacheResult[int64] {
+	data, err := rm.Codec().Marshal(v)
+	if err != nil {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation.WithError(err))
+	}
+
+	return rm.Publish(channel, data)
+}
+
+// SubscribeTyped 订阅频道，消息使用管理器的编解码器解码为 T 后交给 handler
+// 解码失败的消息（毒消息）交给 onPoison 处理后丢弃，onPoison 为 nil 时只记录日志
+func SubscribeTyped[T any](ctx context.Context, rm *RedisManager, handler func(ctx context.Context, v T) error,
+	onPoison PoisonMessageHandler, channels ...string) CacheResult[*Subscription] {
+	return rm.Subscribe(ctx, typedHandler(rm, handler, onPoison), channels...)
+}
+
+// PSubscribeTyped 按模式订阅频道，消息解码规则同 SubscribeTyped
+func PSubscribeTyped[T any](ctx context.Context, rm *RedisManager, handler func(ctx context.Context, v T) error,
+	onPoison PoisonMessageHandler, patterns ...string) CacheResult[*Subscription] {
+	return rm.PSubscribe(ctx, typedHandler(rm, handler, onPoison), patterns...)
+}
+
+// typedHandler 将类型化处理函数包装为 MessageHandler
+func typedHandler[T any](rm *RedisManager, handler func(ctx context.Context, v T) error, onPoison PoisonMessageHandler) MessageHandler {
+	if handler == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, msg *redis.Message) error {
+		var v T
+		if err := rm.Codec().Unmarshal([]byte(msg.Payload), &v); err != nil {
+			if onPoison != nil {
+				onPoison(ctx, msg, err)
+			} else {
+				rm.Logger().Warn("Redis pubsub dropped undecodable message", "channel", msg.Channel, "error", err)
+			}
+			return nil
+		}
+		return handler(ctx, v)
+	}
+}
@@ -0,0 +1,47 @@
+package redisx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// handler 内关闭自己的订阅不会死锁
+func TestSubscriptionCloseFromHandler(t *testing.T) {
+	s := newFakeServer(t, func(args []string) string {
+		if args[0] == "subscribe" {
+			return ">3\r\n$9\r\nsubscribe\r\n$6\r\nevents\r\n:1\r\n" +
+				">3\r\n$7\r\nmessage\r\n$6\r\nevents\r\n$5\r\nhello\r\n"
+		}
+		return ""
+	})
+	rm := newTestManager(t, s.addr, nil)
+
+	subs := make(chan *Subscription, 1)
+	closed := make(chan struct{})
+	res := rm.Subscribe(context.Background(), func(ctx context.Context, msg *redis.Message) error {
+		_ = (<-subs).Close()
+		close(closed)
+		return nil
+	}, "events")
+	if !res.IsOK() {
+		t.Fatal(res.Err)
+	}
+	subs <- res.Val
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close called from handler did not return")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- res.Val.Close() }()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription did not stop after handler returned")
+	}
+}
@@ -0,0 +1,59 @@
+package redisx
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RateLimiter 基于Redis的分布式限流器
+type RateLimiter struct {
+	rm *RedisManager
+}
+
+// RateLimitResult 限流判断结果
+type RateLimitResult struct {
+	Allowed   bool      // 本次请求是否被允许
+	Remaining int64     // 当前窗口内剩余可用次数
+	ResetAt   time.Time // 窗口内最早一条记录过期、额度恢复的时间
+}
+
+// NewRateLimiter 创建限流器
+func NewRateLimiter(rm *RedisManager) *RateLimiter {
+	return &RateLimiter{rm: rm}
+}
+
+// AllowSlidingWindow 滑动窗口限流
+// 在任意长度为 window 的时间窗口内最多允许 limit 次请求；被拒绝的请求不计入窗口
+// 时间戳取自调用方本地时钟，多实例部署时需保证时钟基本同步
+func (rl *RateLimiter) AllowSlidingWindow(key string, limit int64, window time.Duration) CacheResult[RateLimitResult] {
+	if limit <= 0 || window <= 0 {
+		return NewCacheError[RateLimitResult](INVALID_OPERATION, ErrInvalidOperation.WithMessage("limit and window must be positive"))
+	}
+
+	now := time.Now().UnixMilli()
+	member := fmt.Sprintf("%d-%d", now, rand.Int63())
+
+	result := rl.rm.EvalScript(ScriptKeySlidingWindow, []string{key}, now, window.Milliseconds(), limit, member)
+	if !result.IsOK() {
+		return NewCacheError[RateLimitResult](result.ErrCode, result.Err)
+	}
+
+	vals, ok := result.Val.([]interface{})
+	if !ok || len(vals) != 3 {
+		return NewCacheError[RateLimitResult](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	allowed, ok1 := vals[0].(int64)
+	remaining, ok2 := vals[1].(int64)
+	reset, ok3 := vals[2].(int64)
+	if !ok1 || !ok2 || !ok3 {
+		return NewCacheError[RateLimitResult](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(RateLimitResult{
+		Allowed:   allowed == 1,
+		Remaining: remaining,
+		ResetAt:   time.UnixMilli(reset),
+	})
+}
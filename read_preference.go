@@ -0,0 +1,186 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ReadPreference 单次读操作的节点选择，只在集群和哨兵模式下生效，其他模式下始终读取唯一的节点
+type ReadPreference int
+
+const (
+	ReadDefault ReadPreference = iota // 按全局配置（ReadOnly、RouteRandomly、RouteByLatency）路由
+	ReadPrimary                       // 读取键所在的主节点，用于对一致性敏感的读
+	ReadReplica                       // 读取键所在的从节点，没有可用的从节点时读取主节点；启用 RouteRandomly/RouteByLatency 时按其策略在主从节点中选择
+)
+
+// ReadView 指定读取节点的只读操作，由 ReadFrom 创建
+// 直接在选定的节点上执行命令：不经过热点键本地缓存、读合并、自动流水线和降级快照，也不跟随 MOVED 重定向
+type ReadView struct {
+	rm   *RedisManager
+	pref ReadPreference
+}
+
+// ReadFrom 返回按 pref 选择节点的读操作，如 rm.ReadFrom(ReadPrimary).GetS(key)
+func (rm *RedisManager) ReadFrom(pref ReadPreference) *ReadView {
+	return &ReadView{rm: rm, pref: pref}
+}
+
+// readClient ReadView 使用的读命令
+type readClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	HGet(ctx context.Context, key, field string) *redis.StringCmd
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	TTL(ctx context.Context, key string) *redis.DurationCmd
+}
+
+// client 选择执行 key 读操作的节点
+func (v *ReadView) client(key string) (readClient, error) {
+	client := v.rm.activeClient()
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return client, nil
+	}
+
+	switch v.pref {
+	case ReadPrimary:
+		return cluster.MasterForKey(v.rm.ctx, key)
+	case ReadReplica:
+		return cluster.SlaveForKey(v.rm.ctx, key)
+	default:
+		return cluster, nil
+	}
+}
+
+// GetS 获取字符串值
+func (v *ReadView) GetS(key string) CacheResult[string] {
+	v.rm.stats.IncrTotal()
+
+	if !v.rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer v.rm.release()
+
+	client, err := v.client(key)
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	val, err := client.Get(v.rm.ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// GetB 获取字节数组值
+func (v *ReadView) GetB(key string) CacheResult[[]byte] {
+	v.rm.stats.IncrTotal()
+
+	if !v.rm.acquire() {
+		return NewCacheError[[]byte](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer v.rm.release()
+
+	client, err := v.client(key)
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[[]byte](REDIS_INNER_ERROR, err)
+	}
+
+	val, err := client.Get(v.rm.ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[[]byte](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[[]byte](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// HGetS 获取哈希字段的字符串值
+func (v *ReadView) HGetS(key, field string) CacheResult[string] {
+	v.rm.stats.IncrTotal()
+
+	if !v.rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer v.rm.release()
+
+	client, err := v.client(key)
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	val, err := client.HGet(v.rm.ctx, key, field).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// HGetAll 获取哈希的所有字段，键不存在时返回空 map
+func (v *ReadView) HGetAll(key string) CacheResult[map[string]string] {
+	v.rm.stats.IncrTotal()
+
+	if !v.rm.acquire() {
+		return NewCacheError[map[string]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer v.rm.release()
+
+	client, err := v.client(key)
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[map[string]string](REDIS_INNER_ERROR, err)
+	}
+
+	val, err := client.HGetAll(v.rm.ctx, key).Result()
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[map[string]string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TTL 获取键的剩余生存时间，键不存在时返回 KEY_NOT_FOUND
+func (v *ReadView) TTL(key string) CacheResult[time.Duration] {
+	v.rm.stats.IncrTotal()
+
+	if !v.rm.acquire() {
+		return NewCacheError[time.Duration](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer v.rm.release()
+
+	client, err := v.client(key)
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[time.Duration](REDIS_INNER_ERROR, err)
+	}
+
+	val, err := client.TTL(v.rm.ctx, key).Result()
+	if err != nil {
+		v.rm.stats.IncrError()
+		return NewCacheError[time.Duration](REDIS_INNER_ERROR, err)
+	}
+
+	if val == -2*time.Second {
+		return NewCacheError[time.Duration](KEY_NOT_FOUND, ErrKeyNotFound)
+	}
+
+	return NewCacheResult(val)
+}
@@ -0,0 +1,75 @@
+// Package redisxprom 以 prometheus.Collector 的形式导出 redisx 的统计信息，可注册到应用已有的 Registry
+// 单独作为一个模块发布，redisx 核心模块不依赖 prometheus/client_golang
+package redisxprom
+
+import (
+	"go-redisx"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector 基于 RedisManager.Metrics 的指标采集器，每次抓取时读取当前统计
+type Collector struct {
+	rm    *redisx.RedisManager
+	descs map[string]*prometheus.Desc
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector 创建指标采集器，opts 与 PrometheusHandler 相同，为 nil 时使用默认命名空间
+// 命名空间或固定标签不合法（包括与 mode 等内置标签重名）时返回 INVALID_CONFIG
+func NewCollector(rm *redisx.RedisManager, opts *redisx.PrometheusOptions) (*Collector, error) {
+	o := redisx.PrometheusOptions{Namespace: redisx.DefaultPrometheusNamespace}
+	if opts != nil {
+		if opts.Namespace != "" {
+			o.Namespace = opts.Namespace
+		}
+		o.ConstLabels = opts.ConstLabels
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	constLabels := prometheus.Labels(rm.MetricLabels())
+	for name, value := range o.ConstLabels {
+		constLabels[name] = value
+	}
+
+	c := &Collector{rm: rm, descs: make(map[string]*prometheus.Desc)}
+	for _, f := range redisx.MetricFamilies() {
+		c.descs[f.Name] = prometheus.NewDesc(o.Namespace+"_"+f.Name, f.Help, f.Labels, constLabels)
+	}
+	return c, nil
+}
+
+// Describe 实现 prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+// Collect 实现 prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, f := range c.rm.Metrics() {
+		desc := c.descs[f.Name]
+		for _, s := range f.Samples {
+			var (
+				m   prometheus.Metric
+				err error
+			)
+			switch f.Type {
+			case redisx.MetricCounter:
+				m, err = prometheus.NewConstMetric(desc, prometheus.CounterValue, s.Value, s.LabelValues...)
+			case redisx.MetricGauge:
+				m, err = prometheus.NewConstMetric(desc, prometheus.GaugeValue, s.Value, s.LabelValues...)
+			case redisx.MetricHistogram:
+				m, err = prometheus.NewConstHistogram(desc, s.Count, s.Value, s.Buckets, s.LabelValues...)
+			}
+			if err != nil {
+				m = prometheus.NewInvalidMetric(desc, err)
+			}
+			ch <- m
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package redisxprom
+
+import (
+	"testing"
+
+	"go-redisx"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newTestManager(t *testing.T) *redisx.RedisManager {
+	t.Helper()
+	rm, err := redisx.NewRedisManager(&redisx.RedisConfig{
+		Mode:   redisx.ModeSingle,
+		Single: &redisx.SingleConfig{Addr: "127.0.0.1:1"},
+		Common: redisx.CommonConfig{LazyConnect: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = rm.Close() })
+	return rm
+}
+
+func TestCollector(t *testing.T) {
+	c, err := NewCollector(newTestManager(t), &redisx.PrometheusOptions{ConstLabels: map[string]string{"service": "api"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range families {
+		if f.GetName() != "redisx_up" {
+			continue
+		}
+		found = true
+		labels := map[string]string{}
+		for _, l := range f.GetMetric()[0].GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["mode"] != "single" || labels["service"] != "api" {
+			t.Errorf("redisx_up labels = %v", labels)
+		}
+	}
+	if !found {
+		t.Fatal("redisx_up not collected")
+	}
+}
+
+func TestCollectorConstLabelConflict(t *testing.T) {
+	if _, err := NewCollector(newTestManager(t), &redisx.PrometheusOptions{ConstLabels: map[string]string{"mode": "x"}}); err == nil {
+		t.Fatal("const label mode accepted")
+	}
+}
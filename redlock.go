@@ -0,0 +1,177 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RedLockOptions RedLock 选项
+type RedLockOptions struct {
+	TTL           time.Duration // 锁的过期时间，默认 30s
+	DriftFactor   float64       // 时钟漂移系数，有效期扣除 TTL*DriftFactor+2ms，默认 0.01
+	RetryInterval time.Duration // Lock 阻塞获取时的基础重试间隔，实际间隔在 [RetryInterval, 2*RetryInterval) 内随机，默认 200ms
+}
+
+// RedLock 跨多个独立 Redis 实例的分布式锁（Redlock 算法）
+// 在多数（N/2+1）实例上加锁成功且扣除耗时和时钟漂移后仍有剩余有效期才视为获取成功，
+// 单个实例故障不影响锁的安全性；各实例应为相互独立的部署而非同一集群的节点
+type RedLock struct {
+	managers []*RedisManager
+	key      string
+	token    string
+	opts     RedLockOptions
+
+	mutex      sync.Mutex
+	validUntil time.Time
+}
+
+// NewRedLock 创建 RedLock，managers 为各独立实例的管理器
+func NewRedLock(managers []*RedisManager, key string, opts *RedLockOptions) (*RedLock, error) {
+	if len(managers) == 0 {
+		return nil, ErrInvalidConfig.WithMessage("redlock requires at least one redis manager")
+	}
+
+	var o RedLockOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.TTL <= 0 {
+		o.TTL = 30 * time.Second
+	}
+	if o.DriftFactor <= 0 {
+		o.DriftFactor = 0.01
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 200 * time.Millisecond
+	}
+
+	return &RedLock{
+		managers: managers,
+		key:      key,
+		token:    newTaskID(),
+		opts:     o,
+	}, nil
+}
+
+// quorum 获取锁所需的最少实例数
+func (rl *RedLock) quorum() int {
+	return len(rl.managers)/2 + 1
+}
+
+// drift 时钟漂移余量
+func (rl *RedLock) drift() time.Duration {
+	return time.Duration(float64(rl.opts.TTL)*rl.opts.DriftFactor) + 2*time.Millisecond
+}
+
+// forEach 并发地在所有实例上执行 fn，返回成功的实例数
+func (rl *RedLock) forEach(fn func(rm *RedisManager) bool) int {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	succeeded := 0
+
+	for _, rm := range rl.managers {
+		wg.Add(1)
+		go func(rm *RedisManager) {
+			defer wg.Done()
+			if fn(rm) {
+				mutex.Lock()
+				succeeded++
+				mutex.Unlock()
+			}
+		}(rm)
+	}
+	wg.Wait()
+
+	return succeeded
+}
+
+// TryLock 尝试一次获取锁，未达到多数或有效期不足时释放已获得的部分并返回 false
+// 单个实例的错误视为该实例加锁失败，不会作为错误返回
+func (rl *RedLock) TryLock() CacheResult[bool] {
+	start := time.Now()
+	acquired := rl.forEach(func(rm *RedisManager) bool {
+		res := rm.TryLock(rl.key, rl.token, rl.opts.TTL)
+		return res.IsOK() && res.Val
+	})
+
+	validity := rl.opts.TTL - time.Since(start) - rl.drift()
+	if acquired >= rl.quorum() && validity > 0 {
+		rl.mutex.Lock()
+		rl.validUntil = start.Add(rl.opts.TTL - rl.drift())
+		rl.mutex.Unlock()
+		return NewCacheResult(true)
+	}
+
+	rl.release()
+	return NewCacheResult(false)
+}
+
+// Lock 阻塞获取锁，每次失败后随机等待以减少多个客户端同时重试造成的冲突
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (rl *RedLock) Lock(ctx context.Context) CacheResult[bool] {
+	for {
+		res := rl.TryLock()
+		if res.Val {
+			return res
+		}
+
+		delay := rl.opts.RetryInterval + time.Duration(rand.Int63n(int64(rl.opts.RetryInterval)))
+		if !sleepContext(ctx, delay) {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NewCacheError[bool](TIMEOUT, ErrOperationTimeout.WithError(ctx.Err()))
+			}
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(ctx.Err()))
+		}
+	}
+}
+
+// Extend 在多数实例上将锁的过期时间重置为 TTL，未达到多数时返回 false（锁应视为已丢失）
+func (rl *RedLock) Extend() CacheResult[bool] {
+	start := time.Now()
+	extended := rl.forEach(func(rm *RedisManager) bool {
+		res := rm.RenewLock(rl.key, rl.token, rl.opts.TTL)
+		return res.IsOK() && res.Val
+	})
+
+	validity := rl.opts.TTL - time.Since(start) - rl.drift()
+	if extended >= rl.quorum() && validity > 0 {
+		rl.mutex.Lock()
+		rl.validUntil = start.Add(rl.opts.TTL - rl.drift())
+		rl.mutex.Unlock()
+		return NewCacheResult(true)
+	}
+
+	return NewCacheResult(false)
+}
+
+// Unlock 在所有实例上释放锁，返回是否在多数实例上释放成功
+func (rl *RedLock) Unlock() CacheResult[bool] {
+	released := rl.release()
+	return NewCacheResult(released >= rl.quorum())
+}
+
+// release 在所有实例上释放锁，返回释放成功的实例数
+func (rl *RedLock) release() int {
+	rl.mutex.Lock()
+	rl.validUntil = time.Time{}
+	rl.mutex.Unlock()
+
+	return rl.forEach(func(rm *RedisManager) bool {
+		res := rm.ReleaseLock(rl.key, rl.token)
+		return res.IsOK() && res.Val
+	})
+}
+
+// Validity 锁的剩余有效期（已扣除时钟漂移），未持有锁时为0
+func (rl *RedLock) Validity() time.Duration {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if remaining := time.Until(rl.validUntil); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
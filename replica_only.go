@@ -0,0 +1,78 @@
+package redisx
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// readOnlyCommands 只读副本模式下允许执行的命令：读命令，以及连接握手、健康检查和脚本预加载使用的命令
+var readOnlyCommands = map[string]bool{
+	// 读命令
+	"get": true, "mget": true, "getrange": true, "strlen": true, "exists": true, "type": true,
+	"ttl": true, "pttl": true, "expiretime": true, "pexpiretime": true, "dump": true, "object": true,
+	"hget": true, "hmget": true, "hgetall": true, "hkeys": true, "hvals": true, "hlen": true,
+	"hexists": true, "hstrlen": true, "hscan": true, "hrandfield": true,
+	"lrange": true, "lindex": true, "llen": true, "lpos": true,
+	"smembers": true, "sismember": true, "smismember": true, "scard": true, "sscan": true,
+	"sinter": true, "sintercard": true, "sunion": true, "sdiff": true, "srandmember": true,
+	"zrange": true, "zrangebyscore": true, "zrevrange": true, "zrevrangebyscore": true,
+	"zrangebylex": true, "zrevrangebylex": true, "zscore": true, "zmscore": true, "zrank": true, "zrevrank": true,
+	"zcard": true, "zcount": true, "zlexcount": true, "zscan": true, "zrandmember": true,
+	"zinter": true, "zunion": true, "zdiff": true,
+	"pfcount": true, "getbit": true, "bitcount": true, "bitpos": true, "bitfield_ro": true,
+	"geopos": true, "geodist": true, "geohash": true, "geosearch": true, "georadius_ro": true, "georadiusbymember_ro": true,
+	"xrange": true, "xrevrange": true, "xlen": true, "xinfo": true, "xread": true, "xpending": true,
+	"scan": true, "keys": true, "randomkey": true, "dbsize": true, "memory": true,
+	"evalsha_ro": true, "eval_ro": true, "fcall_ro": true,
+	"json.get": true, "json.mget": true, "json.type": true, "json.strlen": true, "json.arrlen": true,
+	"json.objkeys": true, "json.objlen": true,
+	"ft.search": true, "ft.aggregate": true, "ft.info": true,
+	"bf.exists": true, "bf.mexists": true, "bf.info": true, "cf.exists": true, "cf.count": true,
+	"cms.query": true, "topk.query": true, "topk.list": true,
+	// 连接、事务和诊断命令
+	"ping": true, "echo": true, "info": true, "time": true, "command": true, "role": true,
+	"hello": true, "auth": true, "select": true, "client": true, "readonly": true, "quit": true,
+	"multi": true, "exec": true, "discard": true, "watch": true, "unwatch": true,
+	"script": true, "slowlog": true, "latency": true,
+}
+
+// replicaOnly 是否为只读副本模式：通过哨兵发现从节点，所有命令只发送到从节点
+func (c *RedisConfig) replicaOnly() bool {
+	return c.Mode == ModeMasterSlave && c.MasterSlave != nil &&
+		c.MasterSlave.Sentinel != nil && c.MasterSlave.Sentinel.Enabled && c.MasterSlave.Sentinel.ReplicaOnly
+}
+
+// replicaOnlyHook 只读副本模式的 go-redis 钩子，在发送前拒绝写命令，避免依赖从节点的 replica-read-only 配置
+// 流水线中包含写命令时整条流水线被拒绝
+type replicaOnlyHook struct{}
+
+func (h replicaOnlyHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h replicaOnlyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !readOnlyCommands[cmd.Name()] {
+			err := ErrInvalidOperation.WithMessage("write command rejected in replica-only mode: " + cmd.Name())
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h replicaOnlyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if !readOnlyCommands[cmd.Name()] {
+				err := ErrInvalidOperation.WithMessage("write command rejected in replica-only mode: " + cmd.Name())
+				for _, cmd := range cmds {
+					cmd.SetErr(err)
+				}
+				return err
+			}
+		}
+		return next(ctx, cmds)
+	}
+}
@@ -0,0 +1,175 @@
+package redisx
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ReplicationLagConfig 复制延迟监控配置，适用于哨兵和集群模式
+type ReplicationLagConfig struct {
+	Interval     time.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`             // 检测间隔，默认 10s
+	MaxOffsetLag int64         `json:"max_offset_lag,omitempty" yaml:"max_offset_lag,omitempty"` // 从节点落后主节点的复制偏移量（字节）超过该值时触发回调，0 表示不按偏移量判断
+	MaxLag       time.Duration `json:"max_lag,omitempty" yaml:"max_lag,omitempty"`               // 从节点距上次确认的时间超过该值时触发回调，0 表示不按时间判断
+}
+
+// setDefaults 设置默认值
+func (c *ReplicationLagConfig) setDefaults() {
+	if c.Interval <= 0 {
+		c.Interval = 10 * time.Second
+	}
+}
+
+// ReplicaLag 从节点的复制延迟
+type ReplicaLag struct {
+	Master    string        // 主节点地址
+	Replica   string        // 从节点地址
+	State     string        // 复制状态（online、wait_bgsave 等）
+	OffsetLag int64         // 落后主节点的复制偏移量（字节）
+	Lag       time.Duration // 距从节点上次确认的时间（秒级精度）
+	Exceeded  bool          // 是否超过配置的阈值
+}
+
+// ReplicationLagHandler 从节点复制延迟超过阈值时的回调
+type ReplicationLagHandler func(lag ReplicaLag)
+
+// replicationMonitor 复制延迟的检测结果和回调
+type replicationMonitor struct {
+	mu       sync.RWMutex
+	config   ReplicationLagConfig
+	lags     []ReplicaLag
+	handlers []ReplicationLagHandler
+}
+
+// ReplicationLag 获取最近一次检测的各从节点复制延迟，未启用监控时返回 nil
+func (rm *RedisManager) ReplicationLag() []ReplicaLag {
+	if rm.replication == nil {
+		return nil
+	}
+	rm.replication.mu.RLock()
+	defer rm.replication.mu.RUnlock()
+	return append([]ReplicaLag(nil), rm.replication.lags...)
+}
+
+// OnReplicationLag 注册复制延迟超过阈值时的回调，每次检测对每个超限的从节点调用一次
+func (rm *RedisManager) OnReplicationLag(handler ReplicationLagHandler) {
+	if rm.replication == nil {
+		return
+	}
+	rm.replication.mu.Lock()
+	defer rm.replication.mu.Unlock()
+	rm.replication.handlers = append(rm.replication.handlers, handler)
+}
+
+// replicationLagLoop 复制延迟检测循环
+func (rm *RedisManager) replicationLagLoop() {
+	ticker := time.NewTicker(rm.replication.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			rm.checkReplicationLag()
+		case <-rm.done:
+			return
+		}
+	}
+}
+
+// checkReplicationLag 读取各主节点的 INFO replication 并计算从节点延迟
+func (rm *RedisManager) checkReplicationLag() {
+	client, ok := rm.GetClient().(*redis.ClusterClient)
+	if !ok {
+		return
+	}
+
+	var mu sync.Mutex
+	var lags []ReplicaLag
+	_ = client.ForEachMaster(rm.ctx, func(ctx context.Context, master *redis.Client) error {
+		info, err := master.Info(ctx, "replication").Result()
+		if err != nil {
+			rm.Logger().Warn("Redis replication info failed", "node", master.Options().Addr, "error", err)
+			return nil
+		}
+
+		replicas := parseReplicationInfo(master.Options().Addr, info)
+		mu.Lock()
+		lags = append(lags, replicas...)
+		mu.Unlock()
+		return nil
+	})
+
+	config := rm.replication.config
+	for i := range lags {
+		lags[i].Exceeded = (config.MaxOffsetLag > 0 && lags[i].OffsetLag > config.MaxOffsetLag) ||
+			(config.MaxLag > 0 && lags[i].Lag > config.MaxLag)
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].Master != lags[j].Master {
+			return lags[i].Master < lags[j].Master
+		}
+		return lags[i].Replica < lags[j].Replica
+	})
+
+	rm.replication.mu.Lock()
+	rm.replication.lags = lags
+	handlers := rm.replication.handlers
+	rm.replication.mu.Unlock()
+
+	for _, lag := range lags {
+		if !lag.Exceeded {
+			continue
+		}
+		rm.Logger().Warn("Redis replica lag exceeded", "master", lag.Master, "replica", lag.Replica,
+			"state", lag.State, "offset_lag", lag.OffsetLag, "lag", lag.Lag)
+		for _, handler := range handlers {
+			handler(lag)
+		}
+	}
+}
+
+// parseReplicationInfo 解析主节点 INFO replication 输出中的从节点信息
+// 从节点行格式：slave0:ip=10.0.0.2,port=6379,state=online,offset=1234,lag=0
+func parseReplicationInfo(master, info string) []ReplicaLag {
+	var masterOffset int64
+	var replicas []map[string]string
+
+	for _, line := range strings.Split(info, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "master_repl_offset":
+			masterOffset, _ = strconv.ParseInt(value, 10, 64)
+		case strings.HasPrefix(name, "slave") && strings.Contains(value, "="):
+			fields := make(map[string]string)
+			for _, kv := range strings.Split(value, ",") {
+				if k, v, ok := strings.Cut(kv, "="); ok {
+					fields[k] = v
+				}
+			}
+			replicas = append(replicas, fields)
+		}
+	}
+
+	lags := make([]ReplicaLag, 0, len(replicas))
+	for _, fields := range replicas {
+		offset, _ := strconv.ParseInt(fields["offset"], 10, 64)
+		lagSeconds, _ := strconv.ParseInt(fields["lag"], 10, 64)
+		lags = append(lags, ReplicaLag{
+			Master:    master,
+			Replica:   net.JoinHostPort(fields["ip"], fields["port"]),
+			State:     fields["state"],
+			OffsetLag: max(0, masterOffset-offset),
+			Lag:       time.Duration(lagSeconds) * time.Second,
+		})
+	}
+	return lags
+}
@@ -0,0 +1,209 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RetryConfig 封装层的重试策略，在 go-redis 自身的 MaxRetries 之外生效
+// 默认只重试幂等命令（GET、EXISTS 等读命令和不带条件的 SET、MSET 等重复执行结果相同的写命令），INCR 等非幂等命令需显式允许
+type RetryConfig struct {
+	Attempts      int           `json:"attempts,omitempty" yaml:"attempts,omitempty"`             // 总尝试次数（含首次），默认 3
+	MinBackoff    time.Duration `json:"min_backoff,omitempty" yaml:"min_backoff,omitempty"`       // 首次重试前的等待时间，之后按指数增长，默认 10ms
+	MaxBackoff    time.Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`       // 最大等待时间，默认 200ms
+	Classes       []ErrorClass  `json:"classes,omitempty" yaml:"classes,omitempty"`               // 可重试的错误类别，默认连接和超时错误
+	AllowCommands []string      `json:"allow_commands,omitempty" yaml:"allow_commands,omitempty"` // 额外允许重试的非幂等命令（小写命令名，如 incr）
+}
+
+// setDefaults 设置默认值
+func (c *RetryConfig) setDefaults() {
+	if c.Attempts <= 0 {
+		c.Attempts = 3
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = 10 * time.Millisecond
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = max(200*time.Millisecond, c.MinBackoff)
+	}
+	if len(c.Classes) == 0 {
+		c.Classes = []ErrorClass{ErrorClassConnection, ErrorClassTimeout}
+	}
+}
+
+// idempotentCommands 重复执行结果相同、默认可以重试的命令
+var idempotentCommands = map[string]bool{
+	// 读命令
+	"get": true, "mget": true, "getrange": true, "strlen": true, "exists": true, "type": true,
+	"ttl": true, "pttl": true, "expiretime": true, "pexpiretime": true, "dump": true,
+	"hget": true, "hmget": true, "hgetall": true, "hkeys": true, "hvals": true, "hlen": true,
+	"hexists": true, "hstrlen": true, "hscan": true,
+	"lrange": true, "lindex": true, "llen": true, "lpos": true,
+	"smembers": true, "sismember": true, "smismember": true, "scard": true, "sscan": true,
+	"sinter": true, "sunion": true, "sdiff": true, "srandmember": true,
+	"zrange": true, "zrangebyscore": true, "zrevrange": true, "zrevrangebyscore": true,
+	"zrangebylex": true, "zscore": true, "zmscore": true, "zrank": true, "zrevrank": true,
+	"zcard": true, "zcount": true, "zlexcount": true, "zscan": true,
+	"pfcount": true, "getbit": true, "bitcount": true, "bitpos": true,
+	"geopos": true, "geodist": true, "geohash": true, "geosearch": true,
+	"xrange": true, "xrevrange": true, "xlen": true, "xinfo": true,
+	"scan": true, "keys": true, "dbsize": true, "ping": true, "echo": true, "info": true,
+	"evalsha_ro": true, "eval_ro": true, "fcall_ro": true,
+	"json.get": true, "json.mget": true, "json.type": true, "json.strlen": true, "json.arrlen": true,
+	"json.objkeys": true, "json.objlen": true,
+	"ft.search": true, "ft.aggregate": true, "ft.info": true,
+	"bf.exists": true, "bf.mexists": true, "cf.exists": true,
+	// 重复执行结果相同的写命令；DEL、HSET、SADD 等返回变更数量的命令重试后返回值不同，不在此列
+	"set": true, "mset": true, "setex": true, "psetex": true, "hmset": true,
+	"expireat": true, "pexpireat": true, "json.set": true,
+}
+
+// conditionalSetOptions 使 SET/JSON.SET 的结果依赖于执行前状态的选项：首次执行已生效但超时时，重试会返回不同的结果
+var conditionalSetOptions = map[string]bool{"nx": true, "xx": true, "get": true}
+
+// setOptionsStart SET 和 JSON.SET 的选项在参数中的起始位置（SET key value、JSON.SET key path value 之后）
+var setOptionsStart = map[string]int{"set": 3, "json.set": 4}
+
+// idempotent 命令是否幂等：带 NX/XX/GET 选项的 SET 不是幂等的（如 SetNX 获取锁，重试会返回 false 而锁已被自己持有）
+func idempotent(cmd redis.Cmder) bool {
+	name := cmd.Name()
+	if !idempotentCommands[name] {
+		return false
+	}
+	if start, ok := setOptionsStart[name]; ok && len(cmd.Args()) > start {
+		for _, arg := range cmd.Args()[start:] {
+			if s, ok := arg.(string); ok && conditionalSetOptions[strings.ToLower(s)] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// retryAllowedKey context 中允许重试非幂等命令的标记
+type retryAllowedKey struct{}
+
+// WithRetryAllowed 允许以该 context 执行的非幂等命令按重试策略重试
+// 调用方需自行确保命令重复执行是安全的（例如已通过其他方式去重）
+func WithRetryAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryAllowedKey{}, true)
+}
+
+// retryPolicy 重试策略
+type retryPolicy struct {
+	config  RetryConfig
+	classes map[ErrorClass]bool
+	allowed map[string]bool
+}
+
+// newRetryPolicy 创建重试策略
+func newRetryPolicy(config RetryConfig) *retryPolicy {
+	p := &retryPolicy{
+		config:  config,
+		classes: make(map[ErrorClass]bool),
+		allowed: make(map[string]bool),
+	}
+	for _, class := range config.Classes {
+		p.classes[class] = true
+	}
+	for _, name := range config.AllowCommands {
+		p.allowed[strings.ToLower(name)] = true
+	}
+	return p
+}
+
+// canRetry 命令是否允许重试
+func (p *retryPolicy) canRetry(ctx context.Context, cmd redis.Cmder) bool {
+	if idempotent(cmd) || p.allowed[cmd.Name()] {
+		return true
+	}
+	allowed, _ := ctx.Value(retryAllowedKey{}).(bool)
+	return allowed
+}
+
+// retryable 错误是否可以重试，熔断器拒绝和调用方取消的命令不重试
+func (p *retryPolicy) retryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, errCircuitOpen) {
+		return false
+	}
+	return p.classes[ClassifyError(err)]
+}
+
+// wait 等待第 attempt 次重试的退避时间（带随机抖动），context 结束时返回 false
+func (p *retryPolicy) wait(ctx context.Context, attempt int) bool {
+	backoff := p.config.MinBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > p.config.MaxBackoff {
+		backoff = p.config.MaxBackoff
+	}
+	backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retryHook 按重试策略重试失败命令的 go-redis 钩子
+type retryHook struct {
+	rm     *RedisManager
+	policy *retryPolicy
+}
+
+func (h retryHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h retryHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if !h.policy.retryable(ctx, err) || !h.policy.canRetry(ctx, cmd) {
+			return err
+		}
+
+		for attempt := 1; attempt < h.policy.config.Attempts && h.policy.retryable(ctx, err); attempt++ {
+			if !h.policy.wait(ctx, attempt) {
+				break
+			}
+			h.rm.Logger().Debug("Redis command retry", "command", cmd.Name(), "attempt", attempt, "error", err)
+			cmd.SetErr(nil)
+			err = next(ctx, cmd)
+		}
+		return err
+	}
+}
+
+func (h retryHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		if !h.policy.retryable(ctx, err) {
+			return err
+		}
+		// 只有全部命令都允许重试时才重试整个流水线（事务中的 MULTI/EXEC 不在幂等命令中，未通过 WithRetryAllowed 允许时事务不会重试）
+		for _, cmd := range cmds {
+			if !h.policy.canRetry(ctx, cmd) {
+				return err
+			}
+		}
+
+		for attempt := 1; attempt < h.policy.config.Attempts && h.policy.retryable(ctx, err); attempt++ {
+			if !h.policy.wait(ctx, attempt) {
+				break
+			}
+			h.rm.Logger().Debug("Redis pipeline retry", "size", len(cmds), "attempt", attempt, "error", err)
+			for _, cmd := range cmds {
+				cmd.SetErr(nil)
+			}
+			err = next(ctx, cmds)
+		}
+		return err
+	}
+}
@@ -0,0 +1,131 @@
+package redisx
+
+import (
+	"crypto/md5"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Ring 键分布算法
+const (
+	RingHashRendezvous = "rendezvous" // go-redis 默认的 rendezvous 哈希，分片权重不同时按权重加权
+	RingHashKetama     = "ketama"     // 与 twemproxy distribution: ketama 一致的分布，用于从 twemproxy 迁移时保持键的位置
+)
+
+// ketama 的键哈希函数，与 twemproxy 的 hash 配置同名
+const (
+	KeyHashFNV1a64 = "fnv1a_64"
+	KeyHashFNV1a32 = "fnv1a_32"
+	KeyHashFNV164  = "fnv1_64"
+	KeyHashFNV132  = "fnv1_32"
+	KeyHashMD5     = "md5"
+)
+
+// ketama 参数，与 twemproxy 相同
+const (
+	ketamaPointsPerServer = 160
+	ketamaPointsPerHash   = 4
+)
+
+// ketamaKeyHashes 支持的键哈希函数，实现与 twemproxy 的 hash 模块一致（包括 fnv1a_64 按 32 位计算）
+// twemproxy 按 (uint32_t)key[x] 读取有符号 char，0x80 以上的字节按符号扩展参与计算，非 ASCII 键需同样处理才能落在相同的分片
+var ketamaKeyHashes = map[string]func(key string) uint32{
+	KeyHashFNV1a64: func(key string) uint32 {
+		hash := uint32(0xcbf29ce484222325 & math.MaxUint32)
+		for i := 0; i < len(key); i++ {
+			hash ^= uint32(int8(key[i]))
+			hash *= uint32(0x100000001b3 & math.MaxUint32)
+		}
+		return hash
+	},
+	KeyHashFNV1a32: func(key string) uint32 {
+		hash := uint32(2166136261)
+		for i := 0; i < len(key); i++ {
+			hash ^= uint32(int8(key[i]))
+			hash *= 16777619
+		}
+		return hash
+	},
+	KeyHashFNV164: func(key string) uint32 {
+		hash := uint64(0xcbf29ce484222325)
+		for i := 0; i < len(key); i++ {
+			hash *= 0x100000001b3
+			hash ^= uint64(int8(key[i]))
+		}
+		return uint32(hash)
+	},
+	KeyHashFNV132: func(key string) uint32 {
+		hash := uint32(2166136261)
+		for i := 0; i < len(key); i++ {
+			hash *= 16777619
+			hash ^= uint32(int8(key[i]))
+		}
+		return hash
+	},
+	KeyHashMD5: func(key string) uint32 {
+		return ketamaPoint(md5.Sum([]byte(key)), 0)
+	},
+}
+
+// ketamaPoint 取 MD5 摘要中第 n 组 4 字节（小端）作为哈希环上的点
+func ketamaPoint(digest [md5.Size]byte, n int) uint32 {
+	b := digest[n*4 : n*4+4]
+	return uint32(b[3])<<24 | uint32(b[2])<<16 | uint32(b[1])<<8 | uint32(b[0])
+}
+
+// ketamaNode 哈希环上的点
+type ketamaNode struct {
+	value uint32
+	name  string
+}
+
+// ketamaHash twemproxy 的 ketama 一致性哈希
+// 分片名称对应 twemproxy servers 配置中的名称（未配置名称时为 "host:port"），分到的点数与权重成正比
+type ketamaHash struct {
+	keyHash   func(key string) uint32
+	continuum []ketamaNode
+}
+
+// newKetamaHash 以当前可用的分片建立哈希环
+func newKetamaHash(names []string, weights map[string]int, keyHash func(key string) uint32) *ketamaHash {
+	h := &ketamaHash{keyHash: keyHash}
+
+	totalWeight := 0
+	for _, name := range names {
+		totalWeight += max(weights[name], 1)
+	}
+
+	for _, name := range names {
+		// 与 twemproxy 一样按 float 精度计算每个分片的哈希次数
+		pct := float32(max(weights[name], 1)) / float32(totalWeight)
+		hashes := int(math.Floor(float64(pct * ketamaPointsPerServer / ketamaPointsPerHash * float32(len(names)))))
+		for i := 0; i < hashes; i++ {
+			digest := md5.Sum([]byte(fmt.Sprintf("%s-%d", name, i)))
+			for n := 0; n < ketamaPointsPerHash; n++ {
+				h.continuum = append(h.continuum, ketamaNode{value: ketamaPoint(digest, n), name: name})
+			}
+		}
+	}
+
+	sort.Slice(h.continuum, func(i, j int) bool {
+		return h.continuum[i].value < h.continuum[j].value
+	})
+	return h
+}
+
+// Get 返回 key 所属的分片名称：哈希环上第一个不小于键哈希值的点，超过最后一个点时回到第一个点
+func (h *ketamaHash) Get(key string) string {
+	if len(h.continuum) == 0 {
+		return ""
+	}
+
+	hash := h.keyHash(key)
+	i := sort.Search(len(h.continuum), func(i int) bool {
+		return h.continuum[i].value >= hash
+	})
+	if i == len(h.continuum) {
+		i = 0
+	}
+	return h.continuum[i].name
+}
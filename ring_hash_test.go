@@ -0,0 +1,95 @@
+package redisx
+
+import "testing"
+
+// 期望值由 twemproxy hashkit（nc_fnv.c、nc_ketama.c）中 hash_fnv1*、ketama_hash、ketama_update 和 ketama_dispatch 的 C 代码在 x86-64（char 有符号）上计算得到
+
+func TestKetamaKeyHashes(t *testing.T) {
+	tests := []struct {
+		key                                   string
+		fnv1a64, fnv1a32, fnv164, fnv132, md5 uint32
+	}{
+		{"foo", 4275688823, 2851307223, 1805727027, 1083137555, 3675831724},
+		{"hello world", 37540583, 3582672807, 2979073647, 1418570095, 3141252702},
+		{"user:1000", 2927313545, 1350311305, 3550362913, 2796551329, 781738503},
+		{"中文键", 664681914, 1754357754, 3119008376, 2434726168, 249473266},
+		{"ключ", 2846718081, 2950043617, 1687779865, 833875961, 1719363011},
+		{"café", 3472276361, 1970454601, 2315714289, 3598905713, 3833532679},
+		{"\xff", 2046707310, 4193493326, 2046707744, 4210270944, 3561969920},
+	}
+
+	for _, tt := range tests {
+		for name, want := range map[string]uint32{
+			KeyHashFNV1a64: tt.fnv1a64,
+			KeyHashFNV1a32: tt.fnv1a32,
+			KeyHashFNV164:  tt.fnv164,
+			KeyHashFNV132:  tt.fnv132,
+			KeyHashMD5:     tt.md5,
+		} {
+			if got := ketamaKeyHashes[name](tt.key); got != want {
+				t.Errorf("%s(%q) = %d, want %d", name, tt.key, got, want)
+			}
+		}
+	}
+}
+
+func TestKetamaContinuum(t *testing.T) {
+	names := []string{"redis-1", "redis-2", "redis-3"}
+	weights := map[string]int{"redis-1": 1, "redis-2": 1, "redis-3": 2}
+	h := newKetamaHash(names, weights, ketamaKeyHashes[KeyHashFNV1a64])
+
+	if len(h.continuum) != 480 {
+		t.Fatalf("continuum has %d points, want 480", len(h.continuum))
+	}
+
+	counts := make(map[string]int)
+	for _, node := range h.continuum {
+		counts[node.name]++
+	}
+	if counts["redis-1"] != 120 || counts["redis-2"] != 120 || counts["redis-3"] != 240 {
+		t.Errorf("points per server = %v, want 120/120/240", counts)
+	}
+
+	points := []ketamaNode{
+		{8857619, "redis-3"},
+		{24210803, "redis-3"},
+		{31753278, "redis-2"},
+		{68686830, "redis-1"},
+		{71672965, "redis-3"},
+	}
+	for i, want := range points {
+		if got := h.continuum[i]; got != want {
+			t.Errorf("continuum[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+	if got, want := h.continuum[len(h.continuum)-1], (ketamaNode{4292785429, "redis-3"}); got != want {
+		t.Errorf("last point = %+v, want %+v", got, want)
+	}
+}
+
+func TestKetamaDispatch(t *testing.T) {
+	names := []string{"redis-1", "redis-2", "redis-3"}
+	weights := map[string]int{"redis-1": 1, "redis-2": 1, "redis-3": 2}
+	fnv := newKetamaHash(names, weights, ketamaKeyHashes[KeyHashFNV1a64])
+	md5 := newKetamaHash(names, weights, ketamaKeyHashes[KeyHashMD5])
+
+	tests := []struct {
+		key, fnv1a64, md5 string
+	}{
+		{"foo", "redis-3", "redis-1"},
+		{"hello world", "redis-1", "redis-2"},
+		{"user:1000", "redis-2", "redis-1"},
+		{"中文键", "redis-3", "redis-1"},
+		{"ключ", "redis-2", "redis-2"},
+		{"café", "redis-3", "redis-2"},
+		{"\xff", "redis-3", "redis-3"},
+	}
+	for _, tt := range tests {
+		if got := fnv.Get(tt.key); got != tt.fnv1a64 {
+			t.Errorf("fnv1a_64 Get(%q) = %s, want %s", tt.key, got, tt.fnv1a64)
+		}
+		if got := md5.Get(tt.key); got != tt.md5 {
+			t.Errorf("md5 Get(%q) = %s, want %s", tt.key, got, tt.md5)
+		}
+	}
+}
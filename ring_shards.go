@@ -0,0 +1,180 @@
+package redisx
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// RingShardConfig Ring 分片配置
+type RingShardConfig struct {
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`         // 分片名称，参与键的分布计算，默认为序号 "0"、"1"…；更换地址时保持名称不变可避免键重新分布
+	Addr     string `json:"addr" yaml:"addr"`                             // 分片地址
+	Weight   int    `json:"weight,omitempty" yaml:"weight,omitempty"`     // 权重，默认 1，分到的键数与权重成正比
+	Password string `json:"password,omitempty" yaml:"password,omitempty"` // 分片密码，为空时使用 MasterSlave.Password
+	Database *int   `json:"database,omitempty" yaml:"database,omitempty"` // 分片数据库编号，为空时使用 MasterSlave.Database
+}
+
+// RingShard 分片信息
+type RingShard struct {
+	Name   string `json:"name"`
+	Addr   string `json:"addr"`
+	Weight int    `json:"weight"`
+}
+
+// ringShards 补全默认值后的 Ring 分片配置，未配置 Shards 时由 Addrs 生成
+func (c *MasterSlaveConfig) ringShards() []RingShardConfig {
+	if len(c.Shards) == 0 {
+		shards := make([]RingShardConfig, len(c.Addrs))
+		for i, addr := range c.Addrs {
+			shards[i] = RingShardConfig{Name: fmt.Sprintf("%d", i), Addr: addr, Weight: 1}
+		}
+		return shards
+	}
+
+	shards := make([]RingShardConfig, len(c.Shards))
+	for i, shard := range c.Shards {
+		if shard.Name == "" {
+			shard.Name = fmt.Sprintf("%d", i)
+		}
+		if shard.Weight == 0 {
+			shard.Weight = 1
+		}
+		shards[i] = shard
+	}
+	return shards
+}
+
+// ringOptions 把分片配置应用到 Ring 配置：分片地址、各分片的密码和数据库编号，以及键分布使用的一致性哈希
+func (c *MasterSlaveConfig) ringOptions(opts *redis.RingOptions) {
+	shards := c.ringShards()
+
+	opts.Addrs = make(map[string]string, len(shards))
+	byAddr := make(map[string]RingShardConfig, len(shards))
+	weights := make(map[string]int, len(shards))
+	weighted := false
+	for _, shard := range shards {
+		opts.Addrs[shard.Name] = shard.Addr
+		byAddr[shard.Addr] = shard
+		weights[shard.Name] = shard.Weight
+		weighted = weighted || shard.Weight != 1
+	}
+
+	opts.NewClient = func(opt *redis.Options) *redis.Client {
+		if shard, ok := byAddr[opt.Addr]; ok {
+			if shard.Password != "" {
+				opt.Password = shard.Password
+			}
+			if shard.Database != nil {
+				opt.DB = *shard.Database
+			}
+		}
+		return redis.NewClient(opt)
+	}
+
+	switch {
+	case c.NewConsistentHash != nil:
+		opts.NewConsistentHash = c.NewConsistentHash
+	case c.RingHash == RingHashKetama:
+		keyHash := ketamaKeyHashes[KeyHashFNV1a64]
+		if c.RingKeyHash != "" {
+			keyHash = ketamaKeyHashes[c.RingKeyHash]
+		}
+		opts.NewConsistentHash = func(names []string) redis.ConsistentHash {
+			return newKetamaHash(names, weights, keyHash)
+		}
+	case weighted:
+		// 权重相同时保留 go-redis 默认的哈希，与未配置权重时的键分布一致
+		opts.NewConsistentHash = func(names []string) redis.ConsistentHash {
+			return newWeightedRendezvous(names, weights)
+		}
+	}
+}
+
+// weightedRendezvous 加权的最高随机权重（rendezvous）哈希：每个分片的得分为 weight / -ln(u)，u 为键与分片名哈希得到的 (0,1) 均匀分布值
+// 增减分片时只有属于该分片的键重新分布
+type weightedRendezvous struct {
+	names   []string
+	hashes  []uint64
+	weights []float64
+}
+
+// newWeightedRendezvous 创建加权哈希，names 为当前可用的分片
+func newWeightedRendezvous(names []string, weights map[string]int) *weightedRendezvous {
+	r := &weightedRendezvous{
+		names:   names,
+		hashes:  make([]uint64, len(names)),
+		weights: make([]float64, len(names)),
+	}
+	for i, name := range names {
+		r.hashes[i] = xxhash.Sum64String(name)
+		r.weights[i] = float64(weights[name])
+		if r.weights[i] <= 0 {
+			r.weights[i] = 1
+		}
+	}
+	return r
+}
+
+// Get 返回 key 所属的分片名称
+func (r *weightedRendezvous) Get(key string) string {
+	keyHash := xxhash.Sum64String(key)
+
+	var best string
+	bestScore := math.Inf(-1)
+	for i, name := range r.names {
+		u := (float64(mix64(keyHash^r.hashes[i])>>11) + 0.5) / (1 << 53)
+		if score := r.weights[i] / -math.Log(u); score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// mix64 64 位哈希的混合函数（splitmix64 的最终步骤）
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// ShardForKey 返回 key 在 Ring 模式下所属的分片，用于排查键的分布；只计算当前可用的分片，分片下线期间其键临时分到其他分片
+// 键包含 {hashtag} 时按 hashtag 计算；非 Ring 模式时返回 INVALID_OPERATION
+func (rm *RedisManager) ShardForKey(key string) CacheResult[RingShard] {
+	ring, ok := rm.GetClient().(*redis.Ring)
+	if !ok {
+		return NewCacheError[RingShard](INVALID_OPERATION, ErrInvalidOperation.WithMessage("shard lookup is only supported in ring mode"))
+	}
+
+	node, err := ring.GetShardClientForKey(key)
+	if err != nil {
+		return NewCacheError[RingShard](REDIS_INNER_ERROR, err)
+	}
+
+	addr := node.Options().Addr
+	for _, shard := range rm.config.MasterSlave.ringShards() {
+		if shard.Addr == addr {
+			return NewCacheResult(RingShard{Name: shard.Name, Addr: shard.Addr, Weight: shard.Weight})
+		}
+	}
+	return NewCacheResult(RingShard{Addr: addr})
+}
+
+// RingShards 返回配置的 Ring 分片，非 Ring 模式时返回 nil
+func (rm *RedisManager) RingShards() []RingShard {
+	if _, ok := rm.GetClient().(*redis.Ring); !ok {
+		return nil
+	}
+
+	shards := rm.config.MasterSlave.ringShards()
+	infos := make([]RingShard, len(shards))
+	for i, shard := range shards {
+		infos[i] = RingShard{Name: shard.Name, Addr: shard.Addr, Weight: shard.Weight}
+	}
+	return infos
+}
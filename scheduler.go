@@ -0,0 +1,257 @@
+package redisx
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxMissedScan 检测错过的执行次数时最多向前推算的周期数
+const maxMissedScan = 1000
+
+// Schedule 任务调度规则，返回 after 之后的下一次触发时间
+// 多个实例对同一时间点必须计算出相同的结果，以便按触发时间协调
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// intervalSchedule 固定间隔调度，触发时间对齐到 Unix 纪元的整数倍
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every 创建固定间隔的调度规则，触发时间为 Unix 纪元起 interval 的整数倍，如 Every(time.Minute) 在每分钟的第0秒触发（UTC）
+// 不能整除一天的间隔（如 7*time.Hour）不对齐到每天的固定时刻；其他规则可自行实现 Schedule
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	// time.Truncate 对齐到零值时间（公元1年），不等于 Unix 纪元，这里按 Unix 纳秒计算
+	n, d := after.UnixNano(), int64(s.interval)
+	return time.Unix(0, n-n%d+d).In(after.Location())
+}
+
+// JobFunc 定时任务函数
+type JobFunc func(ctx context.Context) error
+
+// JobStats 单个任务在本实例上的统计
+type JobStats struct {
+	Runs         int64         // 本实例执行次数
+	Failures     int64         // 执行失败次数
+	Skipped      int64         // 由其他实例执行而跳过的次数
+	Missed       int64         // 检测到的未被任何实例执行的周期数
+	LastRun      time.Time     // 本实例最近一次执行的触发时间
+	LastDuration time.Duration // 本实例最近一次执行耗时
+	LastError    string        // 本实例最近一次执行的错误
+}
+
+// scheduledJob 已注册的任务
+type scheduledJob struct {
+	name     string
+	schedule Schedule
+	fn       JobFunc
+	stats    JobStats
+}
+
+// Scheduler 分布式定时任务调度器
+// 每个实例注册相同的任务，每个触发时间点通过分布式锁保证只有一个实例执行；
+// 锁以触发时间为键的一部分且不主动释放，执行结束后其他实例也不会重复执行同一周期
+type Scheduler struct {
+	rm         *RedisManager
+	name       string
+	instanceID string
+
+	// OnMissed 检测到任务错过执行时的回调，missed 为错过的周期数
+	OnMissed func(job string, missed int64)
+
+	mutex   sync.Mutex
+	jobs    map[string]*scheduledJob
+	running bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewScheduler 创建调度器，name 用于区分不同的调度器，同一调度器的所有实例应使用相同的 name
+func NewScheduler(rm *RedisManager, name string) *Scheduler {
+	return &Scheduler{
+		rm:         rm,
+		name:       name,
+		instanceID: newTaskID(),
+		jobs:       make(map[string]*scheduledJob),
+	}
+}
+
+// Register 注册任务，需在 Start 之前调用
+func (s *Scheduler) Register(name string, schedule Schedule, fn JobFunc) error {
+	if name == "" || schedule == nil || fn == nil {
+		return ErrInvalidOperation.WithMessage("job name, schedule and func are required")
+	}
+	if is, ok := schedule.(intervalSchedule); ok && is.interval <= 0 {
+		return ErrInvalidOperation.WithMessage("schedule interval must be positive")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return ErrInvalidOperation.WithMessage("scheduler already started")
+	}
+	if _, exists := s.jobs[name]; exists {
+		return ErrInvalidOperation.WithMessage("job already registered: " + name)
+	}
+
+	s.jobs[name] = &scheduledJob{name: name, schedule: schedule, fn: fn}
+	return nil
+}
+
+// Start 启动调度，ctx 取消或调用 Stop 时停止
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return ErrInvalidOperation.WithMessage("scheduler already started")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.running = true
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(runCtx, job)
+	}
+	s.rm.addWorker(s, s.Stop)
+
+	return nil
+}
+
+// Stop 停止调度并等待正在执行的任务完成
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	if !s.running {
+		s.mutex.Unlock()
+		return
+	}
+	s.running = false
+	s.cancel()
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+	s.rm.removeWorker(s)
+}
+
+// Stats 获取所有任务的统计
+func (s *Scheduler) Stats() map[string]JobStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := make(map[string]JobStats, len(s.jobs))
+	for name, job := range s.jobs {
+		stats[name] = job.stats
+	}
+	return stats
+}
+
+// lockKey 任务在某个触发时间点的锁键
+func (s *Scheduler) lockKey(job string, tick time.Time) string {
+	return "scheduler:" + s.name + ":" + job + ":" + strconv.FormatInt(tick.UnixMilli(), 10)
+}
+
+// lastRunKey 记录各任务最近一次触发时间的哈希键
+func (s *Scheduler) lastRunKey() string {
+	return "scheduler:" + s.name + ":last_run"
+}
+
+// run 单个任务的调度循环
+func (s *Scheduler) run(ctx context.Context, job *scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		tick := job.schedule.Next(time.Now())
+		if !sleepContext(ctx, time.Until(tick)) {
+			return
+		}
+		s.fire(ctx, job, tick)
+	}
+}
+
+// fire 竞争触发时间点的执行权，获得后执行任务
+func (s *Scheduler) fire(ctx context.Context, job *scheduledJob, tick time.Time) {
+	// 锁的有效期覆盖到下一个触发时间，避免时钟略有偏差的实例在锁过期后重复执行
+	ttl := job.schedule.Next(tick).Sub(tick)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	res := s.rm.TryLock(s.lockKey(job.name, tick), s.instanceID, ttl)
+	if !res.IsOK() {
+		s.rm.Logger().Warn("Redis scheduler lock failed", "job", job.name, "error", res.Err)
+		return
+	}
+	if !res.Val {
+		s.mutex.Lock()
+		job.stats.Skipped++
+		s.mutex.Unlock()
+		return
+	}
+
+	s.detectMissed(job, tick)
+
+	start := time.Now()
+	err := job.fn(ctx)
+	duration := time.Since(start)
+
+	s.mutex.Lock()
+	job.stats.Runs++
+	job.stats.LastRun = tick
+	job.stats.LastDuration = duration
+	job.stats.LastError = ""
+	if err != nil {
+		job.stats.Failures++
+		job.stats.LastError = err.Error()
+	}
+	s.mutex.Unlock()
+
+	if err != nil {
+		s.rm.Logger().Error("Redis scheduler job failed", "job", job.name, "tick", tick, "error", err)
+	}
+}
+
+// detectMissed 对比上一次的触发时间，统计中间未被任何实例执行的周期
+func (s *Scheduler) detectMissed(job *scheduledJob, tick time.Time) {
+	key := s.lastRunKey()
+	prev := s.rm.HGetS(key, job.name)
+	if set := s.rm.HSetS(key, job.name, strconv.FormatInt(tick.UnixMilli(), 10)); !set.IsOK() {
+		s.rm.Logger().Warn("Redis scheduler record last run failed", "job", job.name, "error", set.Err)
+	}
+	if !prev.IsOK() {
+		return
+	}
+
+	prevMs, err := strconv.ParseInt(prev.Val, 10, 64)
+	if err != nil {
+		return
+	}
+
+	var missed int64
+	next := job.schedule.Next(time.UnixMilli(prevMs))
+	for next.Before(tick) && missed < maxMissedScan {
+		missed++
+		next = job.schedule.Next(next)
+	}
+	if missed == 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	job.stats.Missed += missed
+	s.mutex.Unlock()
+
+	s.rm.Logger().Warn("Redis scheduler detected missed runs", "job", job.name, "missed", missed)
+	if s.OnMissed != nil {
+		s.OnMissed(job.name, missed)
+	}
+}
@@ -2,6 +2,10 @@ package redisx
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -97,6 +101,27 @@ func (rm *RedisManager) SafeHDecr(key string, field string, decr int64) CacheRes
 	return NewCacheResult(val)
 }
 
+// SafeZIncr 安全有序集合增分操作, 分数只会增加到 max 为止
+// 增加后超过上限时截断为 max，当前分数已达上限则不做修改并返回当前分数
+func (rm *RedisManager) SafeZIncr(key string, member string, incr, max float64) CacheResult[float64] {
+	result := rm.EvalScript(ScriptKeyZIncr, []string{key}, member, incr, max)
+	if !result.IsOK() {
+		return NewCacheError[float64](result.ErrCode, result.Err)
+	}
+
+	str, ok := result.Val.(string)
+	if !ok {
+		return NewCacheError[float64](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return NewCacheError[float64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
 // SetExpireIfExists 如果键存在则设置过期时间
 func (rm *RedisManager) SetExpireIfExists(key string, ttl time.Duration) CacheResult[bool] {
 	result := rm.EvalScript(ScriptKeyCheckExpire, []string{key}, int64(ttl.Seconds()))
@@ -142,9 +167,16 @@ func (rm *RedisManager) TestAddition(a, b int64) CacheResult[int64] {
 	return NewCacheResult(val)
 }
 
-// TryLock 尝试获取分布式锁
+// TryLock 尝试获取分布式锁，全局配置 Durability.Locks 时等待从节点确认（见 DurableWriter.TryLock）
 func (rm *RedisManager) TryLock(lockKey, lockValue string, expiration time.Duration) CacheResult[bool] {
-	result := rm.EvalScript(ScriptKeyLock, []string{lockKey}, lockValue, expiration.Milliseconds())
+	if rm.locksDurable() {
+		return rm.Durable(nil).TryLock(lockKey, lockValue, expiration)
+	}
+	return lockScriptResult(rm.EvalScript(ScriptKeyLock, []string{lockKey}, lockValue, expiration.Milliseconds()))
+}
+
+// lockScriptResult 解析加锁脚本的返回值
+func lockScriptResult(result CacheResult[interface{}]) CacheResult[bool] {
 	if !result.IsOK() {
 		return NewCacheError[bool](result.ErrCode, result.Err)
 	}
@@ -197,9 +229,106 @@ func (rm *RedisManager) RenewLock(lockKey, lockValue string, expiration time.Dur
 	return NewCacheResult(val == 1)
 }
 
-// TryMultiLock 尝试获取多个分布式锁
-func (rm *RedisManager) TryMultiLock(lockKeys []string, lockValue string, expiration time.Duration) CacheResult[bool] {
-	result := rm.EvalScript(ScriptKeyMultiLock, lockKeys, lockValue, expiration.Milliseconds())
+// RetryPolicy TryLockWithRetry 的重试策略
+type RetryPolicy struct {
+	Attempts int           // 最多尝试次数（含首次），默认 3
+	Backoff  time.Duration // 两次尝试之间的等待时间，默认 50ms
+	Jitter   float64       // 等待时间的随机抖动比例（0~1），实际等待在 Backoff*(1±Jitter) 范围内，避免多个客户端同时重试
+}
+
+// TryLockWithRetry 尝试获取分布式锁，锁被占用时按 policy 重试，用于容忍短暂的竞争
+// 只有锁被占用时才重试，连接错误等直接返回；管理器关闭时返回 INTERRUPTED
+func (rm *RedisManager) TryLockWithRetry(lockKey, lockValue string, expiration time.Duration, policy RetryPolicy) CacheResult[bool] {
+	if policy.Attempts <= 0 {
+		policy.Attempts = 3
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 50 * time.Millisecond
+	}
+	policy.Jitter = min(max(policy.Jitter, 0), 1)
+
+	for attempt := 1; ; attempt++ {
+		res := rm.TryLock(lockKey, lockValue, expiration)
+		if !res.IsOK() || res.Val || attempt >= policy.Attempts {
+			return res
+		}
+
+		delay := policy.Backoff
+		if policy.Jitter > 0 {
+			delay += time.Duration((rand.Float64()*2 - 1) * policy.Jitter * float64(policy.Backoff))
+		}
+		if !sleepContext(rm.ctx, delay) {
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(rm.ctx.Err()))
+		}
+	}
+}
+
+// TryReentrantLock 尝试获取可重入锁，同一 lockValue 可重复获取
+// 返回获取成功后的重入次数，0 表示锁被其他持有者占用
+func (rm *RedisManager) TryReentrantLock(lockKey, lockValue string, expiration time.Duration) CacheResult[int64] {
+	result := rm.EvalScript(ScriptKeyReentrantLock, []string{lockKey}, lockValue, expiration.Milliseconds())
+	if !result.IsOK() {
+		return NewCacheError[int64](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[int64](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	if val == -1 {
+		return NewCacheError[int64](REDIS_INNER_ERROR, fmt.Errorf("invalid lock parameters"))
+	}
+
+	return NewCacheResult(val)
+}
+
+// ReleaseReentrantLock 释放一次可重入锁
+// 返回剩余重入次数，0 表示锁已完全释放，-1 表示锁不存在或不由 lockValue 持有
+func (rm *RedisManager) ReleaseReentrantLock(lockKey, lockValue string) CacheResult[int64] {
+	result := rm.EvalScript(ScriptKeyReentrantUnlock, []string{lockKey}, lockValue)
+	if !result.IsOK() {
+		return NewCacheError[int64](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[int64](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(val)
+}
+
+// RenewReentrantLock 续期可重入锁
+func (rm *RedisManager) RenewReentrantLock(lockKey, lockValue string, expiration time.Duration) CacheResult[bool] {
+	result := rm.EvalScript(ScriptKeyReentrantRenew, []string{lockKey}, lockValue, expiration.Milliseconds())
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	if val == -1 {
+		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("invalid renew lock parameters"))
+	}
+
+	return NewCacheResult(val == 1)
+}
+
+// TryFairLock 尝试获取公平锁，enqueue 为 true 时未获得锁则进入等待队列
+// 等待者需在 waiterTimeout 内再次调用以保持排队位置，否则被移出队列
+func (rm *RedisManager) TryFairLock(lockKey, lockValue string, expiration, waiterTimeout time.Duration, enqueue bool) CacheResult[bool] {
+	queueKey, heartbeatKey := fairLockKeys(lockKey)
+	flag := 0
+	if enqueue {
+		flag = 1
+	}
+
+	result := rm.EvalScript(ScriptKeyFairLock, []string{lockKey, queueKey, heartbeatKey},
+		lockValue, expiration.Milliseconds(), time.Now().UnixMilli(), waiterTimeout.Milliseconds(), flag)
 	if !result.IsOK() {
 		return NewCacheError[bool](result.ErrCode, result.Err)
 	}
@@ -209,20 +338,163 @@ func (rm *RedisManager) TryMultiLock(lockKeys []string, lockValue string, expira
 		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
 	}
 
-	// 处理返回值：1=成功，0=失败，-1=参数错误
 	if val == -1 {
-		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("invalid multi-lock parameters"))
+		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("invalid lock parameters"))
 	}
 
 	return NewCacheResult(val == 1)
 }
 
+// LeaveFairLockQueue 放弃等待公平锁，将 lockValue 移出等待队列，返回是否在队列中
+func (rm *RedisManager) LeaveFairLockQueue(lockKey, lockValue string) CacheResult[bool] {
+	queueKey, heartbeatKey := fairLockKeys(lockKey)
+
+	pipe := rm.Pipeline()
+	removed := pipe.ZRem(queueKey, lockValue)
+	pipe.HDel(heartbeatKey, lockValue)
+	if res := pipe.Exec(); !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+
+	return NewCacheResult(removed.Val() == 1)
+}
+
+// fairLockKeys 公平锁的等待队列和心跳键，与锁位于同一个集群槽
+func fairLockKeys(lockKey string) (string, string) {
+	base := sameSlotBase(lockKey)
+	return base + ":fair_queue", base + ":fair_heartbeat"
+}
+
+// sameSlotBase 返回一个与 key 位于同一个集群槽的键前缀，用于派生辅助键
+// 没有哈希标签的键整体作为标签，其槽与原键相同
+func sameSlotBase(key string) string {
+	if hasHashTag(key) {
+		return key
+	}
+	return "{" + key + "}"
+}
+
+// hasHashTag 键是否包含有效的哈希标签（非空的 {...}）
+func hasHashTag(key string) bool {
+	start := strings.IndexByte(key, '{')
+	if start < 0 {
+		return false
+	}
+	end := strings.IndexByte(key[start+1:], '}')
+	return end > 0
+}
+
+// MultiLockResult 多键锁的获取结果
+type MultiLockResult struct {
+	Acquired  bool   // 是否获取了全部锁
+	FailedKey string // 获取失败时被其他客户端持有的键
+}
+
+// TryMultiLock 尝试获取多个分布式锁，全部获取成功才返回 true
+// 键按字典序排序后获取，多个客户端以不同顺序传入相同的键时也不会互相死锁
+func (rm *RedisManager) TryMultiLock(lockKeys []string, lockValue string, expiration time.Duration) CacheResult[bool] {
+	res := rm.TryMultiLockDetail(lockKeys, lockValue, expiration)
+	if !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(res.Val.Acquired)
+}
+
+// TryMultiLockDetail 尝试获取多个分布式锁，失败时返回导致失败的键
+// 优先通过脚本原子获取；集群模式下键不在同一个槽时退化为按序逐个获取，任一失败则释放本次已获取的锁
+func (rm *RedisManager) TryMultiLockDetail(lockKeys []string, lockValue string, expiration time.Duration) CacheResult[MultiLockResult] {
+	keys := sortedUniqueKeys(lockKeys)
+
+	result := rm.EvalScript(ScriptKeyMultiLock, keys, lockValue, expiration.Milliseconds())
+	if !result.IsOK() {
+		if isCrossSlotErr(result.Err) {
+			return rm.tryMultiLockSequential(keys, lockValue, expiration)
+		}
+		return NewCacheError[MultiLockResult](result.ErrCode, result.Err)
+	}
+
+	vals, ok := result.Val.([]interface{})
+	if !ok || len(vals) != 2 {
+		return NewCacheError[MultiLockResult](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+	status, ok1 := vals[0].(int64)
+	index, ok2 := vals[1].(int64)
+	if !ok1 || !ok2 {
+		return NewCacheError[MultiLockResult](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	// 处理返回值：1=成功，0=失败，-1=参数错误
+	switch status {
+	case -1:
+		return NewCacheError[MultiLockResult](REDIS_INNER_ERROR, fmt.Errorf("invalid multi-lock parameters"))
+	case 1:
+		return NewCacheResult(MultiLockResult{Acquired: true})
+	}
+	if index < 1 || index > int64(len(keys)) {
+		return NewCacheError[MultiLockResult](REDIS_INNER_ERROR, fmt.Errorf("unexpected multi-lock key index: %d", index))
+	}
+	return NewCacheResult(MultiLockResult{FailedKey: keys[index-1]})
+}
+
+// tryMultiLockSequential 按序逐个获取锁，已由 lockValue 持有的锁视为获取成功并续期
+// 失败时只释放本次新获取的锁，调用前已持有的锁保持不变
+func (rm *RedisManager) tryMultiLockSequential(keys []string, lockValue string, expiration time.Duration) CacheResult[MultiLockResult] {
+	acquired := make([]string, 0, len(keys))
+	rollback := func() {
+		for _, key := range acquired {
+			if res := rm.ReleaseLock(key, lockValue); !res.IsOK() {
+				rm.Logger().Warn("Redis multi-lock rollback failed", "key", key, "error", res.Err)
+			}
+		}
+	}
+
+	for _, key := range keys {
+		res := rm.TryLock(key, lockValue, expiration)
+		if !res.IsOK() {
+			rollback()
+			return NewCacheError[MultiLockResult](res.ErrCode, res.Err)
+		}
+		if res.Val {
+			acquired = append(acquired, key)
+			continue
+		}
+
+		renewed := rm.RenewLock(key, lockValue, expiration)
+		if !renewed.IsOK() {
+			rollback()
+			return NewCacheError[MultiLockResult](renewed.ErrCode, renewed.Err)
+		}
+		if !renewed.Val {
+			rollback()
+			return NewCacheResult(MultiLockResult{FailedKey: key})
+		}
+	}
+
+	return NewCacheResult(MultiLockResult{Acquired: true})
+}
+
 // ReleaseMultiLock 释放多个分布式锁
-// 返回实际解锁的锁数量
+// 返回实际解锁的锁数量；集群模式下键不在同一个槽时逐个释放
 func (rm *RedisManager) ReleaseMultiLock(lockKeys []string, lockValue string) CacheResult[int64] {
-	result := rm.EvalScript(ScriptKeyMultiUnlock, lockKeys, lockValue)
+	keys := sortedUniqueKeys(lockKeys)
+
+	result := rm.EvalScript(ScriptKeyMultiUnlock, keys, lockValue)
 	if !result.IsOK() {
-		return NewCacheError[int64](result.ErrCode, result.Err)
+		if !isCrossSlotErr(result.Err) {
+			return NewCacheError[int64](result.ErrCode, result.Err)
+		}
+
+		var unlocked int64
+		for _, key := range keys {
+			res := rm.ReleaseLock(key, lockValue)
+			if !res.IsOK() {
+				return NewCacheError[int64](res.ErrCode, res.Err)
+			}
+			if res.Val {
+				unlocked++
+			}
+		}
+		return NewCacheResult(unlocked)
 	}
 
 	val, ok := result.Val.(int64)
@@ -232,3 +504,22 @@ func (rm *RedisManager) ReleaseMultiLock(lockKeys []string, lockValue string) Ca
 
 	return NewCacheResult(val)
 }
+
+// sortedUniqueKeys 返回排序并去重后的键列表副本
+func sortedUniqueKeys(keys []string) []string {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	unique := sorted[:0]
+	for _, key := range sorted {
+		if len(unique) == 0 || key != unique[len(unique)-1] {
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+// isCrossSlotErr 判断是否为集群模式下多个键不在同一个槽的错误
+func isCrossSlotErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "CROSSSLOT")
+}
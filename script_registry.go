@@ -0,0 +1,257 @@
+package redisx
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// luaScript 注册的 Lua 脚本，sha 与 SCRIPT LOAD 返回的 SHA1 一致
+// 注册后不再修改（lastUsed 除外），替换脚本时整体替换，正在执行的调用仍使用旧版本
+type luaScript struct {
+	source   string
+	sha      string
+	version  string
+	lastUsed atomic.Int64 // 最后一次执行的时间（UnixNano），未执行过为 0
+}
+
+// newLuaScript 创建注册脚本并计算其 SHA1
+func newLuaScript(source, version string) *luaScript {
+	sum := sha1.Sum([]byte(source))
+	return &luaScript{source: source, sha: hex.EncodeToString(sum[:]), version: version}
+}
+
+// ScriptInfo 注册脚本的信息
+type ScriptInfo struct {
+	Name     string    `json:"name"`
+	Version  string    `json:"version,omitempty"`
+	SHA      string    `json:"sha"`
+	LastUsed time.Time `json:"last_used,omitzero"` // 最后一次通过 EvalScript 执行的时间
+}
+
+// ScriptNodeStatus 节点上注册脚本的缓存状态
+type ScriptNodeStatus struct {
+	Addr    string   `json:"addr"`
+	Missing []string `json:"missing,omitempty"` // 检查时节点未缓存的脚本，已重新加载
+	Error   string   `json:"error,omitempty"`
+}
+
+// RegisterScriptVersion 以指定版本注册 Lua 脚本，已注册的同名脚本被原子替换：之后的 EvalScript 使用新脚本及其 SHA，
+// 正在执行的调用不受影响；客户端已建立时在后台把新脚本预加载到各节点
+func (rm *RedisManager) RegisterScriptVersion(name, version, script string) {
+	rm.scriptsMutex.Lock()
+	previous := rm.scripts[name]
+	rm.scripts[name] = newLuaScript(script, version)
+	rm.scriptsMutex.Unlock()
+
+	if previous != nil && rm.GetClient() != nil {
+		rm.Logger().Info("Redis script replaced", "name", name, "from", previous.version, "to", version)
+		go rm.loadScripts()
+	}
+}
+
+// Scripts 返回按名称排序的注册脚本信息
+func (rm *RedisManager) Scripts() []ScriptInfo {
+	names, scripts := rm.scriptList()
+	infos := make([]ScriptInfo, len(names))
+	for i, script := range scripts {
+		infos[i] = ScriptInfo{Name: names[i], Version: script.version, SHA: script.sha}
+		if used := script.lastUsed.Load(); used > 0 {
+			infos[i].LastUsed = time.Unix(0, used)
+		}
+	}
+	return infos
+}
+
+// registeredScript 获取注册的脚本
+func (rm *RedisManager) registeredScript(name string) (*luaScript, bool) {
+	rm.scriptsMutex.RLock()
+	defer rm.scriptsMutex.RUnlock()
+	script, exists := rm.scripts[name]
+	return script, exists
+}
+
+// RegisterScriptsFromFS 从文件系统（如 embed.FS、os.DirFS）注册匹配 pattern 的 .lua 文件，脚本名为去掉 .lua 后缀的文件名
+// pattern 使用 fs.Glob 语法，如 "scripts/*.lua"；没有匹配的文件或读取失败时返回错误且不注册任何脚本，同名脚本覆盖已注册的脚本
+func (rm *RedisManager) RegisterScriptsFromFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+
+	scripts := make(map[string]string, len(files))
+	for _, file := range files {
+		if path.Ext(file) != ".lua" {
+			continue
+		}
+		name := strings.TrimSuffix(path.Base(file), ".lua")
+		if _, exists := scripts[name]; exists {
+			return ErrInvalidOperation.WithMessage(fmt.Sprintf("duplicate script name %q: %s", name, file))
+		}
+		source, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		scripts[name] = string(source)
+	}
+	if len(scripts) == 0 {
+		return ErrInvalidOperation.WithMessage("no lua scripts match pattern: " + pattern)
+	}
+
+	RegisterScripts(rm, scripts)
+	return nil
+}
+
+// scriptList 按名称排序的注册脚本
+func (rm *RedisManager) scriptList() ([]string, []*luaScript) {
+	rm.scriptsMutex.RLock()
+	defer rm.scriptsMutex.RUnlock()
+
+	names := make([]string, 0, len(rm.scripts))
+	for name := range rm.scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scripts := make([]*luaScript, len(names))
+	for i, name := range names {
+		scripts[i] = rm.scripts[name]
+	}
+	return names, scripts
+}
+
+// evalScript 以 EVALSHA 执行脚本，服务端未缓存该脚本（NOSCRIPT）时改用 EVAL 执行，EVAL 同时会把脚本重新缓存到服务端
+// NOSCRIPT 通常意味着 Redis 重启、故障转移或执行了 SCRIPT FLUSH，此时在后台重新加载所有注册的脚本
+func (rm *RedisManager) evalScript(script *luaScript, keys []string, args ...interface{}) (interface{}, error) {
+	script.lastUsed.Store(time.Now().UnixNano())
+	val, err := rm.activeClient().EvalSha(rm.ctx, script.sha, keys, args...).Result()
+	if err != nil && redis.HasErrorPrefix(err, "NOSCRIPT") {
+		go rm.loadScripts()
+		return rm.activeClient().Eval(rm.ctx, script.source, keys, args...).Result()
+	}
+	return val, err
+}
+
+// VerifyScripts 检查各节点是否缓存了所有注册的脚本并加载缺失的脚本，返回各节点检查时缺失的脚本
+// 集群和哨兵模式包括从节点（故障转移后从节点会成为主节点）；任一节点检查或加载失败时结果为 REDIS_INNER_ERROR，Val 中仍包含各节点的状态
+func (rm *RedisManager) VerifyScripts() CacheResult[[]ScriptNodeStatus] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]ScriptNodeStatus](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	nodes, err := rm.syncScripts(rm.GetClient())
+	if err != nil {
+		rm.stats.IncrError()
+		return CacheResult[[]ScriptNodeStatus]{Val: nodes, ErrCode: innerErrorCode(err), Err: err}
+	}
+	return NewCacheResult(nodes)
+}
+
+// loadScripts 在所有节点上加载注册的脚本，客户端建立、恢复健康和出现 NOSCRIPT 时调用，同一时间只执行一次
+func (rm *RedisManager) loadScripts() {
+	client := rm.GetClient()
+	if client == nil || !rm.loadingScripts.CompareAndSwap(false, true) {
+		return
+	}
+	defer rm.loadingScripts.Store(false)
+
+	nodes, err := rm.syncScripts(client)
+	if err != nil {
+		rm.Logger().Warn("Redis script preload failed", "error", err)
+	}
+	for _, node := range nodes {
+		if len(node.Missing) > 0 {
+			rm.Logger().Info("Redis scripts loaded", "node", node.Addr, "count", len(node.Missing))
+		}
+	}
+}
+
+// loadNodeScripts 在集群新发现的节点上加载注册的脚本
+func (rm *RedisManager) loadNodeScripts(node *redis.Client) {
+	names, scripts := rm.scriptList()
+	status, err := syncNodeScripts(rm.ctx, node, names, scripts)
+	if err != nil {
+		rm.Logger().Debug("Redis script preload failed", "node", status.Addr, "error", err)
+	}
+}
+
+// syncScripts 检查并加载所有节点上的注册脚本，返回按地址排序的各节点状态和第一个错误
+func (rm *RedisManager) syncScripts(client RedisClient) ([]ScriptNodeStatus, error) {
+	names, scripts := rm.scriptList()
+
+	var mu sync.Mutex
+	var nodes []ScriptNodeStatus
+	var firstErr error
+	visit := func(ctx context.Context, node *redis.Client) error {
+		status, err := syncNodeScripts(ctx, node, names, scripts)
+
+		mu.Lock()
+		defer mu.Unlock()
+		nodes = append(nodes, status)
+		if firstErr == nil {
+			firstErr = err
+		}
+		return nil
+	}
+
+	switch c := client.(type) {
+	case *redis.ClusterClient:
+		_ = c.ForEachShard(rm.ctx, visit)
+	case *redis.Ring:
+		_ = c.ForEachShard(rm.ctx, visit)
+	case *redis.Client:
+		_ = visit(rm.ctx, c)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Addr < nodes[j].Addr
+	})
+	return nodes, firstErr
+}
+
+// syncNodeScripts 通过 SCRIPT EXISTS 检查节点缓存的脚本，并通过一条流水线加载缺失的脚本
+func syncNodeScripts(ctx context.Context, node *redis.Client, names []string, scripts []*luaScript) (ScriptNodeStatus, error) {
+	status := ScriptNodeStatus{Addr: node.Options().Addr}
+	if len(scripts) == 0 {
+		return status, nil
+	}
+
+	shas := make([]string, len(scripts))
+	for i, script := range scripts {
+		shas[i] = script.sha
+	}
+	exists, err := node.ScriptExists(ctx, shas...).Result()
+	if err != nil {
+		status.Error = err.Error()
+		return status, err
+	}
+
+	pipe := node.Pipeline()
+	for i, ok := range exists {
+		if !ok {
+			status.Missing = append(status.Missing, names[i])
+			pipe.ScriptLoad(ctx, scripts[i].source)
+		}
+	}
+	if len(status.Missing) == 0 {
+		return status, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		status.Error = err.Error()
+		return status, err
+	}
+	return status, nil
+}
@@ -0,0 +1,438 @@
+package redisx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RediSearch 模块封装：索引定义、文档写入、FT.SEARCH 查询和 FT.AGGREGATE 聚合
+// 需要服务端加载 RediSearch 模块（Redis 8 起内置），模块不可用时返回 MODULE_NOT_LOADED
+// go-redis 只在 RESP2 下解析 FT.SEARCH/FT.AGGREGATE 的响应（RESP3 下未开启 UnstableResp3 时拒绝执行，开启后结果为空），
+// 协商为 RESP3 时这两个命令以通用命令发送并在此解析 RESP3 响应，无需配置 Common.UnstableResp3
+
+// defaultSearchPageSize 默认分页大小
+const defaultSearchPageSize = 10
+
+// IndexDefinition 索引定义构建器（FT.CREATE）
+type IndexDefinition struct {
+	options redis.FTCreateOptions
+	schema  []*redis.FieldSchema
+}
+
+// NewIndexDefinition 创建基于 Hash 的索引定义，prefixes 为需要被索引的键前缀
+func NewIndexDefinition(prefixes ...string) *IndexDefinition {
+	def := &IndexDefinition{
+		options: redis.FTCreateOptions{OnHash: true},
+	}
+	for _, p := range prefixes {
+		def.options.Prefix = append(def.options.Prefix, p)
+	}
+	return def
+}
+
+// Filter 设置索引过滤表达式，只有满足表达式的文档才会被索引
+func (d *IndexDefinition) Filter(expr string) *IndexDefinition {
+	d.options.Filter = expr
+	return d
+}
+
+// TextField 添加全文检索字段，weight 为0时使用默认权重1
+func (d *IndexDefinition) TextField(name string, weight float64, sortable bool) *IndexDefinition {
+	d.schema = append(d.schema, &redis.FieldSchema{
+		FieldName: name,
+		FieldType: redis.SearchFieldTypeText,
+		Weight:    weight,
+		Sortable:  sortable,
+	})
+	return d
+}
+
+// TagField 添加标签字段，separator 为空时使用默认分隔符 ","
+func (d *IndexDefinition) TagField(name string, separator string) *IndexDefinition {
+	d.schema = append(d.schema, &redis.FieldSchema{
+		FieldName: name,
+		FieldType: redis.SearchFieldTypeTag,
+		Separator: separator,
+	})
+	return d
+}
+
+// NumericField 添加数值字段
+func (d *IndexDefinition) NumericField(name string, sortable bool) *IndexDefinition {
+	d.schema = append(d.schema, &redis.FieldSchema{
+		FieldName: name,
+		FieldType: redis.SearchFieldTypeNumeric,
+		Sortable:  sortable,
+	})
+	return d
+}
+
+// GeoField 添加地理位置字段（值格式为 "经度,纬度"）
+func (d *IndexDefinition) GeoField(name string) *IndexDefinition {
+	d.schema = append(d.schema, &redis.FieldSchema{
+		FieldName: name,
+		FieldType: redis.SearchFieldTypeGeo,
+	})
+	return d
+}
+
+// SearchQuery 类型化的查询构建器（FT.SEARCH）
+// 多个条件之间为 AND 关系
+type SearchQuery struct {
+	clauses  []string
+	options  redis.FTSearchOptions
+	page     int
+	pageSize int
+}
+
+// NewSearchQuery 创建查询，text 为全文检索关键字，为空时匹配全部文档
+func NewSearchQuery(text string) *SearchQuery {
+	q := &SearchQuery{
+		page:     1,
+		pageSize: defaultSearchPageSize,
+	}
+	if text != "" {
+		q.clauses = append(q.clauses, text)
+	}
+	return q
+}
+
+// Text 在指定字段中做全文检索
+func (q *SearchQuery) Text(field, text string) *SearchQuery {
+	q.clauses = append(q.clauses, fmt.Sprintf("@%s:(%s)", field, text))
+	return q
+}
+
+// Tag 匹配包含任一指定标签的文档
+func (q *SearchQuery) Tag(field string, values ...string) *SearchQuery {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = escapeTagValue(v)
+	}
+	q.clauses = append(q.clauses, fmt.Sprintf("@%s:{%s}", field, strings.Join(escaped, " | ")))
+	return q
+}
+
+// NumericRange 匹配数值字段在 [min, max] 范围内的文档，min/max 可使用 "-inf"、"+inf" 或 "(" 开区间前缀
+func (q *SearchQuery) NumericRange(field string, min, max string) *SearchQuery {
+	q.clauses = append(q.clauses, fmt.Sprintf("@%s:[%s %s]", field, min, max))
+	return q
+}
+
+// Raw 追加原始查询表达式
+func (q *SearchQuery) Raw(expr string) *SearchQuery {
+	q.clauses = append(q.clauses, expr)
+	return q
+}
+
+// SortBy 按可排序字段排序
+func (q *SearchQuery) SortBy(field string, asc bool) *SearchQuery {
+	q.options.SortBy = []redis.FTSearchSortBy{{FieldName: field, Asc: asc, Desc: !asc}}
+	return q
+}
+
+// Return 只返回指定字段
+func (q *SearchQuery) Return(fields ...string) *SearchQuery {
+	for _, f := range fields {
+		q.options.Return = append(q.options.Return, redis.FTSearchReturn{FieldName: f})
+	}
+	return q
+}
+
+// WithScores 返回文档相关性得分
+func (q *SearchQuery) WithScores() *SearchQuery {
+	q.options.WithScores = true
+	return q
+}
+
+// Page 设置分页，page 从1开始
+func (q *SearchQuery) Page(page, pageSize int) *SearchQuery {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	q.page = page
+	q.pageSize = pageSize
+	return q
+}
+
+// String 返回查询表达式
+func (q *SearchQuery) String() string {
+	if len(q.clauses) == 0 {
+		return "*"
+	}
+	return strings.Join(q.clauses, " ")
+}
+
+// escapeTagValue 转义标签值中的特殊字符
+func escapeTagValue(v string) string {
+	var b strings.Builder
+	for _, r := range v {
+		if strings.ContainsRune(",.<>{}[]\"':;!@#$%^&*()-+=~|/\\ ", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SearchPage 分页查询结果
+type SearchPage struct {
+	Total    int64
+	Docs     []redis.Document
+	Page     int
+	PageSize int
+	HasMore  bool
+}
+
+// SearchIndex RediSearch 索引
+type SearchIndex struct {
+	rm   *RedisManager
+	name string
+}
+
+// NewSearchIndex 创建索引操作对象（不会在服务端创建索引，需调用 Create）
+func NewSearchIndex(rm *RedisManager, name string) *SearchIndex {
+	return &SearchIndex{
+		rm:   rm,
+		name: name,
+	}
+}
+
+// Create 按定义创建索引
+func (si *SearchIndex) Create(def *IndexDefinition) CacheResult[string] {
+	rm := si.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleSearch); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	if len(def.schema) == 0 {
+		return NewCacheError[string](INVALID_OPERATION, ErrInvalidOperation.WithMessage("index schema is empty"))
+	}
+
+	val, err := rm.activeClient().FTCreate(rm.ctx, si.name, &def.options, def.schema...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// Drop 删除索引，deleteDocs 为 true 时同时删除被索引的文档
+func (si *SearchIndex) Drop(deleteDocs bool) CacheResult[string] {
+	rm := si.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleSearch); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().FTDropIndexWithArgs(rm.ctx, si.name, &redis.FTDropIndexOptions{DeleteDocs: deleteDocs}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// IndexDocument 写入文档（Hash），键需匹配索引定义的前缀才会被索引
+func (si *SearchIndex) IndexDocument(key string, fields map[string]interface{}) CacheResult[int64] {
+	return si.rm.HMSet(key, fields)
+}
+
+// DeleteDocument 删除文档
+func (si *SearchIndex) DeleteDocument(keys ...string) CacheResult[int64] {
+	return si.rm.Del(keys...)
+}
+
+// Search 执行分页查询
+func (si *SearchIndex) Search(q *SearchQuery) CacheResult[SearchPage] {
+	rm := si.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[SearchPage](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleSearch); err != nil {
+		return NewCacheError[SearchPage](MODULE_NOT_LOADED, err)
+	}
+
+	options := q.options
+	options.LimitOffset = (q.page - 1) * q.pageSize
+	options.Limit = q.pageSize
+
+	val, err := rm.ftSearch(si.name, q.String(), &options)
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[SearchPage](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(SearchPage{
+		Total:    int64(val.Total),
+		Docs:     val.Docs,
+		Page:     q.page,
+		PageSize: q.pageSize,
+		HasMore:  q.page*q.pageSize < val.Total,
+	})
+}
+
+// Aggregate 执行聚合查询（FT.AGGREGATE），query 为空时匹配全部文档
+func (si *SearchIndex) Aggregate(query string, options *redis.FTAggregateOptions) CacheResult[*redis.FTAggregateResult] {
+	rm := si.rm
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*redis.FTAggregateResult](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleSearch); err != nil {
+		return NewCacheError[*redis.FTAggregateResult](MODULE_NOT_LOADED, err)
+	}
+
+	if query == "" {
+		query = "*"
+	}
+
+	val, err := rm.ftAggregate(si.name, query, options)
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[*redis.FTAggregateResult](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// ftSearch 执行 FT.SEARCH，RESP3 下自行解析响应
+func (rm *RedisManager) ftSearch(index, query string, options *redis.FTSearchOptions) (redis.FTSearchResult, error) {
+	if rm.Protocol() != 3 {
+		return rm.activeClient().FTSearchWithArgs(rm.ctx, index, query, options).Result()
+	}
+
+	// 通过未执行的流水线构造参数，与 go-redis 的参数格式保持一致
+	args := rm.activeClient().Pipeline().FTSearchWithArgs(rm.ctx, index, query, options).Args()
+	raw, err := rm.activeClient().Do(rm.ctx, args...).Result()
+	if err != nil {
+		return redis.FTSearchResult{}, err
+	}
+	return parseSearchResp3(raw)
+}
+
+// ftAggregate 执行 FT.AGGREGATE，RESP3 下自行解析响应
+func (rm *RedisManager) ftAggregate(index, query string, options *redis.FTAggregateOptions) (*redis.FTAggregateResult, error) {
+	if rm.Protocol() != 3 {
+		return rm.activeClient().FTAggregateWithArgs(rm.ctx, index, query, options).Result()
+	}
+
+	args := rm.activeClient().Pipeline().FTAggregateWithArgs(rm.ctx, index, query, options).Args()
+	raw, err := rm.activeClient().Do(rm.ctx, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseAggregateResp3(raw)
+}
+
+// resp3Map 将 RESP3 map 转换为以字符串为键的 map
+func resp3Map(v interface{}) (map[string]interface{}, bool) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[fmt.Sprint(k)] = v
+	}
+	return result, true
+}
+
+// resp3Results 解析 RESP3 响应的 total_results 和 results 部分
+// 格式为 {"attributes": [], "warning": [], "total_results": n, "format": "STRING", "results": [{...}]}
+func resp3Results(raw interface{}) (int, []map[string]interface{}, error) {
+	reply, ok := resp3Map(raw)
+	if !ok {
+		return 0, nil, fmt.Errorf("unexpected search reply type %T", raw)
+	}
+
+	total, _ := reply["total_results"].(int64)
+	items, _ := reply["results"].([]interface{})
+	results := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		result, ok := resp3Map(item)
+		if !ok {
+			return 0, nil, fmt.Errorf("unexpected search result type %T", item)
+		}
+		results = append(results, result)
+	}
+	return int(total), results, nil
+}
+
+// parseSearchResp3 解析 FT.SEARCH 的 RESP3 响应，每个结果为 {"id": ..., "score": ..., "payload": ..., "sortkey": ..., "extra_attributes": {...}}
+func parseSearchResp3(raw interface{}) (redis.FTSearchResult, error) {
+	total, results, err := resp3Results(raw)
+	if err != nil {
+		return redis.FTSearchResult{}, err
+	}
+
+	docs := make([]redis.Document, 0, len(results))
+	for _, result := range results {
+		doc := redis.Document{ID: fmt.Sprint(result["id"])}
+		if score, ok := result["score"].(float64); ok {
+			doc.Score = &score
+		}
+		if payload, ok := result["payload"].(string); ok {
+			doc.Payload = &payload
+		}
+		if sortKey, ok := result["sortkey"].(string); ok {
+			doc.SortKey = &sortKey
+		}
+		if attrs, ok := resp3Map(result["extra_attributes"]); ok {
+			doc.Fields = make(map[string]string, len(attrs))
+			for k, v := range attrs {
+				doc.Fields[k] = fmt.Sprint(v)
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return redis.FTSearchResult{Total: total, Docs: docs}, nil
+}
+
+// parseAggregateResp3 解析 FT.AGGREGATE 的 RESP3 响应，每行为 {"extra_attributes": {...}, "values": []}；WITHCURSOR 时响应为 [结果, 游标]
+func parseAggregateResp3(raw interface{}) (*redis.FTAggregateResult, error) {
+	if withCursor, ok := raw.([]interface{}); ok && len(withCursor) > 0 {
+		raw = withCursor[0]
+	}
+	total, results, err := resp3Results(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]redis.AggregateRow, 0, len(results))
+	for _, result := range results {
+		fields, _ := resp3Map(result["extra_attributes"])
+		if fields == nil {
+			fields = map[string]interface{}{}
+		}
+		rows = append(rows, redis.AggregateRow{Fields: fields})
+	}
+	return &redis.FTAggregateResult{Total: total, Rows: rows}, nil
+}
@@ -0,0 +1,66 @@
+package redisx
+
+import "testing"
+
+// RESP3 下 FT.SEARCH 的响应
+const searchReplyResp3 = "%5\r\n" +
+	"+attributes\r\n*0\r\n" +
+	"+warning\r\n*0\r\n" +
+	"+total_results\r\n:2\r\n" +
+	"+format\r\n+STRING\r\n" +
+	"+results\r\n*2\r\n" +
+	"%3\r\n+id\r\n$5\r\ndoc:1\r\n+extra_attributes\r\n%1\r\n$5\r\ntitle\r\n$5\r\nhello\r\n+values\r\n*0\r\n" +
+	"%3\r\n+id\r\n$5\r\ndoc:2\r\n+extra_attributes\r\n%1\r\n$5\r\ntitle\r\n$5\r\nworld\r\n+values\r\n*0\r\n"
+
+// RESP3 下 FT.AGGREGATE 的响应
+const aggregateReplyResp3 = "%5\r\n" +
+	"+attributes\r\n*0\r\n" +
+	"+warning\r\n*0\r\n" +
+	"+total_results\r\n:1\r\n" +
+	"+format\r\n+STRING\r\n" +
+	"+results\r\n*1\r\n" +
+	"%2\r\n+extra_attributes\r\n%1\r\n$5\r\ncount\r\n$1\r\n2\r\n+values\r\n*0\r\n"
+
+func searchModuleReply(args []string) string {
+	switch args[0] {
+	case "module":
+		return "*1\r\n%2\r\n$4\r\nname\r\n$6\r\nsearch\r\n$3\r\nver\r\n:80000\r\n"
+	case "ft.search":
+		return searchReplyResp3
+	case "ft.aggregate":
+		return aggregateReplyResp3
+	}
+	return ""
+}
+
+func TestSearchDefaultProtocol(t *testing.T) {
+	s := newFakeServer(t, searchModuleReply)
+	rm := newTestManager(t, s.addr, nil)
+	if rm.Protocol() != 3 {
+		t.Fatalf("protocol = %d, want 3", rm.Protocol())
+	}
+
+	res := NewSearchIndex(rm, "idx").Search(NewSearchQuery("hello"))
+	if !res.IsOK() {
+		t.Fatalf("search failed: %v", res.Err)
+	}
+	if res.Val.Total != 2 || len(res.Val.Docs) != 2 {
+		t.Fatalf("search returned total=%d docs=%d, want 2 and 2", res.Val.Total, len(res.Val.Docs))
+	}
+	if doc := res.Val.Docs[0]; doc.ID != "doc:1" || doc.Fields["title"] != "hello" {
+		t.Errorf("first doc = %+v", doc)
+	}
+}
+
+func TestAggregateDefaultProtocol(t *testing.T) {
+	s := newFakeServer(t, searchModuleReply)
+	rm := newTestManager(t, s.addr, nil)
+
+	res := NewSearchIndex(rm, "idx").Aggregate("", nil)
+	if !res.IsOK() {
+		t.Fatalf("aggregate failed: %v", res.Err)
+	}
+	if res.Val.Total != 1 || len(res.Val.Rows) != 1 || res.Val.Rows[0].Fields["count"] != "2" {
+		t.Fatalf("aggregate returned %+v", res.Val)
+	}
+}
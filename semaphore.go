@@ -0,0 +1,155 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SemaphoreOptions 信号量选项
+type SemaphoreOptions struct {
+	TTL           time.Duration // 持有许可的过期时间，持有者崩溃后许可在过期后自动回收，默认 30s
+	RetryInterval time.Duration // Acquire 阻塞等待时的重试间隔，默认 100ms
+}
+
+// Semaphore 分布式计数信号量，限制跨实例同时访问稀缺资源的并发数
+// 每个 Semaphore 对象是一个持有者，可以持有多个许可；持有时间超过 TTL 的许可会被回收，长时间持有需调用 Refresh
+type Semaphore struct {
+	rm         *RedisManager
+	holdersKey string
+	expiryKey  string
+	limit      int64
+	holder     string
+	opts       SemaphoreOptions
+}
+
+// NewSemaphore 创建信号量，limit 为许可总数，同一个 key 的所有持有者应使用相同的 limit
+func NewSemaphore(rm *RedisManager, key string, limit int64, opts *SemaphoreOptions) (*Semaphore, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidConfig.WithMessage("semaphore limit must be positive")
+	}
+
+	var o SemaphoreOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.TTL <= 0 {
+		o.TTL = 30 * time.Second
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 100 * time.Millisecond
+	}
+
+	base := sameSlotBase(key)
+	return &Semaphore{
+		rm:         rm,
+		holdersKey: base + ":holders",
+		expiryKey:  base + ":expiry",
+		limit:      limit,
+		holder:     newTaskID(),
+		opts:       o,
+	}, nil
+}
+
+// semaphoreState 获取脚本的执行结果
+type semaphoreState struct {
+	acquired  bool
+	available int64
+}
+
+// acquire 执行获取脚本，n 为0时只查询可用许可数
+func (s *Semaphore) acquire(n int64) CacheResult[semaphoreState] {
+	result := s.rm.EvalScript(ScriptKeySemaphoreAcquire, []string{s.holdersKey, s.expiryKey},
+		s.holder, n, s.limit, time.Now().UnixMilli(), s.opts.TTL.Milliseconds())
+	if !result.IsOK() {
+		return NewCacheError[semaphoreState](result.ErrCode, result.Err)
+	}
+
+	vals, ok := result.Val.([]interface{})
+	if !ok || len(vals) != 2 {
+		return NewCacheError[semaphoreState](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+	acquired, ok1 := vals[0].(int64)
+	available, ok2 := vals[1].(int64)
+	if !ok1 || !ok2 {
+		return NewCacheError[semaphoreState](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(semaphoreState{acquired: acquired == 1, available: available})
+}
+
+// TryAcquire 尝试获取 n 个许可，不阻塞；可用许可不足时返回 false
+func (s *Semaphore) TryAcquire(n int64) CacheResult[bool] {
+	if n <= 0 || n > s.limit {
+		return NewCacheError[bool](INVALID_OPERATION, ErrInvalidOperation.WithMessage("permits must be in (0, limit]"))
+	}
+
+	res := s.acquire(n)
+	if !res.IsOK() {
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(res.Val.acquired)
+}
+
+// Acquire 阻塞获取 n 个许可，直到成功或 ctx 结束
+// ctx 超时返回 TIMEOUT，ctx 取消返回 INTERRUPTED
+func (s *Semaphore) Acquire(ctx context.Context, n int64) CacheResult[bool] {
+	for {
+		res := s.TryAcquire(n)
+		if !res.IsOK() || res.Val {
+			return res
+		}
+
+		if !sleepContext(ctx, s.opts.RetryInterval) {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NewCacheError[bool](TIMEOUT, ErrOperationTimeout.WithError(ctx.Err()))
+			}
+			return NewCacheError[bool](INTERRUPTED, ErrInterrupted.WithError(ctx.Err()))
+		}
+	}
+}
+
+// Release 释放 n 个许可，返回本持有者剩余的许可数
+func (s *Semaphore) Release(n int64) CacheResult[int64] {
+	if n <= 0 {
+		return NewCacheError[int64](INVALID_OPERATION, ErrInvalidOperation.WithMessage("permits must be positive"))
+	}
+
+	result := s.rm.EvalScript(ScriptKeySemaphoreRelease, []string{s.holdersKey, s.expiryKey}, s.holder, n)
+	if !result.IsOK() {
+		return NewCacheError[int64](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[int64](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(val)
+}
+
+// Available 查询当前可用的许可数（会先回收已过期持有者的许可）
+func (s *Semaphore) Available() CacheResult[int64] {
+	res := s.acquire(0)
+	if !res.IsOK() {
+		return NewCacheError[int64](res.ErrCode, res.Err)
+	}
+	return NewCacheResult(res.Val.available)
+}
+
+// Refresh 将本持有者许可的过期时间重置为 TTL，未持有许可时返回 false
+func (s *Semaphore) Refresh() CacheResult[bool] {
+	result := s.rm.EvalScript(ScriptKeySemaphoreRefresh, []string{s.holdersKey, s.expiryKey},
+		s.holder, time.Now().UnixMilli(), s.opts.TTL.Milliseconds())
+	if !result.IsOK() {
+		return NewCacheError[bool](result.ErrCode, result.Err)
+	}
+
+	val, ok := result.Val.(int64)
+	if !ok {
+		return NewCacheError[bool](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(val == 1)
+}
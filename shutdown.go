@@ -0,0 +1,128 @@
+package redisx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// shutdownPollInterval 等待正在执行的命令完成时的检查间隔
+const shutdownPollInterval = 10 * time.Millisecond
+
+// Shutdown 优雅关闭：先停止后台任务（流消费者、调度器、任务队列、流生产者、锁看门狗、订阅）并等待其处理完当前消息，
+// 再停止接受新操作（返回 CONNECTION_FAILED），等待正在执行的操作（包括 GetOrLoad 执行 loader 期间）完成后关闭客户端
+// ctx 结束时仍有后台任务或操作未完成则强制关闭并返回 ctx 的错误
+func (rm *RedisManager) Shutdown(ctx context.Context) error {
+	drainErr := rm.stopWorkers(ctx)
+
+	rm.closing.Store(true)
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for rm.inflight.Load() > 0 && drainErr == nil {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			drainErr = ctx.Err()
+			rm.Logger().Warn("Redis manager shutdown deadline exceeded", "inflight", rm.inflight.Load())
+		}
+	}
+
+	// 等待期间新启动的后台任务
+	if err := rm.stopWorkers(ctx); err != nil && drainErr == nil {
+		drainErr = err
+	}
+
+	if err := rm.Close(); err != nil && drainErr == nil {
+		return err
+	}
+	return drainErr
+}
+
+// workerSet 正在运行的后台任务及其停止函数
+type workerSet struct {
+	mu    sync.Mutex
+	stops map[any]func()
+}
+
+// addWorker 登记后台任务，Shutdown 时调用 stop 停止；stop 需等待任务退出
+func (rm *RedisManager) addWorker(key any, stop func()) {
+	rm.workers.mu.Lock()
+	defer rm.workers.mu.Unlock()
+	if rm.workers.stops == nil {
+		rm.workers.stops = make(map[any]func())
+	}
+	rm.workers.stops[key] = stop
+}
+
+// removeWorker 后台任务停止后取消登记
+func (rm *RedisManager) removeWorker(key any) {
+	rm.workers.mu.Lock()
+	defer rm.workers.mu.Unlock()
+	delete(rm.workers.stops, key)
+}
+
+// stopWorkers 并发停止所有登记的后台任务，ctx 结束时不再等待并返回 ctx 的错误
+func (rm *RedisManager) stopWorkers(ctx context.Context) error {
+	rm.workers.mu.Lock()
+	stops := make([]func(), 0, len(rm.workers.stops))
+	for _, stop := range rm.workers.stops {
+		stops = append(stops, stop)
+	}
+	rm.workers.stops = nil
+	rm.workers.mu.Unlock()
+
+	if len(stops) == 0 {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for _, stop := range stops {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stop()
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		rm.Logger().Warn("Redis manager shutdown deadline exceeded while stopping workers")
+		return ctx.Err()
+	}
+}
+
+// inflightHook 统计正在执行的命令数的 go-redis 钩子，安装在最外层，覆盖通过 GetClient 直接执行的命令
+type inflightHook struct {
+	rm *RedisManager
+}
+
+func (h inflightHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h inflightHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.rm.inflight.Add(1)
+		defer h.rm.inflight.Add(-1)
+		return next(ctx, cmd)
+	}
+}
+
+func (h inflightHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.rm.inflight.Add(1)
+		defer h.rm.inflight.Add(-1)
+		return next(ctx, cmds)
+	}
+}
@@ -0,0 +1,268 @@
+package redisx
+
+// RedisBloom 模块的 Top-K（TOPK.*）与 Count-Min Sketch（CMS.*）命令封装
+// 用于热门元素统计等频率估计场景，模块不可用时返回 MODULE_NOT_LOADED
+
+// TopKReserve 创建保留 k 个最高频元素的 Top-K 结构
+func (rm *RedisManager) TopKReserve(key string, k int64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKReserve(rm.ctx, key, k).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKReserveWithOptions 以指定宽度、深度和衰减系数创建 Top-K 结构
+func (rm *RedisManager) TopKReserveWithOptions(key string, k int64, width, depth int64, decay float64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKReserveWithOptions(rm.ctx, key, k, width, depth, decay).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKAdd 向 Top-K 添加元素，返回因此被挤出 Top-K 的元素（未挤出时对应位置为空字符串）
+func (rm *RedisManager) TopKAdd(key string, elements ...interface{}) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKAdd(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKIncrBy 按 element, increment 交替排列的参数增加元素计数，返回被挤出的元素
+func (rm *RedisManager) TopKIncrBy(key string, elements ...interface{}) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKIncrBy(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKQuery 判断元素当前是否在 Top-K 中
+func (rm *RedisManager) TopKQuery(key string, elements ...interface{}) CacheResult[[]bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]bool](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKQuery(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]bool](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKList 获取 Top-K 中的全部元素
+func (rm *RedisManager) TopKList(key string) CacheResult[[]string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKList(rm.ctx, key).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// TopKListWithCount 获取 Top-K 中的全部元素及其估计计数
+func (rm *RedisManager) TopKListWithCount(key string) CacheResult[map[string]int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[map[string]int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[map[string]int64](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().TopKListWithCount(rm.ctx, key).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[map[string]int64](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CMSInitByDim 以指定宽度和深度初始化 Count-Min Sketch
+func (rm *RedisManager) CMSInitByDim(key string, width, depth int64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CMSInitByDim(rm.ctx, key, width, depth).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CMSInitByProb 以允许的误差率和误差概率初始化 Count-Min Sketch
+func (rm *RedisManager) CMSInitByProb(key string, errorRate, probability float64) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CMSInitByProb(rm.ctx, key, errorRate, probability).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CMSIncrBy 按 element, increment 交替排列的参数增加元素计数，返回增加后的估计计数
+func (rm *RedisManager) CMSIncrBy(key string, elements ...interface{}) CacheResult[[]int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]int64](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CMSIncrBy(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]int64](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CMSQuery 查询元素的估计计数
+func (rm *RedisManager) CMSQuery(key string, elements ...interface{}) CacheResult[[]int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[[]int64](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CMSQuery(rm.ctx, key, elements...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]int64](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// CMSMerge 将多个 Count-Min Sketch 合并到 destKey（要求宽度和深度一致）
+func (rm *RedisManager) CMSMerge(destKey string, sourceKeys ...string) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if err := rm.requireModule(ModuleBloom); err != nil {
+		return NewCacheError[string](MODULE_NOT_LOADED, err)
+	}
+
+	val, err := rm.activeClient().CMSMerge(rm.ctx, destKey, sourceKeys...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](moduleErrorCode(err), err)
+	}
+
+	return NewCacheResult(val)
+}
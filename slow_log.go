@@ -0,0 +1,165 @@
+package redisx
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SlowLogConfig 客户端慢操作日志配置
+type SlowLogConfig struct {
+	Threshold time.Duration `json:"threshold,omitempty" yaml:"threshold,omitempty"` // 耗时达到该值的命令记为慢操作，默认 100ms
+	Size      int           `json:"size,omitempty" yaml:"size,omitempty"`           // 保留的最近慢操作条数，默认 128
+	Stack     bool          `json:"stack,omitempty" yaml:"stack,omitempty"`         // 是否记录调用栈
+	Log       bool          `json:"log,omitempty" yaml:"log,omitempty"`             // 是否同时通过 Logger 输出（Warn 级别）
+}
+
+// setDefaults 设置默认值
+func (c *SlowLogConfig) setDefaults() {
+	if c.Threshold <= 0 {
+		c.Threshold = 100 * time.Millisecond
+	}
+	if c.Size <= 0 {
+		c.Size = 128
+	}
+}
+
+// SlowLogEntry 一条慢操作记录
+type SlowLogEntry struct {
+	Time     time.Time     // 命令开始时间
+	Command  string        // 命令名，流水线为 "pipeline"
+	Keys     []string      // 涉及的键
+	Duration time.Duration // 耗时
+	Err      error         // 命令错误（键不存在不视为错误）
+	Stack    string        // 调用栈，未启用时为空
+}
+
+// slowLog 固定容量的环形缓冲区
+type slowLog struct {
+	mu      sync.Mutex
+	config  SlowLogConfig
+	entries []SlowLogEntry
+	next    int
+	full    bool
+}
+
+// newSlowLog 创建慢操作日志
+func newSlowLog(config SlowLogConfig) *slowLog {
+	return &slowLog{
+		config:  config,
+		entries: make([]SlowLogEntry, config.Size),
+	}
+}
+
+// add 写入一条记录，缓冲区已满时覆盖最早的记录
+func (l *slowLog) add(entry SlowLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// list 按时间倒序返回所有记录
+func (l *slowLog) list() []SlowLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.next
+	if l.full {
+		n = len(l.entries)
+	}
+	result := make([]SlowLogEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		result = append(result, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return result
+}
+
+// reset 清空记录
+func (l *slowLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	clear(l.entries)
+	l.next = 0
+	l.full = false
+}
+
+// SlowLog 获取最近的慢操作记录（最新的在前），未启用慢操作日志时返回 nil
+func (rm *RedisManager) SlowLog() []SlowLogEntry {
+	if rm.slowLog == nil {
+		return nil
+	}
+	return rm.slowLog.list()
+}
+
+// ResetSlowLog 清空慢操作记录
+func (rm *RedisManager) ResetSlowLog() {
+	if rm.slowLog != nil {
+		rm.slowLog.reset()
+	}
+}
+
+// isSlow 命令耗时是否达到慢操作阈值
+func (rm *RedisManager) isSlow(elapsed time.Duration) bool {
+	return rm.slowLog != nil && elapsed >= rm.slowLog.config.Threshold
+}
+
+// recordSlow 记录慢操作
+func (rm *RedisManager) recordSlow(start time.Time, elapsed time.Duration, command string, keys []string, err error) {
+	l := rm.slowLog
+	if errorCodeOf(err) == KEY_NOT_FOUND {
+		err = nil
+	}
+	entry := SlowLogEntry{Time: start, Command: command, Keys: keys, Duration: elapsed, Err: err}
+	if l.config.Stack {
+		entry.Stack = string(debug.Stack())
+	}
+	l.add(entry)
+
+	if l.config.Log {
+		rm.Logger().Warn("Redis slow operation", "command", command, "keys", keys, "latency", elapsed, "error", err)
+	}
+}
+
+// slowLogHook 记录慢操作的 go-redis 钩子
+type slowLogHook struct {
+	rm *RedisManager
+}
+
+func (h slowLogHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h slowLogHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		if elapsed := time.Since(start); h.rm.isSlow(elapsed) {
+			h.rm.recordSlow(start, elapsed, cmd.Name(), commandKeys(cmd), err)
+		}
+		return err
+	}
+}
+
+func (h slowLogHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		if elapsed := time.Since(start); h.rm.isSlow(elapsed) {
+			var keys []string
+			for _, cmd := range cmds {
+				keys = append(keys, commandKeys(cmd)...)
+			}
+			h.rm.recordSlow(start, elapsed, "pipeline", keys, err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,146 @@
+package redisx
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// StatsSnapshot 某一时刻的统计快照
+type StatsSnapshot struct {
+	Time      time.Time
+	Mode      RedisMode
+	Healthy   bool
+	Total     int64         // 操作总数
+	Errors    int64         // 失败操作数
+	ErrorRate float64       // Errors / Total
+	Uptime    time.Duration // 管理器运行时间
+
+	ErrorClasses map[ErrorClass]int64      // 按类别统计的命令错误数
+	Commands     []CommandMetrics          // 各命令的耗时和错误统计
+	Namespaces   map[string]NamespaceStats // 各命名空间的读取统计
+	HotKeys      []HotKey                  // 估算访问次数最高的键，未启用热点键检测时为空
+	Locks        LockStats                 // 分布式锁统计
+	Pool         PoolStats                 // 连接池统计
+	Replicas     []ReplicaLag              // 各从节点的复制延迟，未启用监控时为空
+}
+
+// StatsSink 统计快照的接收方（如推送到 StatsD、写入文件或看板），在统计输出 goroutine 中按 StatsInterval 周期调用
+type StatsSink interface {
+	OnSnapshot(snapshot StatsSnapshot)
+}
+
+// StatsSinkFunc 以函数实现 StatsSink
+type StatsSinkFunc func(snapshot StatsSnapshot)
+
+// OnSnapshot 实现 StatsSink
+func (f StatsSinkFunc) OnSnapshot(snapshot StatsSnapshot) {
+	f(snapshot)
+}
+
+// StatsSnapshot 获取当前统计快照
+func (rm *RedisManager) StatsSnapshot() StatsSnapshot {
+	total, errors, uptime := rm.stats.GetStats()
+	snapshot := StatsSnapshot{
+		Time:         time.Now(),
+		Mode:         rm.config.Mode,
+		Healthy:      rm.IsHealthy(),
+		Total:        total,
+		Errors:       errors,
+		Uptime:       uptime,
+		ErrorClasses: rm.stats.ErrorClasses(),
+		Commands:     rm.CommandMetrics(),
+		Namespaces:   rm.stats.NamespaceStats(),
+		HotKeys:      rm.stats.TopKeys(5),
+		Locks:        rm.LockStats(),
+		Pool:         rm.PoolStats(),
+		Replicas:     rm.ReplicationLag(),
+	}
+	if total > 0 {
+		snapshot.ErrorRate = float64(errors) / float64(total)
+	}
+	return snapshot
+}
+
+// SetStatsSinks 设置统计快照的接收方，替换默认的日志输出（使用管理器的 Logger）；不传参数时恢复为日志输出
+// 需启用 EnableStats
+func (rm *RedisManager) SetStatsSinks(sinks ...StatsSink) {
+	rm.statsSinksMutex.Lock()
+	defer rm.statsSinksMutex.Unlock()
+	rm.statsSinks = sinks
+}
+
+// emitStats 生成快照并发送给所有接收方
+func (rm *RedisManager) emitStats() {
+	rm.statsSinksMutex.RLock()
+	sinks := rm.statsSinks
+	if len(sinks) == 0 {
+		sinks = []StatsSink{rm.logStats}
+	}
+	rm.statsSinksMutex.RUnlock()
+
+	snapshot := rm.StatsSnapshot()
+	for _, sink := range sinks {
+		sink.OnSnapshot(snapshot)
+	}
+}
+
+// LogStatsSink 通过 Logger 输出统计快照（默认的统计输出方式）
+type LogStatsSink struct {
+	logger       Logger
+	poolTimeouts atomic.Uint32 // 上次输出时的连接池等待超时次数
+}
+
+// NewLogStatsSink 创建日志统计输出，logger 为 nil 时使用 slog.Default()
+func NewLogStatsSink(logger Logger) *LogStatsSink {
+	if logger == nil {
+		logger = NewSlogLogger(nil)
+	}
+	return &LogStatsSink{logger: logger}
+}
+
+// OnSnapshot 实现 StatsSink
+func (s *LogStatsSink) OnSnapshot(snapshot StatsSnapshot) {
+	logger := s.logger
+	logger.Info("Redis stats", "total", snapshot.Total, "errors", snapshot.Errors, "uptime", snapshot.Uptime,
+		"error_rate", snapshot.ErrorRate, "healthy", snapshot.Healthy)
+
+	if len(snapshot.ErrorClasses) > 0 {
+		args := make([]any, 0, 2*len(snapshot.ErrorClasses))
+		for class, n := range snapshot.ErrorClasses {
+			args = append(args, string(class), n)
+		}
+		logger.Info("Redis error classes", args...)
+	}
+
+	for prefix, ns := range snapshot.Namespaces {
+		logger.Info("Redis namespace stats", "namespace", prefix, "hits", ns.Hits, "misses", ns.Misses,
+			"errors", ns.Errors, "hit_rate", ns.HitRate, "error_rate", ns.ErrorRate)
+	}
+
+	for _, key := range snapshot.HotKeys {
+		if key.Hot {
+			logger.Warn("Redis hot key detected", "key", key.Key, "estimated_count", key.Count,
+				"rate", key.Rate, "suggested_shards", key.Shards)
+		}
+	}
+
+	// 没有锁操作时不输出
+	if l := snapshot.Locks; l.Acquired > 0 || l.Contended > 0 {
+		logger.Info("Redis lock stats", "acquired", l.Acquired, "contended", l.Contended, "released", l.Released,
+			"renew_failures", l.RenewFailures, "expired", l.Expired, "avg_wait", l.AvgWait(), "max_wait", l.MaxWait,
+			"avg_hold", l.AvgHold(), "max_hold", l.MaxHold)
+	}
+
+	for _, r := range snapshot.Replicas {
+		logger.Info("Redis replica lag", "master", r.Master, "replica", r.Replica, "state", r.State,
+			"offset_lag", r.OffsetLag, "lag", r.Lag, "exceeded", r.Exceeded)
+	}
+
+	p := snapshot.Pool
+	logger.Info("Redis pool stats", "hits", p.Hits, "misses", p.Misses, "timeouts", p.Timeouts,
+		"wait_count", p.WaitCount, "wait_duration", p.WaitDuration, "total_conns", p.TotalConns,
+		"idle_conns", p.IdleConns, "in_use", p.InUse(), "stale_conns", p.StaleConns, "pool_size", p.PoolSize)
+	if last := s.poolTimeouts.Swap(p.Timeouts); p.Timeouts > last {
+		logger.Warn("Redis pool exhausted, connection wait timed out", "timeouts", p.Timeouts-last, "pool_size", p.PoolSize)
+	}
+}
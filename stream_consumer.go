@@ -0,0 +1,376 @@
+package redisx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamHandler 流消息处理函数，返回 nil 时消息被确认（XACK）
+type StreamHandler func(ctx context.Context, msg StreamMessage) error
+
+// StreamConsumerConfig 流消费者配置
+type StreamConsumerConfig struct {
+	Stream       string
+	Group        string
+	Consumer     string
+	StartID      string        // 消费者组不存在时的起始ID，默认 "$"
+	Workers      int           // 并发工作协程数，默认 1
+	BatchSize    int64         // 单次 XREADGROUP 读取的消息数，默认 10
+	BlockTimeout time.Duration // 单次读取的最长阻塞时间，默认 5s
+	MaxRetries   int           // 处理失败后的本地重试次数，默认 3，-1 表示不重试
+	RetryBackoff time.Duration // 首次重试的退避时间，之后指数递增，默认 100ms
+	MaxBackoff   time.Duration // 退避时间上限，默认 5s
+
+	// 死信配置：消息投递次数达到 MaxDeliveries 且处理仍失败时，附带失败信息转入 DeadLetterStream 并确认原消息
+	DeadLetterStream string // 为空时不启用死信
+	MaxDeliveries    int64  // 默认 1，即首次投递的重试耗尽后即转入死信；大于 1 时失败的消息由滞留消息回收重新投递，未配置 ClaimMinIdle 时自动启用
+
+	// 滞留消息回收：定期通过 XAUTOCLAIM 认领空闲超过 ClaimMinIdle 的待确认消息（如崩溃消费者遗留的消息、本消费者处理失败的消息）并重新处理
+	// ClaimMinIdle 应大于单条消息处理（含本地重试）的最长耗时，否则仍在处理的消息会被重复认领
+	ClaimMinIdle  time.Duration // 为0时不启用回收（MaxDeliveries 大于 1 时默认 30s）
+	ClaimInterval time.Duration // 回收扫描间隔，默认为 ClaimMinIdle 的一半
+}
+
+// StreamConsumerStats 流消费者统计
+// Lag 为消费者组尚未读取的消息数（Redis 7.0+，不可用时为 -1），Pending 为已读取但未确认的消息数
+type StreamConsumerStats struct {
+	Processed  int64
+	Failed     int64
+	Retries    int64
+	DeadLetter int64
+	Claimed    int64 // 从其他消费者回收的消息数
+	Lag        int64
+	Pending    int64
+	Throughput float64 // 启动以来每秒成功处理的消息数
+}
+
+// StreamConsumer 基于消费者组的流消费者
+// 多个工作协程以同一个消费者名称通过 XREADGROUP 读取消息，处理成功后 XACK，失败时按指数退避重试
+// 重试耗尽的消息保留在待确认列表中，配置死信流后达到投递上限的消息转入死信流
+type StreamConsumer struct {
+	rm      *RedisManager
+	config  StreamConsumerConfig
+	handler StreamHandler
+
+	processed atomic.Int64
+	failed    atomic.Int64
+	retries   atomic.Int64
+	dead      atomic.Int64
+	claimed   atomic.Int64
+
+	mutex     sync.Mutex
+	running   bool
+	startedAt time.Time
+	cancel    context.CancelFunc
+	done      chan struct{} // 本次运行的所有协程退出后关闭
+}
+
+// NewStreamConsumer 创建流消费者
+func NewStreamConsumer(rm *RedisManager, config StreamConsumerConfig, handler StreamHandler) (*StreamConsumer, error) {
+	if config.Stream == "" || config.Group == "" || config.Consumer == "" {
+		return nil, ErrInvalidConfig.WithMessage("stream consumer requires stream, group and consumer")
+	}
+	if handler == nil {
+		return nil, ErrInvalidConfig.WithMessage("stream handler is nil")
+	}
+
+	if config.StartID == "" {
+		config.StartID = "$"
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 10
+	}
+	if config.BlockTimeout <= 0 {
+		config.BlockTimeout = 5 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	} else if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 5 * time.Second
+	}
+	if config.MaxDeliveries <= 0 {
+		config.MaxDeliveries = 1
+	}
+	// 工作协程只读取新消息（>），失败的消息只能通过回收重新投递
+	if config.MaxDeliveries > 1 && config.ClaimMinIdle <= 0 {
+		config.ClaimMinIdle = 30 * time.Second
+	}
+	if config.ClaimMinIdle > 0 && config.ClaimInterval <= 0 {
+		config.ClaimInterval = config.ClaimMinIdle / 2
+	}
+
+	return &StreamConsumer{
+		rm:      rm,
+		config:  config,
+		handler: handler,
+	}, nil
+}
+
+// Start 创建消费者组（已存在则忽略）并启动工作协程
+// ctx 取消或调用 Stop 时停止消费
+func (c *StreamConsumer) Start(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.running {
+		return ErrInvalidOperation.WithMessage("stream consumer already started")
+	}
+
+	if res := c.rm.XGroupCreate(c.config.Stream, c.config.Group, c.config.StartID, true); !res.IsOK() {
+		return res.Err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	c.cancel = cancel
+	c.done = done
+	c.running = true
+	c.startedAt = time.Now()
+
+	var wg sync.WaitGroup
+	run := func(loop func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loop(runCtx)
+		}()
+	}
+	for i := 0; i < c.config.Workers; i++ {
+		run(c.worker)
+	}
+	if c.config.ClaimMinIdle > 0 {
+		run(c.claimLoop)
+	}
+	c.rm.addWorker(c, c.Stop)
+
+	// ctx 结束或调用 Stop 后所有协程退出时重置运行状态，之后可以再次 Start
+	go func() {
+		wg.Wait()
+		cancel()
+		c.mutex.Lock()
+		c.running = false
+		c.rm.removeWorker(c)
+		c.mutex.Unlock()
+		close(done)
+	}()
+
+	return nil
+}
+
+// Stop 停止消费并等待正在处理的消息完成
+func (c *StreamConsumer) Stop() {
+	c.mutex.Lock()
+	if !c.running {
+		c.mutex.Unlock()
+		return
+	}
+	cancel, done := c.cancel, c.done
+	c.mutex.Unlock()
+
+	cancel()
+	<-done
+}
+
+// worker 工作协程：循环读取并处理新消息
+func (c *StreamConsumer) worker(ctx context.Context) {
+	streams := map[string]string{c.config.Stream: ">"}
+	opts := &XReadOptions{Count: c.config.BatchSize, Block: c.config.BlockTimeout}
+
+	for ctx.Err() == nil {
+		res := c.rm.XReadGroup(ctx, c.config.Group, c.config.Consumer, streams, opts)
+		if !res.IsOK() {
+			if ctx.Err() != nil {
+				return
+			}
+			c.rm.Logger().Warn("Redis stream consumer read failed", "stream", c.config.Stream, "group", c.config.Group, "error", res.Err)
+			if !sleepContext(ctx, c.config.RetryBackoff) {
+				return
+			}
+			continue
+		}
+
+		for _, msg := range res.Val {
+			c.process(ctx, msg)
+		}
+	}
+}
+
+// claimLoop 定期回收滞留在待确认列表中的消息
+func (c *StreamConsumer) claimLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.config.ClaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.claimStale(ctx)
+		}
+	}
+}
+
+// claimStale 完整扫描一遍待确认列表，认领并处理空闲超时的消息
+func (c *StreamConsumer) claimStale(ctx context.Context) {
+	start := "0-0"
+	for ctx.Err() == nil {
+		res := c.rm.XAutoClaim(c.config.Stream, c.config.Group, c.config.Consumer,
+			c.config.ClaimMinIdle, start, c.config.BatchSize)
+		if !res.IsOK() {
+			c.rm.Logger().Warn("Redis stream consumer autoclaim failed", "stream", c.config.Stream, "group", c.config.Group, "error", res.Err)
+			return
+		}
+
+		for _, msg := range res.Val.Messages {
+			// 已被删除的消息没有内容，无需处理
+			if msg.Values == nil {
+				continue
+			}
+			c.claimed.Add(1)
+			c.process(ctx, msg)
+		}
+
+		if res.Val.Next == "0-0" || res.Val.Next == "" {
+			return
+		}
+		start = res.Val.Next
+	}
+}
+
+// process 处理单条消息，失败时按指数退避重试
+func (c *StreamConsumer) process(ctx context.Context, msg StreamMessage) {
+	backoff := c.config.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := c.handler(ctx, msg)
+		if err == nil {
+			c.processed.Add(1)
+			if res := c.rm.XAck(c.config.Stream, c.config.Group, msg.ID); !res.IsOK() {
+				c.rm.Logger().Warn("Redis stream consumer ack failed", "stream", c.config.Stream, "id", msg.ID, "error", res.Err)
+			}
+			return
+		}
+
+		if attempt >= c.config.MaxRetries {
+			c.failed.Add(1)
+			c.rm.Logger().Warn("Redis stream consumer handler failed", "stream", c.config.Stream, "id", msg.ID, "attempts", attempt+1, "error", err)
+			if c.config.DeadLetterStream != "" {
+				c.maybeDeadLetter(msg, err)
+			}
+			return
+		}
+
+		c.retries.Add(1)
+		if !sleepContext(ctx, backoff) {
+			return
+		}
+		backoff *= 2
+		if backoff > c.config.MaxBackoff {
+			backoff = c.config.MaxBackoff
+		}
+	}
+}
+
+// maybeDeadLetter 投递次数达到上限时将消息转入死信流并确认原消息
+func (c *StreamConsumer) maybeDeadLetter(msg StreamMessage, cause error) {
+	res := c.rm.XPendingExt(&redis.XPendingExtArgs{
+		Stream: c.config.Stream,
+		Group:  c.config.Group,
+		Start:  msg.ID,
+		End:    msg.ID,
+		Count:  1,
+	})
+	if !res.IsOK() || len(res.Val) == 0 {
+		c.rm.Logger().Warn("Redis stream consumer delivery count unavailable", "stream", c.config.Stream, "id", msg.ID, "error", res.Err)
+		return
+	}
+
+	deliveries := res.Val[0].RetryCount
+	if deliveries < c.config.MaxDeliveries {
+		return
+	}
+
+	values := make(map[string]interface{}, len(msg.Values)+7)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values[dlqFieldSourceStream] = c.config.Stream
+	values[dlqFieldSourceID] = msg.ID
+	values[dlqFieldGroup] = c.config.Group
+	values[dlqFieldConsumer] = c.config.Consumer
+	values[dlqFieldError] = cause.Error()
+	values[dlqFieldDeliveries] = deliveries
+	values[dlqFieldFailedAt] = time.Now().UnixMilli()
+
+	// 先写入死信流再确认原消息，写入失败时消息留在待确认列表中等待下次处理
+	if add := c.rm.XAdd(c.config.DeadLetterStream, values, nil); !add.IsOK() {
+		c.rm.Logger().Error("Redis stream consumer dead-letter failed", "stream", c.config.Stream, "id", msg.ID, "error", add.Err)
+		return
+	}
+	c.dead.Add(1)
+
+	if ack := c.rm.XAck(c.config.Stream, c.config.Group, msg.ID); !ack.IsOK() {
+		c.rm.Logger().Warn("Redis stream consumer ack failed", "stream", c.config.Stream, "id", msg.ID, "error", ack.Err)
+	}
+}
+
+// Stats 获取消费者统计，Lag 和 Pending 通过 XINFO GROUPS 从服务端查询
+func (c *StreamConsumer) Stats() CacheResult[StreamConsumerStats] {
+	c.mutex.Lock()
+	startedAt := c.startedAt
+	c.mutex.Unlock()
+
+	stats := StreamConsumerStats{
+		Processed:  c.processed.Load(),
+		Failed:     c.failed.Load(),
+		Retries:    c.retries.Load(),
+		DeadLetter: c.dead.Load(),
+		Claimed:    c.claimed.Load(),
+		Lag:        -1,
+	}
+	if !startedAt.IsZero() {
+		if elapsed := time.Since(startedAt).Seconds(); elapsed > 0 {
+			stats.Throughput = float64(stats.Processed) / elapsed
+		}
+	}
+
+	res := c.rm.XInfoGroups(c.config.Stream)
+	if !res.IsOK() {
+		return NewCacheError[StreamConsumerStats](res.ErrCode, res.Err)
+	}
+	for _, g := range res.Val {
+		if g.Name == c.config.Group {
+			stats.Lag = g.Lag
+			stats.Pending = g.Pending
+			break
+		}
+	}
+
+	return NewCacheResult(stats)
+}
+
+// sleepContext 等待指定时间，context 结束时提前返回 false
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,43 @@
+package redisx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// ctx 结束后消费者回到未运行状态，可以再次 Start
+func TestStreamConsumerRestartAfterCancel(t *testing.T) {
+	s := newFakeServer(t, func(args []string) string {
+		if args[0] == "xreadgroup" {
+			time.Sleep(10 * time.Millisecond)
+			return "_\r\n"
+		}
+		return ""
+	})
+	rm := newTestManager(t, s.addr, nil)
+	c, err := NewStreamConsumer(rm, StreamConsumerConfig{Stream: "orders", Group: "billing", Consumer: "c1"},
+		func(ctx context.Context, msg StreamMessage) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := c.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := c.Start(context.Background())
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Start after cancel = %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Stop()
+}
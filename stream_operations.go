@@ -0,0 +1,600 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==== Stream Operations ====
+
+// XAddOptions XADD 的可选参数
+// ID 为空时由服务端自动生成；MaxLen 与 MinID 二选一用于裁剪，Approx 为 true 时使用近似裁剪（~）
+type XAddOptions struct {
+	ID         string
+	MaxLen     int64
+	MinID      string
+	Approx     bool
+	Limit      int64
+	NoMkStream bool
+}
+
+// xAddArgs 将 XAddOptions 转换为 go-redis 的参数
+func xAddArgs(stream string, values map[string]interface{}, opts *XAddOptions) (*redis.XAddArgs, *RedisError) {
+	args := &redis.XAddArgs{
+		Stream: stream,
+		Values: values,
+	}
+	if opts == nil {
+		return args, nil
+	}
+
+	if opts.MaxLen > 0 && opts.MinID != "" {
+		return nil, ErrInvalidOperation.WithMessage("xadd: MaxLen and MinID are mutually exclusive")
+	}
+	if opts.Limit > 0 && !opts.Approx {
+		return nil, ErrInvalidOperation.WithMessage("xadd: Limit requires approximate trimming")
+	}
+
+	args.ID = opts.ID
+	args.MaxLen = opts.MaxLen
+	args.MinID = opts.MinID
+	args.Approx = opts.Approx
+	args.Limit = opts.Limit
+	args.NoMkStream = opts.NoMkStream
+	return args, nil
+}
+
+// XAdd 向流追加一条消息，返回消息ID；opts 为 nil 时自动生成ID且不裁剪
+// 设置 NoMkStream 且流不存在时返回 KEY_NOT_FOUND
+func (rm *RedisManager) XAdd(stream string, values map[string]interface{}, opts *XAddOptions) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	args, rerr := xAddArgs(stream, values, opts)
+	if rerr != nil {
+		return NewCacheError[string](INVALID_OPERATION, rerr)
+	}
+
+	val, err := rm.activeClient().XAdd(rm.ctx, args).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XTrimMaxLen 裁剪流使其最多保留 maxLen 条消息，返回删除的消息数量
+// approx 为 true 时使用近似裁剪（~），以整个宏节点为单位删除，开销更低但可能多保留少量消息
+func (rm *RedisManager) XTrimMaxLen(stream string, maxLen int64, approx bool) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	var val int64
+	var err error
+	if approx {
+		val, err = rm.activeClient().XTrimMaxLenApprox(rm.ctx, stream, maxLen, 0).Result()
+	} else {
+		val, err = rm.activeClient().XTrimMaxLen(rm.ctx, stream, maxLen).Result()
+	}
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XTrimMinID 删除ID小于 minID 的消息（Redis 6.2+），返回删除的消息数量，approx 含义同 XTrimMaxLen
+func (rm *RedisManager) XTrimMinID(stream, minID string, approx bool) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	var val int64
+	var err error
+	if approx {
+		val, err = rm.activeClient().XTrimMinIDApprox(rm.ctx, stream, minID, 0).Result()
+	} else {
+		val, err = rm.activeClient().XTrimMinID(rm.ctx, stream, minID).Result()
+	}
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XTrimMaxAge 删除早于 maxAge 之前写入的消息，仅适用于使用自动生成ID（毫秒时间戳前缀）的流
+func (rm *RedisManager) XTrimMaxAge(stream string, maxAge time.Duration, approx bool) CacheResult[int64] {
+	return rm.XTrimMinID(stream, minIDForAge(maxAge), approx)
+}
+
+// minIDForAge 根据保留时长计算 MINID
+func minIDForAge(maxAge time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(-maxAge).UnixMilli(), 10) + "-0"
+}
+
+// StreamMessage 从流中读取到的一条消息
+type StreamMessage struct {
+	Stream string
+	ID     string
+	Values map[string]interface{}
+}
+
+// XReadOptions XREAD/XREADGROUP 的可选参数
+// Block 为0时不阻塞；大于0时最多等待 Block；小于0时一直等待直到有消息或context结束
+// NoAck 仅对 XReadGroup 生效，读取的消息不进入待确认列表
+type XReadOptions struct {
+	Count int64
+	Block time.Duration
+	NoAck bool
+}
+
+// xStreamArgs 将 流->起始ID 映射展开为 STREAMS 参数（按流名排序，保证参数稳定）
+func xStreamArgs(streams map[string]string) []string {
+	names := make([]string, 0, len(streams))
+	for name := range streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]string, 0, len(names)*2)
+	args = append(args, names...)
+	for _, name := range names {
+		args = append(args, streams[name])
+	}
+	return args
+}
+
+// xMessagesToStream 将单个流的消息转换为消息列表
+func xMessagesToStream(stream string, messages []redis.XMessage) []StreamMessage {
+	msgs := make([]StreamMessage, 0, len(messages))
+	for _, m := range messages {
+		msgs = append(msgs, StreamMessage{Stream: stream, ID: m.ID, Values: m.Values})
+	}
+	return msgs
+}
+
+// toStreamMessages 将 go-redis 的结果展开为消息列表
+func toStreamMessages(streams []redis.XStream) []StreamMessage {
+	var msgs []StreamMessage
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			msgs = append(msgs, StreamMessage{Stream: s.Stream, ID: m.ID, Values: m.Values})
+		}
+	}
+	return msgs
+}
+
+// blockingPollInterval 阻塞读取单次在服务端等待的最长时间
+// 长时间阻塞被切分为多个短周期，既能及时响应context取消，也避免长期独占连接池中的连接
+const blockingPollInterval = time.Second
+
+// xread 内部方法：执行一次或多次（阻塞模式）流读取
+// 阻塞模式下按 blockingPollInterval 切分等待，等待结束仍无消息时返回空列表
+func (rm *RedisManager) xread(ctx context.Context, opts *XReadOptions, read func(block time.Duration) ([]redis.XStream, error)) CacheResult[[]StreamMessage] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]StreamMessage](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	if opts == nil {
+		opts = &XReadOptions{}
+	}
+
+	// 非阻塞：不携带 BLOCK 参数
+	if opts.Block == 0 {
+		val, err := read(-1)
+		if errors.Is(err, redis.Nil) {
+			return NewCacheResult([]StreamMessage{})
+		} else if err != nil {
+			rm.stats.IncrError()
+			return NewCacheError[[]StreamMessage](REDIS_INNER_ERROR, err)
+		}
+		return NewCacheResult(toStreamMessages(val))
+	}
+
+	var deadline time.Time
+	if opts.Block > 0 {
+		deadline = time.Now().Add(opts.Block)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return NewCacheError[[]StreamMessage](TIMEOUT, ErrOperationTimeout.WithError(err))
+			}
+			return NewCacheError[[]StreamMessage](INTERRUPTED, ErrInterrupted.WithError(err))
+		}
+
+		wait := blockingPollInterval
+		if d, ok := ctx.Deadline(); ok && time.Until(d) < wait {
+			wait = time.Until(d)
+		}
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return NewCacheResult([]StreamMessage{})
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		// BLOCK 0 在服务端表示永久阻塞，至少等待1毫秒
+		if wait < time.Millisecond {
+			wait = time.Millisecond
+		}
+
+		val, err := read(wait)
+		if errors.Is(err, redis.Nil) {
+			continue
+		} else if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			rm.stats.IncrError()
+			return NewCacheError[[]StreamMessage](REDIS_INNER_ERROR, err)
+		}
+
+		msgs := toStreamMessages(val)
+		if len(msgs) == 0 {
+			continue
+		}
+		return NewCacheResult(msgs)
+	}
+}
+
+// XRead 从一个或多个流读取消息，streams 为 流名->起始ID（"$" 表示只读新消息）
+// 无消息时返回空列表；阻塞期间context取消返回 INTERRUPTED，context超时返回 TIMEOUT
+func (rm *RedisManager) XRead(ctx context.Context, streams map[string]string, opts *XReadOptions) CacheResult[[]StreamMessage] {
+	if opts == nil {
+		opts = &XReadOptions{}
+	}
+
+	// 阻塞读取被切分为多次请求，"$" 需要先解析为当前最后一条消息的ID，避免两次请求之间到达的消息丢失
+	var args []string
+	return rm.xread(ctx, opts, func(block time.Duration) ([]redis.XStream, error) {
+		if args == nil {
+			if block >= 0 {
+				resolved, err := rm.resolveLastIDs(ctx, streams)
+				if err != nil {
+					return nil, err
+				}
+				streams = resolved
+			}
+			args = xStreamArgs(streams)
+		}
+
+		return rm.activeClient().XRead(ctx, &redis.XReadArgs{
+			Streams: args,
+			Count:   opts.Count,
+			Block:   block,
+		}).Result()
+	})
+}
+
+// resolveLastIDs 将起始ID为 "$" 的流替换为流中最后一条消息的ID（空流为 "0-0"）
+func (rm *RedisManager) resolveLastIDs(ctx context.Context, streams map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(streams))
+	for name, id := range streams {
+		if id != "$" {
+			resolved[name] = id
+			continue
+		}
+
+		last, err := rm.activeClient().XRevRangeN(ctx, name, "+", "-", 1).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		if len(last) > 0 {
+			resolved[name] = last[0].ID
+		} else {
+			resolved[name] = "0-0"
+		}
+	}
+	return resolved, nil
+}
+
+// XReadGroup 以消费者组身份读取消息，streams 为 流名->起始ID（">" 表示读取未分配的新消息，"0" 表示读取自己的待确认消息）
+// 返回值约定同 XRead
+func (rm *RedisManager) XReadGroup(ctx context.Context, group, consumer string, streams map[string]string, opts *XReadOptions) CacheResult[[]StreamMessage] {
+	if opts == nil {
+		opts = &XReadOptions{}
+	}
+	args := xStreamArgs(streams)
+
+	return rm.xread(ctx, opts, func(block time.Duration) ([]redis.XStream, error) {
+		return rm.activeClient().XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: consumer,
+			Streams:  args,
+			Count:    opts.Count,
+			Block:    block,
+			NoAck:    opts.NoAck,
+		}).Result()
+	})
+}
+
+// ==== Consumer Group Operations ====
+
+// XGroupCreate 创建消费者组，start 为组的起始ID（"$" 只消费新消息，"0" 从头消费）
+// mkStream 为 true 时流不存在则自动创建；组已存在视为成功，便于消费者启动时重复调用
+func (rm *RedisManager) XGroupCreate(stream, group, start string, mkStream bool) CacheResult[string] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[string](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	var val string
+	var err error
+	if mkStream {
+		val, err = rm.activeClient().XGroupCreateMkStream(rm.ctx, stream, group, start).Result()
+	} else {
+		val, err = rm.activeClient().XGroupCreate(rm.ctx, stream, group, start).Result()
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return NewCacheResult("OK")
+		}
+		rm.stats.IncrError()
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XGroupDestroy 删除消费者组，返回删除的组数量
+func (rm *RedisManager) XGroupDestroy(stream, group string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XGroupDestroy(rm.ctx, stream, group).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XGroupDelConsumer 从消费者组中删除消费者，返回该消费者被丢弃的待确认消息数量
+func (rm *RedisManager) XGroupDelConsumer(stream, group, consumer string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XGroupDelConsumer(rm.ctx, stream, group, consumer).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XAck 确认消息已处理，返回成功确认的数量
+func (rm *RedisManager) XAck(stream, group string, ids ...string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XAck(rm.ctx, stream, group, ids...).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XPending 获取消费者组待确认消息的汇总信息（总数、ID范围、各消费者待确认数量）
+func (rm *RedisManager) XPending(stream, group string) CacheResult[*redis.XPending] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*redis.XPending](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XPending(rm.ctx, stream, group).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[*redis.XPending](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XPendingExt 获取待确认消息明细（所属消费者、空闲时间、投递次数），可按消费者和最小空闲时间过滤
+func (rm *RedisManager) XPendingExt(args *redis.XPendingExtArgs) CacheResult[[]redis.XPendingExt] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.XPendingExt](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XPendingExt(rm.ctx, args).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.XPendingExt](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XClaim 将空闲时间不少于 minIdle 的待确认消息转移给指定消费者，返回成功认领的消息
+func (rm *RedisManager) XClaim(stream, group, consumer string, minIdle time.Duration, ids ...string) CacheResult[[]StreamMessage] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]StreamMessage](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XClaim(rm.ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[[]StreamMessage](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(xMessagesToStream(stream, val))
+}
+
+// XAutoClaimResult XAUTOCLAIM 的结果，Next 为下一轮扫描的起始ID，为 "0-0" 时表示已扫描完整个待确认列表
+type XAutoClaimResult struct {
+	Messages []StreamMessage
+	Next     string
+}
+
+// XAutoClaim 从 start 开始扫描待确认列表，将空闲时间不少于 minIdle 的消息转移给指定消费者（Redis 6.2+）
+func (rm *RedisManager) XAutoClaim(stream, group, consumer string, minIdle time.Duration, start string, count int64) CacheResult[XAutoClaimResult] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[XAutoClaimResult](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, next, err := rm.activeClient().XAutoClaim(rm.ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    start,
+		Count:    count,
+	}).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[XAutoClaimResult](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(XAutoClaimResult{Messages: xMessagesToStream(stream, val), Next: next})
+}
+
+// XLen 获取流中的消息数量
+func (rm *RedisManager) XLen(stream string) CacheResult[int64] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[int64](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XLen(rm.ctx, stream).Result()
+	if err != nil {
+		rm.stats.IncrError()
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XInfoStream 获取流的概要信息，流不存在时返回 KEY_NOT_FOUND
+func (rm *RedisManager) XInfoStream(stream string) CacheResult[*redis.XInfoStream] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[*redis.XInfoStream](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XInfoStream(rm.ctx, stream).Result()
+	if err != nil {
+		if isNoSuchKeyErr(err) {
+			return NewCacheError[*redis.XInfoStream](KEY_NOT_FOUND, ErrKeyNotFound)
+		}
+		rm.stats.IncrError()
+		return NewCacheError[*redis.XInfoStream](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XInfoGroups 获取流上所有消费者组的信息（含待确认数量和 Redis 7+ 的 lag），流不存在时返回 KEY_NOT_FOUND
+func (rm *RedisManager) XInfoGroups(stream string) CacheResult[[]redis.XInfoGroup] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.XInfoGroup](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XInfoGroups(rm.ctx, stream).Result()
+	if err != nil {
+		if isNoSuchKeyErr(err) {
+			return NewCacheError[[]redis.XInfoGroup](KEY_NOT_FOUND, ErrKeyNotFound)
+		}
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.XInfoGroup](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// XInfoConsumers 获取消费者组内各消费者的信息（待确认数量、空闲时间）
+func (rm *RedisManager) XInfoConsumers(stream, group string) CacheResult[[]redis.XInfoConsumer] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[[]redis.XInfoConsumer](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	val, err := rm.activeClient().XInfoConsumers(rm.ctx, stream, group).Result()
+	if err != nil {
+		if isNoSuchKeyErr(err) {
+			return NewCacheError[[]redis.XInfoConsumer](KEY_NOT_FOUND, ErrKeyNotFound)
+		}
+		rm.stats.IncrError()
+		return NewCacheError[[]redis.XInfoConsumer](REDIS_INNER_ERROR, err)
+	}
+
+	return NewCacheResult(val)
+}
+
+// isNoSuchKeyErr 判断是否为 XINFO 等命令在键不存在时返回的错误
+func isNoSuchKeyErr(err error) bool {
+	return strings.HasPrefix(err.Error(), "ERR no such key")
+}
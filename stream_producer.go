@@ -0,0 +1,291 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// StreamProducerConfig 流生产者配置
+// MaxLen 和 MaxAge 为保留策略，设置后后台定期裁剪流；两者都设置时同时生效
+// BatchSize 大于0时启用批量写入：Enqueue 的消息先进入内存缓冲区，按数量或时间间隔通过 Pipeline 批量 XADD
+type StreamProducerConfig struct {
+	Stream            string
+	BatchSize         int           // 单批写入的消息数，0 表示不启用缓冲
+	FlushInterval     time.Duration // 消息在缓冲区中的最长停留时间，默认 100ms
+	BufferSize        int           // 缓冲区容量，缓冲区满时 Enqueue 阻塞（背压），默认 BatchSize*10
+	MaxLen            int64         // 最多保留的消息数，0 表示不限制
+	MaxAge            time.Duration // 消息最长保留时间（基于自动生成ID中的时间戳），0 表示不限制
+	RetentionInterval time.Duration // 裁剪间隔，默认 1 分钟
+	ExactTrim         bool          // 使用精确裁剪，默认近似裁剪（~）
+}
+
+// StreamProducer 流生产者，负责写入消息并按保留策略裁剪流，避免流无限增长
+// 高吞吐场景可启用批量写入，减少网络往返
+type StreamProducer struct {
+	rm     *RedisManager
+	config StreamProducerConfig
+
+	buffer   chan map[string]interface{}
+	flushReq chan chan error
+	done     chan struct{} // 批量写入协程退出（Close 或管理器关闭）时关闭
+	closed   bool
+	mutex    sync.RWMutex
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewStreamProducer 创建流生产者，配置了保留策略时启动后台裁剪协程
+func NewStreamProducer(rm *RedisManager, config StreamProducerConfig) (*StreamProducer, error) {
+	if config.Stream == "" {
+		return nil, ErrInvalidConfig.WithMessage("stream producer requires stream")
+	}
+	if config.MaxLen < 0 || config.MaxAge < 0 {
+		return nil, ErrInvalidConfig.WithMessage("stream retention must not be negative")
+	}
+	if config.RetentionInterval <= 0 {
+		config.RetentionInterval = time.Minute
+	}
+	if config.BatchSize < 0 {
+		return nil, ErrInvalidConfig.WithMessage("stream producer batch size must not be negative")
+	}
+	if config.BatchSize > 0 {
+		if config.FlushInterval <= 0 {
+			config.FlushInterval = 100 * time.Millisecond
+		}
+		if config.BufferSize < config.BatchSize {
+			config.BufferSize = config.BatchSize * 10
+		}
+	}
+
+	ctx, cancel := context.WithCancel(rm.ctx)
+	p := &StreamProducer{
+		rm:     rm,
+		config: config,
+		cancel: cancel,
+	}
+
+	if config.MaxLen > 0 || config.MaxAge > 0 {
+		p.wg.Add(1)
+		go p.retentionLoop(ctx)
+	}
+	if config.BatchSize > 0 {
+		p.buffer = make(chan map[string]interface{}, config.BufferSize)
+		p.flushReq = make(chan chan error)
+		p.done = make(chan struct{})
+		p.wg.Add(1)
+		go p.flushLoop(ctx)
+	}
+	rm.addWorker(p, p.Close)
+
+	return p, nil
+}
+
+// Send 写入一条消息，返回消息ID
+func (p *StreamProducer) Send(values map[string]interface{}) CacheResult[string] {
+	return p.rm.XAdd(p.config.Stream, values, nil)
+}
+
+// Enqueue 将消息放入缓冲区等待批量写入，缓冲区满时阻塞直到有空位或 ctx 结束，生产者或管理器已关闭时返回错误
+// 未启用批量写入时直接写入；批量写入的失败只记录日志，需要确认写入结果时调用 Flush
+func (p *StreamProducer) Enqueue(ctx context.Context, values map[string]interface{}) error {
+	if p.buffer == nil {
+		res := p.Send(values)
+		if !res.IsOK() {
+			return res.Err
+		}
+		return nil
+	}
+
+	// 持有读锁期间 Close 无法完成，保证写入缓冲区的消息都会被刷出
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.closed {
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	}
+	select {
+	case <-p.done:
+		// 管理器已关闭，批量写入协程已退出，写入缓冲区的消息不会再被刷出
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	default:
+	}
+
+	select {
+	case p.buffer <- values:
+		return nil
+	case <-p.done:
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrOperationTimeout.WithError(ctx.Err())
+		}
+		return ErrInterrupted.WithError(ctx.Err())
+	}
+}
+
+// Flush 立即写入缓冲区中的全部消息并等待完成，返回本次写入的错误
+func (p *StreamProducer) Flush(ctx context.Context) error {
+	if p.buffer == nil {
+		return nil
+	}
+
+	p.mutex.RLock()
+	closed := p.closed
+	p.mutex.RUnlock()
+	if closed {
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	}
+
+	reply := make(chan error, 1)
+	select {
+	case p.flushReq <- reply:
+	case <-p.done:
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	case <-ctx.Done():
+		return ErrInterrupted.WithError(ctx.Err())
+	}
+
+	select {
+	case err := <-reply:
+		return err
+	case <-p.done:
+		// 退出前可能已完成本次写入
+		select {
+		case err := <-reply:
+			return err
+		default:
+		}
+		return ErrInvalidOperation.WithMessage("stream producer closed")
+	case <-ctx.Done():
+		return ErrInterrupted.WithError(ctx.Err())
+	}
+}
+
+// flushLoop 后台批量写入协程
+func (p *StreamProducer) flushLoop(ctx context.Context) {
+	defer p.wg.Done()
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]map[string]interface{}, 0, p.config.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			// 退出前写入缓冲区中剩余的消息
+			batch = p.drain(batch)
+			_ = p.writeBatch(batch)
+			return
+		case values := <-p.buffer:
+			batch = append(batch, values)
+			if len(batch) >= p.config.BatchSize {
+				_ = p.writeBatch(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				_ = p.writeBatch(batch)
+				batch = batch[:0]
+			}
+		case reply := <-p.flushReq:
+			batch = p.drain(batch)
+			reply <- p.writeBatch(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// drain 取出缓冲区中当前所有的消息
+func (p *StreamProducer) drain(batch []map[string]interface{}) []map[string]interface{} {
+	for {
+		select {
+		case values := <-p.buffer:
+			batch = append(batch, values)
+		default:
+			return batch
+		}
+	}
+}
+
+// writeBatch 按 BatchSize 分组通过 Pipeline 写入消息
+func (p *StreamProducer) writeBatch(batch []map[string]interface{}) error {
+	var firstErr error
+	for start := 0; start < len(batch); start += p.config.BatchSize {
+		end := start + p.config.BatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+
+		pipe := p.rm.Pipeline()
+		for _, values := range batch[start:end] {
+			pipe.XAdd(p.config.Stream, values, nil)
+		}
+		if res := pipe.Exec(); !res.IsOK() {
+			p.rm.Logger().Warn("Redis stream producer batch write failed", "stream", p.config.Stream, "size", end-start, "error", res.Err)
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Trim 按保留策略立即裁剪一次，返回删除的消息数量
+func (p *StreamProducer) Trim() CacheResult[int64] {
+	var trimmed int64
+	approx := !p.config.ExactTrim
+
+	if p.config.MaxLen > 0 {
+		res := p.rm.XTrimMaxLen(p.config.Stream, p.config.MaxLen, approx)
+		if !res.IsOK() {
+			return res
+		}
+		trimmed += res.Val
+	}
+	if p.config.MaxAge > 0 {
+		res := p.rm.XTrimMaxAge(p.config.Stream, p.config.MaxAge, approx)
+		if !res.IsOK() {
+			return CacheResult[int64]{Val: trimmed, ErrCode: res.ErrCode, Err: res.Err}
+		}
+		trimmed += res.Val
+	}
+
+	return NewCacheResult(trimmed)
+}
+
+// retentionLoop 后台定期裁剪
+func (p *StreamProducer) retentionLoop(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.RetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if res := p.Trim(); !res.IsOK() {
+				p.rm.Logger().Warn("Redis stream retention trim failed", "stream", p.config.Stream, "error", res.Err)
+			}
+		}
+	}
+}
+
+// Close 写入缓冲区中剩余的消息并停止后台协程
+func (p *StreamProducer) Close() {
+	p.closeOnce.Do(func() {
+		p.mutex.Lock()
+		p.closed = true
+		p.mutex.Unlock()
+
+		p.cancel()
+		p.wg.Wait()
+		p.rm.removeWorker(p)
+	})
+}
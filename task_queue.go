@@ -0,0 +1,239 @@
+package redisx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Task 队列中的任务
+type Task struct {
+	ID      string
+	Payload []byte
+}
+
+// TaskHandler 任务处理函数，返回错误时按队列配置重试
+type TaskHandler func(ctx context.Context, task Task) error
+
+// EnqueueOptions 入队选项
+type EnqueueOptions struct {
+	Delay    time.Duration // 延迟执行时间
+	DedupKey string        // 去重键，DedupTTL 内相同去重键的任务只入队一次
+	DedupTTL time.Duration // 去重有效期，默认使用队列配置
+}
+
+// TaskQueueConfig 任务队列配置
+// 重试、退避、死信和滞留回收参数与 StreamConsumerConfig 含义相同
+type TaskQueueConfig struct {
+	Name          string
+	Group         string // 默认 "workers"
+	Consumer      string
+	Workers       int
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	MaxBackoff    time.Duration
+	MaxDeliveries int64         // 默认 3
+	ClaimMinIdle  time.Duration // 默认 5 分钟
+	PollInterval  time.Duration // 延迟任务检查间隔，默认 1s
+	DedupTTL      time.Duration // 默认 24 小时
+}
+
+// TaskQueue 至少投递一次的任务队列
+// 就绪任务保存在流中由消费者组消费，延迟任务保存在有序集合中到期后转入流；
+// 多次投递仍失败的任务进入死信流。所有键使用相同的哈希标签，集群模式下位于同一个槽
+type TaskQueue struct {
+	rm     *RedisManager
+	config TaskQueueConfig
+
+	streamKey  string
+	delayedKey string
+	dlqKey     string
+
+	mutex    sync.Mutex
+	consumer *StreamConsumer
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewTaskQueue 创建任务队列
+func NewTaskQueue(rm *RedisManager, config TaskQueueConfig) (*TaskQueue, error) {
+	if config.Name == "" {
+		return nil, ErrInvalidConfig.WithMessage("task queue requires name")
+	}
+	if config.Group == "" {
+		config.Group = "workers"
+	}
+	if config.MaxDeliveries <= 0 {
+		config.MaxDeliveries = 3
+	}
+	if config.ClaimMinIdle <= 0 {
+		config.ClaimMinIdle = 5 * time.Minute
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.DedupTTL <= 0 {
+		config.DedupTTL = 24 * time.Hour
+	}
+
+	prefix := "{" + config.Name + "}"
+	return &TaskQueue{
+		rm:         rm,
+		config:     config,
+		streamKey:  prefix + ":stream",
+		delayedKey: prefix + ":delayed",
+		dlqKey:     prefix + ":dlq",
+	}, nil
+}
+
+// newTaskID 生成任务ID
+func newTaskID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Enqueue 任务入队，返回任务ID；去重键重复时不再入队，返回已存在的任务ID
+func (q *TaskQueue) Enqueue(payload []byte, opts *EnqueueOptions) CacheResult[string] {
+	if opts == nil {
+		opts = &EnqueueOptions{}
+	}
+
+	keys := []string{q.streamKey, q.delayedKey}
+	dedupTTL := opts.DedupTTL
+	if dedupTTL <= 0 {
+		dedupTTL = q.config.DedupTTL
+	}
+	if opts.DedupKey != "" {
+		keys = append(keys, "{"+q.config.Name+"}:dedup:"+opts.DedupKey)
+	}
+
+	var runAt int64
+	if opts.Delay > 0 {
+		runAt = time.Now().Add(opts.Delay).UnixMilli()
+	}
+
+	result := q.rm.EvalScript(ScriptKeyTaskEnqueue, keys, newTaskID(), payload, runAt, dedupTTL.Milliseconds())
+	if !result.IsOK() {
+		return NewCacheError[string](result.ErrCode, result.Err)
+	}
+
+	vals, ok := result.Val.([]interface{})
+	if !ok || len(vals) != 2 {
+		return NewCacheError[string](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+	id, ok := vals[1].(string)
+	if !ok {
+		return NewCacheError[string](REDIS_INNER_ERROR, fmt.Errorf("unexpected return type"))
+	}
+
+	return NewCacheResult(id)
+}
+
+// Start 启动工作协程消费任务，并在后台将到期的延迟任务转入就绪流
+func (q *TaskQueue) Start(ctx context.Context, handler TaskHandler) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.consumer != nil {
+		return ErrInvalidOperation.WithMessage("task queue already started")
+	}
+	if handler == nil {
+		return ErrInvalidOperation.WithMessage("task handler is nil")
+	}
+
+	consumer, err := NewStreamConsumer(q.rm, StreamConsumerConfig{
+		Stream:           q.streamKey,
+		Group:            q.config.Group,
+		Consumer:         q.config.Consumer,
+		StartID:          "0",
+		Workers:          q.config.Workers,
+		MaxRetries:       q.config.MaxRetries,
+		RetryBackoff:     q.config.RetryBackoff,
+		MaxBackoff:       q.config.MaxBackoff,
+		DeadLetterStream: q.dlqKey,
+		MaxDeliveries:    q.config.MaxDeliveries,
+		ClaimMinIdle:     q.config.ClaimMinIdle,
+	}, func(ctx context.Context, msg StreamMessage) error {
+		id, _ := msg.Values["task_id"].(string)
+		payload, _ := msg.Values["payload"].(string)
+		return handler(ctx, Task{ID: id, Payload: []byte(payload)})
+	})
+	if err != nil {
+		return err
+	}
+	if err := consumer.Start(ctx); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	q.consumer = consumer
+	q.cancel = cancel
+	q.wg.Add(1)
+	go q.promoteLoop(runCtx)
+	q.rm.addWorker(q, q.Stop)
+
+	return nil
+}
+
+// promoteLoop 定期转移到期的延迟任务
+func (q *TaskQueue) promoteLoop(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result := q.rm.EvalScript(ScriptKeyTaskPromote, []string{q.streamKey, q.delayedKey},
+				time.Now().UnixMilli(), 100)
+			if !result.IsOK() {
+				q.rm.Logger().Warn("Redis task queue promote failed", "queue", q.config.Name, "error", result.Err)
+			}
+		}
+	}
+}
+
+// Stop 停止消费并等待正在执行的任务完成
+func (q *TaskQueue) Stop() {
+	q.mutex.Lock()
+	consumer, cancel := q.consumer, q.cancel
+	q.consumer, q.cancel = nil, nil
+	q.mutex.Unlock()
+
+	if consumer == nil {
+		return
+	}
+	cancel()
+	q.wg.Wait()
+	consumer.Stop()
+	q.rm.removeWorker(q)
+}
+
+// Stats 获取任务消费统计
+func (q *TaskQueue) Stats() CacheResult[StreamConsumerStats] {
+	q.mutex.Lock()
+	consumer := q.consumer
+	q.mutex.Unlock()
+
+	if consumer == nil {
+		return NewCacheError[StreamConsumerStats](INVALID_OPERATION, ErrInvalidOperation.WithMessage("task queue not started"))
+	}
+	return consumer.Stats()
+}
+
+// DelayedCount 获取尚未到期的延迟任务数量
+func (q *TaskQueue) DelayedCount() CacheResult[int64] {
+	return q.rm.ZCard(q.delayedKey)
+}
+
+// DeadLetters 获取任务队列的死信流，用于查看和重新投递失败的任务
+func (q *TaskQueue) DeadLetters() *DeadLetterQueue {
+	return NewDeadLetterQueue(q.rm, q.dlqKey)
+}
@@ -0,0 +1,160 @@
+package redisx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 链路追踪使用的属性名（遵循 OpenTelemetry 数据库语义约定）
+const (
+	AttrDBSystem      = "db.system"
+	AttrDBOperation   = "db.operation"
+	AttrDBRedisKey    = "db.redis.key"
+	AttrDBBatchSize   = "db.operation.batch.size"
+	AttrServerAddress = "server.address"
+)
+
+// Tracer 链路追踪接口，StartSpan 创建客户端 span 并返回携带该 span 的 context
+// 对接 OpenTelemetry 时以 trace.Tracer.Start（SpanKindClient）实现，attrs 转换为 attribute.String
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span 追踪中的一个操作
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+// TracingOptions 链路追踪配置
+type TracingOptions struct {
+	Tracer   Tracer // 为 nil 时关闭追踪
+	HashKeys bool   // 是否以 SHA-256 摘要代替明文键名，避免敏感数据写入追踪系统
+}
+
+// SetTracing 设置链路追踪，每条命令（流水线整体）创建一个客户端 span
+// span 的父级取自命令的 context，在使用 rm 默认 context 的接口中为新的根 span
+// 集群和 Ring 模式下 span 在实际执行命令的节点上创建，重定向后的重试和按节点拆分的流水线各自一个 span；
+// server.address 为该节点地址，哨兵只读副本模式下每个连接随机选择从节点，不设置该属性
+func (rm *RedisManager) SetTracing(opts TracingOptions) {
+	rm.tracingMutex.Lock()
+	defer rm.tracingMutex.Unlock()
+	rm.tracing = opts
+}
+
+// tracingOptions 获取链路追踪配置
+func (rm *RedisManager) tracingOptions() TracingOptions {
+	rm.tracingMutex.RLock()
+	defer rm.tracingMutex.RUnlock()
+	return rm.tracing
+}
+
+// commandKey 命令的第一个键，无键命令返回空字符串
+func commandKey(cmd redis.Cmder) string {
+	pos := 1
+	switch cmd.Name() {
+	case "ping", "echo", "info", "hello", "auth", "select", "client", "cluster", "config",
+		"script", "function", "command", "dbsize", "flushdb", "flushall", "time", "scan", "quit":
+		return ""
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		// 参数为 name script numkeys key...
+		if args := cmd.Args(); len(args) < 4 || fmt.Sprint(args[2]) == "0" {
+			return ""
+		}
+		pos = 3
+	case "xread", "xreadgroup":
+		// 键位于 STREAMS 参数之后
+		for i, arg := range cmd.Args() {
+			if s, ok := arg.(string); ok && (s == "streams" || s == "STREAMS") {
+				pos = i + 1
+				break
+			}
+		}
+	}
+
+	args := cmd.Args()
+	if pos >= len(args) {
+		return ""
+	}
+	return fmt.Sprint(args[pos])
+}
+
+// hashKey 键名的 SHA-256 摘要（前 16 字节）
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// tracingHook 为每条命令创建客户端 span 的 go-redis 钩子，集群和 Ring 模式下安装在各节点上
+type tracingHook struct {
+	rm   *RedisManager
+	addr string // 执行命令的节点地址，未知时为空
+}
+
+func (h tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// attrs 公共属性
+func (h tracingHook) attrs(operation string) map[string]string {
+	attrs := map[string]string{
+		AttrDBSystem:    "redis",
+		AttrDBOperation: operation,
+	}
+	if h.addr != "" {
+		attrs[AttrServerAddress] = h.addr
+	}
+	return attrs
+}
+
+func (h tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		opts := h.rm.tracingOptions()
+		if opts.Tracer == nil {
+			return next(ctx, cmd)
+		}
+
+		attrs := h.attrs(cmd.Name())
+		if key := commandKey(cmd); key != "" {
+			if opts.HashKeys {
+				key = hashKey(key)
+			}
+			attrs[AttrDBRedisKey] = key
+		}
+
+		ctx, span := opts.Tracer.StartSpan(ctx, "redis."+cmd.Name(), attrs)
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if code := errorCodeOf(err); code != OK && code != KEY_NOT_FOUND {
+			span.RecordError(err)
+		}
+		return err
+	}
+}
+
+func (h tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		opts := h.rm.tracingOptions()
+		if opts.Tracer == nil {
+			return next(ctx, cmds)
+		}
+
+		attrs := h.attrs("pipeline")
+		attrs[AttrDBBatchSize] = strconv.Itoa(len(cmds))
+
+		ctx, span := opts.Tracer.StartSpan(ctx, "redis.pipeline", attrs)
+		defer span.End()
+
+		err := next(ctx, cmds)
+		if code := errorCodeOf(err); code != OK && code != KEY_NOT_FOUND {
+			span.RecordError(err)
+		}
+		return err
+	}
+}
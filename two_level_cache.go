@@ -0,0 +1,209 @@
+package redisx
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// TwoLevelCacheConfig 二级缓存配置
+type TwoLevelCacheConfig struct {
+	Namespace  string        // 命名空间，作为 Redis 键前缀（如 "user:"），同时用于区分失效通知频道，必填
+	MaxEntries int           // 本地缓存的最大条目数，默认 10000
+	LocalTTL   time.Duration // 本地条目的最长存活时间，用于限制错过失效通知时的不一致时长，默认 1min
+}
+
+// TwoLevelCacheStats 二级缓存统计
+type TwoLevelCacheStats struct {
+	LocalHits     int64   // 本地缓存命中次数
+	RemoteHits    int64   // 本地未命中、Redis 命中次数
+	Misses        int64   // 两级均未命中次数
+	Invalidations int64   // 收到的其他实例的失效通知数
+	LocalEntries  int     // 本地缓存当前条目数
+	HitRate       float64 // 两级合计命中率
+}
+
+// cacheInvalidation 失效通知
+type cacheInvalidation struct {
+	Origin string   `json:"o"`           // 发出通知的实例
+	Keys   []string `json:"k,omitempty"` // 失效的键（不含命名空间前缀）
+	All    bool     `json:"a,omitempty"` // 清空整个命名空间的本地缓存
+}
+
+// TwoLevelCache 二级缓存：进程内 LRU（L1）+ Redis（L2）
+// 通过本对象写入或删除时，其他实例通过 Pub/Sub 收到通知后移除本地条目；
+// 绕过本对象直接修改 Redis 的写入不会触发通知，只能等待本地条目过期
+type TwoLevelCache[T any] struct {
+	rm         *RedisManager
+	config     TwoLevelCacheConfig
+	instanceID string
+	local      *lruCache[T]
+	sub        *Subscription
+
+	localHits     atomic.Int64
+	remoteHits    atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// NewTwoLevelCache 创建二级缓存并订阅失效通知，ctx 结束或调用 Close 时停止订阅
+func NewTwoLevelCache[T any](ctx context.Context, rm *RedisManager, config TwoLevelCacheConfig) (*TwoLevelCache[T], error) {
+	if config.Namespace == "" {
+		return nil, ErrInvalidConfig.WithMessage("two-level cache requires namespace")
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 10000
+	}
+	if config.LocalTTL <= 0 {
+		config.LocalTTL = time.Minute
+	}
+
+	c := &TwoLevelCache[T]{
+		rm:         rm,
+		config:     config,
+		instanceID: newTaskID(),
+		local:      newLRUCache[T](config.MaxEntries, config.LocalTTL),
+	}
+
+	sub := SubscribeTyped(ctx, rm, c.onInvalidation, nil, c.channel())
+	if !sub.IsOK() {
+		return nil, sub.Err
+	}
+	c.sub = sub.Val
+
+	return c, nil
+}
+
+// channel 失效通知频道
+func (c *TwoLevelCache[T]) channel() string {
+	return "cache:invalidate:" + c.config.Namespace
+}
+
+// redisKey 键在 Redis 中的完整键名
+func (c *TwoLevelCache[T]) redisKey(key string) string {
+	return c.config.Namespace + key
+}
+
+// onInvalidation 处理其他实例的失效通知
+func (c *TwoLevelCache[T]) onInvalidation(ctx context.Context, msg cacheInvalidation) error {
+	if msg.Origin == c.instanceID {
+		return nil
+	}
+
+	c.invalidations.Add(1)
+	if msg.All {
+		c.local.clear()
+		return nil
+	}
+	for _, key := range msg.Keys {
+		c.local.remove(key)
+	}
+	return nil
+}
+
+// publish 发布失效通知，失败时只记录日志（其他实例的本地条目最迟在 LocalTTL 后过期）
+func (c *TwoLevelCache[T]) publish(msg cacheInvalidation) {
+	msg.Origin = c.instanceID
+	if res := PublishTyped(c.rm, c.channel(), msg); !res.IsOK() {
+		c.rm.Logger().Warn("Redis two-level cache publish invalidation failed", "namespace", c.config.Namespace, "error", res.Err)
+	}
+}
+
+// Get 依次读取本地缓存和 Redis，Redis 命中时回填本地缓存；均未命中时返回 KEY_NOT_FOUND
+func (c *TwoLevelCache[T]) Get(key string) CacheResult[T] {
+	if v, ok := c.local.get(key); ok {
+		c.localHits.Add(1)
+		return NewCacheResult(v)
+	}
+
+	res := getCached[T](c.rm, c.redisKey(key))
+	switch {
+	case res.IsOK():
+		c.remoteHits.Add(1)
+		c.local.set(key, res.Val)
+	case res.IsKeyNotFound():
+		c.misses.Add(1)
+	}
+	return res
+}
+
+// GetOrLoad 依次读取本地缓存和 Redis，均未命中时调用 loader 加载并写回两级缓存，opts 同 GetOrLoad
+func (c *TwoLevelCache[T]) GetOrLoad(key string, ttl time.Duration, loader LoaderFunc[T], opts *LoadOptions) CacheResult[T] {
+	if v, ok := c.local.get(key); ok {
+		c.localHits.Add(1)
+		return NewCacheResult(v)
+	}
+
+	res, source := getOrLoad(c.rm, c.redisKey(key), ttl, loader, opts)
+	if !res.IsOK() {
+		return res
+	}
+
+	switch source {
+	case sourceLoader:
+		c.misses.Add(1)
+	default:
+		c.remoteHits.Add(1)
+	}
+	// 逻辑过期的旧值只在 Redis 中等待后台刷新，不写入本地缓存，避免刷新后本地仍保留旧值
+	if source != sourceStale {
+		c.local.set(key, res.Val)
+	}
+	return res
+}
+
+// Set 写入两级缓存并通知其他实例移除本地条目
+func (c *TwoLevelCache[T]) Set(key string, v T, ttl time.Duration) CacheResult[bool] {
+	res := c.rm.SetWithTTL(c.redisKey(key), v, ttl)
+	if !res.IsOK() {
+		c.local.remove(key)
+		return NewCacheError[bool](res.ErrCode, res.Err)
+	}
+
+	c.local.set(key, v)
+	c.publish(cacheInvalidation{Keys: []string{key}})
+	return NewCacheResult(true)
+}
+
+// Delete 从两级缓存删除并通知其他实例，返回 Redis 中实际删除的键数量
+func (c *TwoLevelCache[T]) Delete(keys ...string) CacheResult[int64] {
+	if len(keys) == 0 {
+		return NewCacheResult(int64(0))
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		c.local.remove(key)
+		redisKeys[i] = c.redisKey(key)
+	}
+
+	res := c.rm.Del(redisKeys...)
+	c.publish(cacheInvalidation{Keys: keys})
+	return res
+}
+
+// InvalidateLocal 清空所有实例的本地缓存，Redis 中的数据不变
+func (c *TwoLevelCache[T]) InvalidateLocal() {
+	c.local.clear()
+	c.publish(cacheInvalidation{All: true})
+}
+
+// Stats 获取二级缓存统计
+func (c *TwoLevelCache[T]) Stats() TwoLevelCacheStats {
+	stats := TwoLevelCacheStats{
+		LocalHits:     c.localHits.Load(),
+		RemoteHits:    c.remoteHits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		LocalEntries:  c.local.len(),
+	}
+	if total := stats.LocalHits + stats.RemoteHits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.LocalHits+stats.RemoteHits) / float64(total)
+	}
+	return stats
+}
+
+// Close 停止订阅失效通知
+func (c *TwoLevelCache[T]) Close() error {
+	return c.sub.Close()
+}
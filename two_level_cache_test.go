@@ -0,0 +1,92 @@
+package redisx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// kvServer 只支持 GET/SET 的内存键值测试服务端
+type kvServer struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (s *kvServer) handle(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch args[0] {
+	case "get":
+		v, ok := s.data[args[1]]
+		if !ok {
+			return "_\r\n"
+		}
+		return bulk(v)
+	case "set":
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	}
+	return ""
+}
+
+// 逻辑过期的旧值由后台刷新，并发读取返回旧值但不写入本地缓存，统计不依赖 loader 的执行时机
+func TestTwoLevelCacheGetOrLoadStale(t *testing.T) {
+	s := &kvServer{data: make(map[string]string)}
+	rm := newTestManager(t, newFakeServer(t, s.handle).addr, nil)
+	c := &TwoLevelCache[string]{
+		rm:     rm,
+		config: TwoLevelCacheConfig{Namespace: "user:"},
+		local:  newLRUCache[string](10, time.Minute),
+	}
+
+	old, err := rm.encodeValue("user:1", cacheEntry[string]{Value: "old", Expiry: time.Now().Add(-time.Second).UnixMilli()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.data["user:1"] = string(old)
+
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (string, error) {
+		<-release
+		return "new", nil
+	}
+	opts := &LoadOptions{StaleGrace: time.Minute}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res := c.GetOrLoad("1", time.Minute, loader, opts); !res.IsOK() || res.Val != "old" {
+				t.Errorf("GetOrLoad = %q, %v, want stale value", res.Val, res.Err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, ok := c.local.get("1"); ok {
+		t.Fatal("stale value was written to local cache")
+	}
+
+	close(release)
+	// 等待后台刷新写回 Redis
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		res := c.GetOrLoad("1", time.Minute, loader, opts)
+		if res.Val == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetOrLoad = %q after refresh, want new value", res.Val)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if v, ok := c.local.get("1"); !ok || v != "new" {
+		t.Fatalf("local cache = %q, %v, want refreshed value", v, ok)
+	}
+
+	stats := c.Stats()
+	if stats.Misses != 0 || stats.RemoteHits < 9 {
+		t.Fatalf("stats = %+v, want only remote hits", stats)
+	}
+}
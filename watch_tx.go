@@ -0,0 +1,169 @@
+package redisx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTx WatchTx 闭包中的乐观事务
+// 读操作立即在监视键的连接上执行并返回 CacheResult；写操作加入 MULTI/EXEC 队列，闭包返回后原子提交，
+// 返回的命令在提交成功后才有结果
+type RedisTx struct {
+	ctx  context.Context
+	tx   *redis.Tx
+	pipe redis.Pipeliner
+}
+
+// txFuncError WatchTx 闭包返回的错误
+type txFuncError struct {
+	err error
+}
+
+func (e *txFuncError) Error() string {
+	return e.err.Error()
+}
+
+// WatchTx 监视 keys 执行乐观事务：fn 中读取当前值并排队写入，闭包返回后以 MULTI/EXEC 提交；
+// 监视的键在提交前被其他客户端修改时自动重新执行 fn，最多 Common.WatchTxAttempts 次
+// fn 返回错误时放弃提交，结果为 BREAK；重试次数用尽仍冲突时结果为 REDIS_INNER_ERROR（错误为 redis.TxFailedErr）
+// 集群模式下 keys 必须位于同一个哈希槽
+func (rm *RedisManager) WatchTx(keys []string, fn func(tx *RedisTx) error) CacheResult[bool] {
+	rm.stats.IncrTotal()
+
+	if !rm.acquire() {
+		return NewCacheError[bool](CONNECTION_FAILED, ErrConnectionFailed)
+	}
+	defer rm.release()
+
+	var err error
+	for attempt := 0; attempt < rm.config.Common.WatchTxAttempts; attempt++ {
+		err = rm.activeClient().Watch(rm.ctx, func(tx *redis.Tx) error {
+			rtx := &RedisTx{ctx: rm.ctx, tx: tx, pipe: tx.TxPipeline()}
+			if err := fn(rtx); err != nil {
+				return &txFuncError{err: err}
+			}
+			if rtx.pipe.Len() == 0 {
+				return nil
+			}
+			_, err := rtx.pipe.Exec(rm.ctx)
+			return err
+		}, keys...)
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+
+	if err != nil {
+		var fnErr *txFuncError
+		if errors.As(err, &fnErr) {
+			return NewCacheError[bool](BREAK, fnErr.err)
+		}
+		rm.stats.IncrError()
+		return NewCacheError[bool](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(true)
+}
+
+// ==== 读操作 ====
+
+// GetS 读取字符串值
+func (t *RedisTx) GetS(key string) CacheResult[string] {
+	val, err := t.tx.Get(t.ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// GetB 读取字节数组值
+func (t *RedisTx) GetB(key string) CacheResult[[]byte] {
+	val, err := t.tx.Get(t.ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[[]byte](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		return NewCacheError[[]byte](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// GetInt 读取整数值
+func (t *RedisTx) GetInt(key string) CacheResult[int64] {
+	val, err := t.tx.Get(t.ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[int64](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		return NewCacheError[int64](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// HGetS 读取哈希字段
+func (t *RedisTx) HGetS(key, field string) CacheResult[string] {
+	val, err := t.tx.HGet(t.ctx, key, field).Result()
+	if errors.Is(err, redis.Nil) {
+		return NewCacheError[string](KEY_NOT_FOUND, ErrKeyNotFound)
+	} else if err != nil {
+		return NewCacheError[string](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// HGetAll 读取所有哈希字段
+func (t *RedisTx) HGetAll(key string) CacheResult[map[string]string] {
+	val, err := t.tx.HGetAll(t.ctx, key).Result()
+	if err != nil {
+		return NewCacheError[map[string]string](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val)
+}
+
+// Exists 键是否存在
+func (t *RedisTx) Exists(key string) CacheResult[bool] {
+	val, err := t.tx.Exists(t.ctx, key).Result()
+	if err != nil {
+		return NewCacheError[bool](REDIS_INNER_ERROR, err)
+	}
+	return NewCacheResult(val > 0)
+}
+
+// ==== 写操作（提交时执行）====
+
+// SetS 设置字符串值
+func (t *RedisTx) SetS(key, value string, expiration time.Duration) *redis.StatusCmd {
+	return t.pipe.Set(t.ctx, key, value, expiration)
+}
+
+// SetB 设置字节数组值
+func (t *RedisTx) SetB(key string, value []byte, expiration time.Duration) *redis.StatusCmd {
+	return t.pipe.Set(t.ctx, key, value, expiration)
+}
+
+// Del 删除键
+func (t *RedisTx) Del(keys ...string) *redis.IntCmd {
+	return t.pipe.Del(t.ctx, keys...)
+}
+
+// IncrBy 整数值自增
+func (t *RedisTx) IncrBy(key string, value int64) *redis.IntCmd {
+	return t.pipe.IncrBy(t.ctx, key, value)
+}
+
+// HSet 设置哈希字段
+func (t *RedisTx) HSet(key string, values ...interface{}) *redis.IntCmd {
+	return t.pipe.HSet(t.ctx, key, values...)
+}
+
+// HDel 删除哈希字段
+func (t *RedisTx) HDel(key string, fields ...string) *redis.IntCmd {
+	return t.pipe.HDel(t.ctx, key, fields...)
+}
+
+// Expire 设置过期时间
+func (t *RedisTx) Expire(key string, expiration time.Duration) *redis.BoolCmd {
+	return t.pipe.Expire(t.ctx, key, expiration)
+}